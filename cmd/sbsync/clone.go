@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"storyblok-sync/internal/config"
+	"storyblok-sync/internal/core/clone"
+	"storyblok-sync/internal/core/transform"
+	"storyblok-sync/internal/infra/notify"
+	"storyblok-sync/internal/report"
+	"storyblok-sync/internal/sb"
+)
+
+// runClone implements `sbsync clone --from <id> --to <id>` and returns the exit code.
+func runClone(args []string, defaultReportFormat string) int {
+	fs := flag.NewFlagSet("clone", flag.ContinueOnError)
+	from := fs.Int("from", 0, "source space ID")
+	to := fs.Int("to", 0, "target space ID")
+	checkpoint := fs.String("checkpoint", "", "checkpoint file (default .sbsync/clone-<from>-<to>.json)")
+	reportFormat := fs.String("report-format", defaultReportFormat, "also write the report as html, md or junit")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *reportFormat != "" {
+		f, err := report.ParseFormat(*reportFormat)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "clone:", err)
+			return 2
+		}
+		*reportFormat = f
+	}
+	if *from <= 0 || *to <= 0 || *from == *to {
+		fmt.Fprintln(os.Stderr, "clone: --from and --to must be two different space IDs")
+		return 2
+	}
+	transforms, err := transform.FromEnv()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "clone:", err)
+		return 2
+	}
+
+	cfg, err := config.Load(config.DefaultPath())
+	if err != nil || cfg.Token == "" {
+		fmt.Fprintln(os.Stderr, "clone: no token found (SB_TOKEN or ~/.sbrc)")
+		return 1
+	}
+	api := sb.New(cfg.Token)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	src, tgt, err := lookupSpaces(ctx, api, *from, *to)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "clone:", err)
+		return 1
+	}
+	path := *checkpoint
+	if path == "" {
+		path = clone.DefaultCheckpointPath(src.ID, tgt.ID)
+	}
+	cp, err := clone.LoadCheckpoint(path, src.ID, tgt.ID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "clone:", err)
+		return 1
+	}
+	if cp.Resumed() {
+		fmt.Printf("Setze Klon %s → %s fort (Checkpoint %s)\n", src.Name, tgt.Name, path)
+	} else {
+		fmt.Printf("Klone %s (%d) → %s (%d)\n", src.Name, src.ID, tgt.Name, tgt.ID)
+	}
+
+	srcName, tgtName := fmt.Sprintf("%s (%d)", src.Name, src.ID), fmt.Sprintf("%s (%d)", tgt.Name, tgt.ID)
+	notify.Send(notify.Event{Event: notify.EventStarted, Time: time.Now(), SourceSpace: srcName, SourceSpaceID: src.ID, TargetSpace: tgtName, TargetSpaceID: tgt.ID})
+	failures := notify.Event{Event: notify.EventFailureThreshold, SourceSpace: srcName, SourceSpaceID: src.ID, TargetSpace: tgtName, TargetSpaceID: tgt.ID, Threshold: notify.Threshold()}
+
+	cl := clone.New(api, src, tgt, cp)
+	cl.SetTransforms(transforms)
+	cl.OnEntry(func(e clone.Entry) {
+		if e.Status == clone.StatusFailure && failures.Threshold > 0 {
+			failures.Summary.Failure++
+			failures.AddFailed(e.Name)
+			if failures.Summary.Failure == failures.Threshold {
+				failures.Time = time.Now()
+				failures.DurationMs = time.Since(cp.StartedAt).Milliseconds()
+				notify.Send(failures)
+			}
+		}
+		line := fmt.Sprintf("%-7s %-10s %-6s %s", e.Status, e.Kind, e.Operation, e.Name)
+		if e.Error != "" {
+			line += " – " + e.Error
+		} else if e.Warning != "" {
+			line += " – " + e.Warning
+		}
+		fmt.Println(line)
+	})
+	runErr := cl.Run(ctx)
+
+	rep := report.New(srcName, tgtName)
+	rep.SourceSpaceID, rep.TargetSpaceID = src.ID, tgt.ID
+	rep.StartTime = cp.StartedAt
+	for _, e := range cp.EntriesInOrder() {
+		rep.Add(report.Entry{Kind: e.Kind, Slug: e.Name, Status: e.Status, Operation: e.Operation, Error: e.Error, Warning: e.Warning, Duration: e.DurationMs})
+	}
+	if err := rep.Save(); err != nil {
+		fmt.Fprintln(os.Stderr, "clone: save report:", err)
+	}
+	if *reportFormat != "" && *reportFormat != report.FormatJSON {
+		if name, err := rep.Export(*reportFormat); err != nil {
+			fmt.Fprintln(os.Stderr, "clone: export report:", err)
+		} else {
+			fmt.Println("Report:", name)
+		}
+	}
+	fmt.Printf("Fertig: %s\n", rep.GetDisplaySummary())
+	if !errors.Is(runErr, context.Canceled) {
+		notify.Send(notify.FromReport(notify.EventCompleted, rep))
+	}
+
+	switch {
+	case errors.Is(runErr, context.Canceled):
+		fmt.Printf("Abgebrochen – erneut starten, um bei %s fortzusetzen\n", path)
+		return 130
+	case runErr != nil:
+		fmt.Fprintln(os.Stderr, "clone:", runErr)
+		fmt.Printf("Erneut starten, um bei %s fortzusetzen\n", path)
+		return 1
+	case cl.Failures() > 0:
+		fmt.Printf("%d Fehler – erneut starten, um fehlgeschlagene Elemente zu wiederholen (%s)\n", cl.Failures(), path)
+		return 1
+	}
+	if err := cp.Remove(); err != nil {
+		fmt.Fprintln(os.Stderr, "clone: remove checkpoint:", err)
+	}
+	return 0
+}
+
+// lookupSpaces resolves both space IDs to spaces with name and plan level.
+func lookupSpaces(ctx context.Context, api *sb.Client, fromID, toID int) (sb.Space, sb.Space, error) {
+	spaces, err := api.ListSpaces(ctx)
+	if err != nil {
+		return sb.Space{}, sb.Space{}, fmt.Errorf("list spaces: %w", err)
+	}
+	var src, tgt *sb.Space
+	for i := range spaces {
+		switch spaces[i].ID {
+		case fromID:
+			src = &spaces[i]
+		case toID:
+			tgt = &spaces[i]
+		}
+	}
+	if src == nil {
+		return sb.Space{}, sb.Space{}, fmt.Errorf("space %d not found", fromID)
+	}
+	if tgt == nil {
+		return sb.Space{}, sb.Space{}, fmt.Errorf("space %d not found", toID)
+	}
+	return *src, *tgt, nil
+}
@@ -12,7 +12,12 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"storyblok-sync/internal/infra/audit"
 	"storyblok-sync/internal/infra/logx"
+	"storyblok-sync/internal/infra/metricsx"
+	"storyblok-sync/internal/infra/notify"
+	"storyblok-sync/internal/infra/tracex"
+	"storyblok-sync/internal/report"
 	"storyblok-sync/internal/ui"
 )
 
@@ -22,6 +27,8 @@ func main() {
 
 	// Configure logging based on DEBUG environment variable
 	verboseFlag := flag.Bool("verbose", false, "log full story payloads and responses")
+	reportFormat := flag.String("report-format", "", "also write finished reports as html, md or junit")
+	metricsAddr := flag.String("metrics-addr", "", "serve OpenMetrics on this address under /metrics, e.g. :9090")
 	flag.Parse()
 
 	if len(os.Getenv("DEBUG")) > 0 {
@@ -57,8 +64,103 @@ func main() {
 		log.SetOutput(io.Discard)
 	}
 
+	// Audit log of all writes, independent of DEBUG
+	auditSink, err := audit.FromEnv()
+	if err != nil {
+		fmt.Println("fatal:", err)
+		os.Exit(1)
+	}
+	if auditSink != nil {
+		audit.SetSink(auditSink)
+		defer audit.Close()
+	}
+
+	// Tracing of sync runs, off unless SB_TRACE selects an exporter
+	traceExp, err := tracex.FromEnv()
+	if err != nil {
+		fmt.Println("fatal:", err)
+		os.Exit(1)
+	}
+	if traceExp != nil {
+		tracex.Install(traceExp)
+		defer tracex.Shutdown()
+	}
+
+	// Webhooks on run start, completion and failure threshold
+	notifier, err := notify.FromEnv()
+	if err != nil {
+		fmt.Println("fatal:", err)
+		os.Exit(1)
+	}
+	if notifier != nil {
+		notify.Install(notifier)
+		defer notify.Close()
+	}
+
+	// Metrics endpoint for long (headless) runs
+	if *metricsAddr != "" {
+		addr, stop, err := metricsx.Serve(*metricsAddr)
+		if err != nil {
+			fmt.Println("fatal: metrics:", err)
+			os.Exit(1)
+		}
+		defer stop()
+		fmt.Fprintf(os.Stderr, "metrics: http://%s/metrics\n", addr)
+	}
+
+	// Headless subcommands; retry preloads a report into the TUI
+	var retry *report.Report
+	switch flag.Arg(0) {
+	case "":
+	case "clone":
+		code := runClone(flag.Args()[1:], *reportFormat)
+		// os.Exit skips the deferred flushes
+		notify.Close()
+		tracex.Shutdown()
+		audit.Close()
+		os.Exit(code)
+	case "watch":
+		code := runWatch(flag.Args()[1:], *reportFormat)
+		notify.Close()
+		tracex.Shutdown()
+		audit.Close()
+		os.Exit(code)
+	case "serve":
+		code := runServe(flag.Args()[1:])
+		notify.Close()
+		tracex.Shutdown()
+		audit.Close()
+		os.Exit(code)
+	case "report-schema":
+		os.Stdout.Write(report.Schema)
+		os.Exit(0)
+	case "retry":
+		rep, code := loadRetryReport(flag.Args()[1:])
+		if rep == nil {
+			os.Exit(code)
+		}
+		retry = rep
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q (available: clone, watch, serve, retry, report-schema)\n", flag.Arg(0))
+		os.Exit(2)
+	}
+
+	// Interactive TUI
+	model := ui.InitialModel()
+	if *reportFormat != "" {
+		f, err := report.ParseFormat(*reportFormat)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		model = model.WithReportFormat(f)
+	}
+	if retry != nil {
+		model = model.RetryReport(flag.Arg(1), retry)
+	}
+
 	if _, err := tea.NewProgram(
-		ui.InitialModel(),
+		model,
 		tea.WithAltScreen(),
 	).Run(); err != nil {
 		fmt.Println("error:", err)
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"storyblok-sync/internal/report"
+)
+
+// loadRetryReport implements the argument handling of `sbsync retry
+// <report.json>`. It returns the report to preload into the TUI, or nil and
+// the exit code when there is nothing to retry.
+func loadRetryReport(args []string) (*report.Report, int) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: sbsync retry <sync-report.json>")
+		return nil, 2
+	}
+	rep, err := report.Load(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "retry:", err)
+		return nil, 1
+	}
+	if rep.Summary.Failure == 0 {
+		fmt.Printf("%s enthält keine Fehler\n", args[0])
+		return nil, 0
+	}
+	if src, tgt := rep.SpaceIDs(); src == 0 || tgt == 0 {
+		fmt.Fprintf(os.Stderr, "retry: %s names no source and target space\n", args[0])
+		return nil, 1
+	}
+	return rep, 0
+}
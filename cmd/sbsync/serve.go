@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"storyblok-sync/internal/config"
+	"storyblok-sync/internal/core/transform"
+	"storyblok-sync/internal/core/watch"
+	"storyblok-sync/internal/core/webhook"
+	"storyblok-sync/internal/report"
+	"storyblok-sync/internal/sb"
+)
+
+// runServe implements `sbsync serve --manifest sync.yaml --addr :8080` and
+// returns the exit code. It syncs the items of Storyblok webhooks until
+// SIGINT/SIGTERM, then drains the queue.
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	manifestPath := fs.String("manifest", "sync.yaml", "manifest with source, target and scope")
+	addr := fs.String("addr", ":8080", "listen address for /webhook and /healthz")
+	debounce := fs.Duration("debounce", 5*time.Second, "wait for further events of the same item before syncing")
+	queueSize := fs.Int("queue", 256, "maximum number of queued items")
+	drainTimeout := fs.Duration("drain-timeout", webhook.DefaultDrainTimeout, "how long a shutdown waits for queued items (0: until done)")
+	insecure := fs.Bool("insecure", false, "accept unsigned webhooks when SB_SERVE_SECRET is not set")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	m, err := watch.LoadManifest(*manifestPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "serve:", err)
+		return 2
+	}
+	secret := os.Getenv("SB_SERVE_SECRET")
+	if secret == "" && !*insecure {
+		fmt.Fprintln(os.Stderr, "serve: SB_SERVE_SECRET is not set (use --insecure to accept unsigned webhooks)")
+		return 2
+	}
+	transforms, err := transform.FromEnv()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "serve:", err)
+		return 2
+	}
+
+	cfg, err := config.Load(config.DefaultPath())
+	if err != nil || cfg.Token == "" {
+		fmt.Fprintln(os.Stderr, "serve: no token found (SB_TOKEN or ~/.sbrc)")
+		return 1
+	}
+	api := sb.New(cfg.Token)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	src, tgt, err := lookupSpaces(ctx, api, m.Source, m.Target)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "serve:", err)
+		return 1
+	}
+
+	syncer := webhook.NewSyncer(api, m, src, tgt)
+	syncer.SetTransforms(transforms)
+	srv := webhook.NewServer(secret, webhook.NewQueue(*debounce, *queueSize), syncer)
+	srv.SetDrainTimeout(*drainTimeout)
+	srv.OnEntry(func(ev webhook.Event, e report.Entry) {
+		fmt.Printf("%s %-12s ", time.Now().Format("15:04:05"), ev.Action)
+		printEntry(e)
+	})
+	fmt.Printf("Empfange Webhooks für %s (%d) → %s (%d) auf %s\n", src.Name, src.ID, tgt.Name, tgt.ID, *addr)
+	if err := srv.Serve(ctx, *addr); err != nil {
+		fmt.Fprintln(os.Stderr, "serve:", err)
+		return 1
+	}
+	h := srv.Health()
+	fmt.Printf("Beendet – %d synchronisiert, %d fehlgeschlagen\n", h.Synced, h.Failed)
+	return 0
+}
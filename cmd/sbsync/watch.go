@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"storyblok-sync/internal/config"
+	"storyblok-sync/internal/core/transform"
+	"storyblok-sync/internal/core/watch"
+	"storyblok-sync/internal/infra/notify"
+	"storyblok-sync/internal/report"
+	"storyblok-sync/internal/sb"
+)
+
+// defaultWatchInterval applies when neither --interval nor the manifest set one.
+const defaultWatchInterval = 5 * time.Minute
+
+// runWatch implements `sbsync watch --manifest sync.yaml --interval 5m` and
+// returns the exit code. It syncs changed stories every interval until
+// SIGINT/SIGTERM, finishing the current item first.
+func runWatch(args []string, defaultReportFormat string) int {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	manifestPath := fs.String("manifest", "sync.yaml", "manifest with source, target and scope")
+	interval := fs.Duration("interval", 0, "time between cycles (default: manifest interval or 5m)")
+	once := fs.Bool("once", false, "run a single cycle and exit")
+	reportFormat := fs.String("report-format", defaultReportFormat, "also write cycle reports as html, md or junit")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *reportFormat != "" {
+		f, err := report.ParseFormat(*reportFormat)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "watch:", err)
+			return 2
+		}
+		*reportFormat = f
+	}
+	m, err := watch.LoadManifest(*manifestPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "watch:", err)
+		return 2
+	}
+	every := *interval
+	if every <= 0 {
+		every = m.Interval
+	}
+	if every <= 0 {
+		every = defaultWatchInterval
+	}
+	transforms, err := transform.FromEnv()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "watch:", err)
+		return 2
+	}
+
+	cfg, err := config.Load(config.DefaultPath())
+	if err != nil || cfg.Token == "" {
+		fmt.Fprintln(os.Stderr, "watch: no token found (SB_TOKEN or ~/.sbrc)")
+		return 1
+	}
+	api := sb.New(cfg.Token)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	src, tgt, err := lookupSpaces(ctx, api, m.Source, m.Target)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "watch:", err)
+		return 1
+	}
+	st, err := watch.LoadState(m.StatePath(), src.ID, tgt.ID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "watch:", err)
+		return 1
+	}
+
+	r := watch.New(api, m, src, tgt, st)
+	r.SetTransforms(transforms)
+	r.OnEntry(printEntry)
+	onCycle := func(rep *report.Report, err error) {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			fmt.Fprintln(os.Stderr, "watch:", err)
+		}
+		if rep == nil || len(rep.Entries) == 0 {
+			return
+		}
+		writeCycleReport(rep, *reportFormat)
+		notify.Send(notify.FromReport(notify.EventCompleted, rep))
+		if failures := notify.Threshold(); failures > 0 && rep.Summary.Failure >= failures {
+			ev := notify.FromReport(notify.EventFailureThreshold, rep)
+			ev.Threshold = failures
+			notify.Send(ev)
+		}
+	}
+
+	if *once {
+		rep, err := r.RunOnce(ctx)
+		onCycle(rep, err)
+		switch {
+		case errors.Is(err, context.Canceled):
+			return 130
+		case err != nil || (rep != nil && rep.Summary.Failure > 0):
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Printf("Beobachte %s (%d) → %s (%d) alle %s (Status %s)\n", src.Name, src.ID, tgt.Name, tgt.ID, every, m.StatePath())
+	if err := r.Run(ctx, every, onCycle); !errors.Is(err, context.Canceled) {
+		fmt.Fprintln(os.Stderr, "watch:", err)
+		return 1
+	}
+	fmt.Println("Beendet – Status gespeichert")
+	return 0
+}
+
+// printEntry prints one synced item like clone does.
+func printEntry(e report.Entry) {
+	line := fmt.Sprintf("%-7s %-10s %-6s %s", e.Status, e.Kind, e.Operation, e.Slug)
+	if e.Error != "" {
+		line += " – " + e.Error
+	} else if e.Warning != "" {
+		line += " – " + e.Warning
+	}
+	fmt.Println(line)
+}
+
+// writeCycleReport saves the report of a cycle with changes.
+func writeCycleReport(rep *report.Report, format string) {
+	if err := rep.Save(); err != nil {
+		fmt.Fprintln(os.Stderr, "watch: save report:", err)
+	}
+	if format != "" && format != report.FormatJSON {
+		if name, err := rep.Export(format); err != nil {
+			fmt.Fprintln(os.Stderr, "watch: export report:", err)
+		} else {
+			fmt.Println("Report:", name)
+		}
+	}
+	fmt.Printf("%s Zyklus fertig: %s\n", time.Now().Format("15:04:05"), rep.GetDisplaySummary())
+}
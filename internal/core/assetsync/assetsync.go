@@ -0,0 +1,99 @@
+// Package assetsync syncs asset metadata between spaces. Asset files are not
+// copied: source and target assets are matched by file name, and the internal
+// tags of the source asset are reconciled onto its target counterpart.
+package assetsync
+
+import (
+	"context"
+	"path"
+	"sort"
+
+	"storyblok-sync/internal/core/tagsync"
+	"storyblok-sync/internal/sb"
+)
+
+// API is the client subset for asset metadata.
+type API interface {
+	ListAssets(ctx context.Context, spaceID int) ([]sb.Asset, error)
+	UpdateAssetInternalTags(ctx context.Context, spaceID, assetID int, tagIDs []int) error
+}
+
+// Pair is a source asset and the target asset with the same file name.
+type Pair struct {
+	Source sb.Asset
+	Target sb.Asset
+}
+
+// Name returns the file name the assets were matched by.
+func (p Pair) Name() string { return FileName(p.Source.Filename) }
+
+// FileName returns the last path segment of an asset URL, which is the same
+// in every space while the path before it is space specific.
+func FileName(filename string) string {
+	return path.Base(filename)
+}
+
+// Match pairs source assets with target assets of the same file name, in
+// source order. File names that occur more than once in the target are
+// ambiguous and left unmatched, like source assets missing in the target.
+func Match(source, target []sb.Asset) (pairs []Pair, unmatched []sb.Asset) {
+	byName := make(map[string]sb.Asset, len(target))
+	dupes := make(map[string]bool)
+	for _, a := range target {
+		n := FileName(a.Filename)
+		if _, ok := byName[n]; ok {
+			dupes[n] = true
+		}
+		byName[n] = a
+	}
+	for _, a := range source {
+		n := FileName(a.Filename)
+		t, ok := byName[n]
+		if !ok || dupes[n] {
+			unmatched = append(unmatched, a)
+			continue
+		}
+		pairs = append(pairs, Pair{Source: a, Target: t})
+	}
+	return pairs, unmatched
+}
+
+// SyncTags makes sure the source asset's internal tags exist in the target
+// (object type asset) and sets them on the target asset when its tags differ.
+// It reports whether the target asset was updated.
+func SyncTags(ctx context.Context, api API, r *tagsync.Reconciler, targetSpaceID int, p Pair) (bool, error) {
+	names := make([]string, 0, len(p.Source.InternalTagsList))
+	for _, t := range p.Source.InternalTagsList {
+		names = append(names, t.Name)
+	}
+	ids, _, err := r.EnsureInternalTags(ctx, tagsync.ObjectTypeAsset, names)
+	if err != nil {
+		return false, err
+	}
+	want := make([]int, 0, len(ids))
+	for _, id := range ids {
+		want = append(want, id)
+	}
+	sort.Ints(want)
+	have := append([]int(nil), p.Target.InternalTagIDs...)
+	sort.Ints(have)
+	if equalInts(want, have) {
+		return false, nil
+	}
+	if err := api.UpdateAssetInternalTags(ctx, targetSpaceID, p.Target.ID, want); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
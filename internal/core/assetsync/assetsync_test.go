@@ -0,0 +1,80 @@
+package assetsync
+
+import (
+	"context"
+	"testing"
+
+	"storyblok-sync/internal/core/tagsync"
+	"storyblok-sync/internal/sb"
+)
+
+type fakeAPI struct {
+	tags    []sb.InternalTag
+	updates map[int][]int
+}
+
+func (f *fakeAPI) ListInternalTags(_ context.Context, _ int) ([]sb.InternalTag, error) {
+	return f.tags, nil
+}
+
+func (f *fakeAPI) CreateInternalTag(_ context.Context, _ int, name, objectType string) (sb.InternalTag, error) {
+	t := sb.InternalTag{ID: 100 + len(f.tags), Name: name, ObjectType: objectType}
+	f.tags = append(f.tags, t)
+	return t, nil
+}
+
+func (f *fakeAPI) ListAssets(_ context.Context, _ int) ([]sb.Asset, error) { return nil, nil }
+
+func (f *fakeAPI) UpdateAssetInternalTags(_ context.Context, _ int, assetID int, tagIDs []int) error {
+	f.updates[assetID] = tagIDs
+	return nil
+}
+
+func TestMatchSkipsMissingAndAmbiguousNames(t *testing.T) {
+	src := []sb.Asset{
+		{ID: 1, Filename: "https://a.storyblok.com/f/1/a/logo.svg"},
+		{ID: 2, Filename: "https://a.storyblok.com/f/1/b/hero.png"},
+		{ID: 3, Filename: "https://a.storyblok.com/f/1/c/missing.png"},
+	}
+	tgt := []sb.Asset{
+		{ID: 10, Filename: "https://a.storyblok.com/f/2/x/logo.svg"},
+		{ID: 11, Filename: "https://a.storyblok.com/f/2/y/hero.png"},
+		{ID: 12, Filename: "https://a.storyblok.com/f/2/z/hero.png"},
+	}
+	pairs, unmatched := Match(src, tgt)
+	if len(pairs) != 1 || pairs[0].Target.ID != 10 || pairs[0].Name() != "logo.svg" {
+		t.Fatalf("pairs = %+v", pairs)
+	}
+	if len(unmatched) != 2 || unmatched[0].ID != 2 || unmatched[1].ID != 3 {
+		t.Fatalf("unmatched = %+v", unmatched)
+	}
+}
+
+func TestSyncTagsUpdatesOnlyOnDifference(t *testing.T) {
+	api := &fakeAPI{
+		tags:    []sb.InternalTag{{ID: 5, Name: "brand", ObjectType: tagsync.ObjectTypeAsset}},
+		updates: map[int][]int{},
+	}
+	r := tagsync.NewReconciler(nil, api, 2)
+	p := Pair{
+		Source: sb.Asset{ID: 1, InternalTagsList: []sb.InternalTag{{Name: "brand"}, {Name: "hero"}}},
+		Target: sb.Asset{ID: 10, InternalTagIDs: sb.IntSlice{5}},
+	}
+	updated, err := SyncTags(context.Background(), api, r, 2, p)
+	if err != nil || !updated {
+		t.Fatalf("SyncTags = %v, %v", updated, err)
+	}
+	got := api.updates[10]
+	if len(got) != 2 || got[0] != 5 || got[1] != api.tags[1].ID || api.tags[1].ObjectType != tagsync.ObjectTypeAsset {
+		t.Fatalf("updated tags = %v, tags = %+v", got, api.tags)
+	}
+
+	p.Target.InternalTagIDs = sb.IntSlice(got)
+	delete(api.updates, 10)
+	if updated, err := SyncTags(context.Background(), api, r, 2, p); err != nil || updated {
+		t.Fatalf("second SyncTags = %v, %v", updated, err)
+	}
+	if _, ok := api.updates[10]; ok {
+		t.Fatal("unchanged asset must not be written")
+	}
+}
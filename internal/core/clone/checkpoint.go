@@ -0,0 +1,134 @@
+package clone
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultCheckpointDir is where checkpoints are stored relative to the working directory.
+const DefaultCheckpointDir = ".sbsync"
+
+// DefaultCheckpointPath returns the checkpoint file of a clone run from one space to another.
+func DefaultCheckpointPath(fromID, toID int) string {
+	return filepath.Join(DefaultCheckpointDir, fmt.Sprintf("clone-%d-%d.json", fromID, toID))
+}
+
+// Checkpoint records the finished items and all report entries of a clone
+// run. It is rewritten atomically after every item, so an interrupted run
+// resumes with the first unfinished item and still ends with one report.
+type Checkpoint struct {
+	path string
+	mu   sync.Mutex
+
+	From      int              `json:"from"`
+	To        int              `json:"to"`
+	StartedAt time.Time        `json:"started_at"`
+	Done      map[string]bool  `json:"done"`
+	Entries   map[string]Entry `json:"entries"`
+	Order     []string         `json:"order"` // entry keys in first-seen order
+}
+
+// LoadCheckpoint reads the checkpoint at path or starts a new one when the
+// file does not exist. A checkpoint of another space pair is an error.
+func LoadCheckpoint(path string, fromID, toID int) (*Checkpoint, error) {
+	cp := &Checkpoint{
+		path:      path,
+		From:      fromID,
+		To:        toID,
+		StartedAt: time.Now(),
+		Done:      make(map[string]bool),
+		Entries:   make(map[string]Entry),
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("checkpoint %s: %w", path, err)
+	}
+	if cp.From != fromID || cp.To != toID {
+		return nil, fmt.Errorf("checkpoint %s belongs to clone %d → %d", path, cp.From, cp.To)
+	}
+	if cp.Done == nil {
+		cp.Done = make(map[string]bool)
+	}
+	if cp.Entries == nil {
+		cp.Entries = make(map[string]Entry)
+	}
+	return cp, nil
+}
+
+// Resumed reports whether the checkpoint already holds results of an earlier run.
+func (cp *Checkpoint) Resumed() bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return len(cp.Order) > 0
+}
+
+// IsDone reports whether the item with the given key was finished before.
+func (cp *Checkpoint) IsDone(key string) bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.Done[key]
+}
+
+// Record stores the entry of an item, replacing an earlier attempt, marks
+// the item finished unless it failed, and persists the checkpoint.
+func (cp *Checkpoint) Record(key string, e Entry) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if _, ok := cp.Entries[key]; !ok {
+		cp.Order = append(cp.Order, key)
+	}
+	cp.Entries[key] = e
+	if e.Status != StatusFailure {
+		cp.Done[key] = true
+	}
+	return cp.save()
+}
+
+// EntriesInOrder returns all recorded entries in the order items were first seen.
+func (cp *Checkpoint) EntriesInOrder() []Entry {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	out := make([]Entry, 0, len(cp.Order))
+	for _, k := range cp.Order {
+		out = append(out, cp.Entries[k])
+	}
+	return out
+}
+
+// Remove deletes the checkpoint file after a run without failures.
+func (cp *Checkpoint) Remove() error {
+	if err := os.Remove(cp.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// save writes the checkpoint, replacing the previous file atomically.
+func (cp *Checkpoint) save() error {
+	if cp.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(cp.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := cp.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, cp.path)
+}
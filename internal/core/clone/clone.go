@@ -0,0 +1,201 @@
+// Package clone copies everything sbsync knows about from one space into
+// another: component groups, internal tags, components with presets,
+// datasources, the internal tags of assets present in both spaces, folders
+// and stories. Progress is kept in a Checkpoint so an
+// interrupted clone can be resumed.
+package clone
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	comps "storyblok-sync/internal/core/componentsync"
+	synccore "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/core/tagsync"
+	"storyblok-sync/internal/core/transform"
+	"storyblok-sync/internal/report"
+	"storyblok-sync/internal/sb"
+)
+
+// Entry kinds, shared with the sync report
+const (
+	KindGroup      = report.KindGroup
+	KindTag        = report.KindTag
+	KindComponent  = report.KindComponent
+	KindPreset     = report.KindPreset
+	KindDatasource = report.KindDatasource
+	KindFolder     = report.KindFolder
+	KindStory      = report.KindStory
+	KindAsset      = report.KindAsset
+)
+
+// Entry statuses, matching the sync report
+const (
+	StatusSuccess = "success"
+	StatusWarning = "warning"
+	StatusFailure = "failure"
+)
+
+// Entry is the outcome of one cloned item.
+type Entry struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"` // full slug, group path, component or datasource name
+	Operation  string `json:"operation"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	Warning    string `json:"warning,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+}
+
+// API is the client subset a clone needs.
+type API interface {
+	synccore.SyncAPI
+	comps.GroupAPI
+	tagsync.StoryTagAPI
+	tagsync.InternalTagAPI
+	ListStories(ctx context.Context, opt sb.ListStoriesOpts) ([]sb.Story, error)
+	UnpublishStory(ctx context.Context, spaceID, storyID int) error
+	ListComponents(ctx context.Context, spaceID int) ([]sb.Component, error)
+	CreateComponent(ctx context.Context, spaceID int, comp sb.Component) (sb.Component, error)
+	UpdateComponent(ctx context.Context, spaceID int, comp sb.Component) (sb.Component, error)
+	ListPresets(ctx context.Context, spaceID int) ([]sb.ComponentPreset, error)
+	CreatePreset(ctx context.Context, spaceID int, p sb.ComponentPreset) (sb.ComponentPreset, error)
+	UpdatePreset(ctx context.Context, spaceID int, p sb.ComponentPreset) (sb.ComponentPreset, error)
+	ListDatasources(ctx context.Context, spaceID int) ([]sb.Datasource, error)
+	CreateDatasource(ctx context.Context, spaceID int, ds sb.Datasource) (sb.Datasource, error)
+	ListDatasourceEntries(ctx context.Context, spaceID, datasourceID int) ([]sb.DatasourceEntry, error)
+	CreateDatasourceEntry(ctx context.Context, spaceID int, e sb.DatasourceEntry) (sb.DatasourceEntry, error)
+	UpdateDatasourceEntry(ctx context.Context, spaceID int, e sb.DatasourceEntry) error
+}
+
+// Cloner copies one space into another, step by step in dependency order.
+type Cloner struct {
+	api      API
+	source   sb.Space
+	target   sb.Space
+	cp       *Checkpoint
+	limiter  *synccore.SpaceLimiter
+	progress func(Entry)
+	only     string // item key a single-item sync is restricted to
+
+	transforms *transform.Pipeline
+}
+
+// New creates a cloner writing its progress to cp.
+func New(api API, source, target sb.Space, cp *Checkpoint) *Cloner {
+	return &Cloner{
+		api:     api,
+		source:  source,
+		target:  target,
+		cp:      cp,
+		limiter: synccore.NewFanOutLimiter(source, target),
+	}
+}
+
+// OnEntry registers a callback invoked for every recorded entry.
+func (c *Cloner) OnEntry(fn func(Entry)) {
+	c.progress = fn
+}
+
+// SetTransforms rewrites story payloads with the pipeline before each write.
+func (c *Cloner) SetTransforms(p *transform.Pipeline) {
+	c.transforms = p
+}
+
+// Run executes all steps. Items finished in an earlier run are skipped.
+// Item failures are recorded and left for the next run; an error is only
+// returned when a step cannot proceed at all (e.g. listing fails).
+func (c *Cloner) Run(ctx context.Context) error {
+	steps := []struct {
+		name string
+		run  func(context.Context) error
+	}{
+		{"groups", c.cloneGroups},
+		{"tags", c.cloneInternalTags},
+		{"components", c.cloneComponents},
+		{"datasources", c.cloneDatasources},
+		{"assets", c.cloneAssetTags},
+		{"stories", c.cloneStories},
+	}
+	for _, s := range steps {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.run(ctx); err != nil {
+			return fmt.Errorf("%s: %w", s.name, err)
+		}
+	}
+	return nil
+}
+
+// SyncComponent creates or updates one component and its presets, e.g. after
+// a webhook reported a change. Groups and tags must exist in the target.
+func (c *Cloner) SyncComponent(ctx context.Context, name string) error {
+	return c.syncOne(ctx, KindComponent, name, c.cloneComponents)
+}
+
+// SyncDatasource creates one datasource if missing and copies its entries.
+func (c *Cloner) SyncDatasource(ctx context.Context, slug string) error {
+	return c.syncOne(ctx, KindDatasource, slug, c.cloneDatasources)
+}
+
+// syncOne runs a step restricted to one item.
+func (c *Cloner) syncOne(ctx context.Context, kind, name string, step func(context.Context) error) error {
+	key := itemKey(kind, name)
+	c.only = key
+	defer func() { c.only = "" }()
+	if err := step(ctx); err != nil {
+		return err
+	}
+	c.cp.mu.Lock()
+	_, found := c.cp.Entries[key]
+	c.cp.mu.Unlock()
+	if !found {
+		return fmt.Errorf("%s %q not found in source space", kind, name)
+	}
+	return nil
+}
+
+// skip reports whether an item was finished before or is outside a single-item sync.
+func (c *Cloner) skip(key string) bool {
+	return c.cp.IsDone(key) || (c.only != "" && key != c.only)
+}
+
+// Failures counts failed entries of the run so far.
+func (c *Cloner) Failures() int {
+	n := 0
+	for _, e := range c.cp.EntriesInOrder() {
+		if e.Status == StatusFailure {
+			n++
+		}
+	}
+	return n
+}
+
+// record stores an item's outcome in the checkpoint.
+func (c *Cloner) record(key string, e Entry) error {
+	if err := c.cp.Record(key, e); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	if c.progress != nil {
+		c.progress(e)
+	}
+	return nil
+}
+
+// result builds an entry from an item's error and warning.
+func result(kind, name, op string, start time.Time, err error, warning string) Entry {
+	e := Entry{Kind: kind, Name: name, Operation: op, Status: StatusSuccess, DurationMs: time.Since(start).Milliseconds()}
+	switch {
+	case err != nil:
+		e.Status = StatusFailure
+		e.Error = err.Error()
+	case warning != "":
+		e.Status = StatusWarning
+		e.Warning = warning
+	}
+	return e
+}
+
+func itemKey(kind, name string) string { return kind + ":" + name }
@@ -0,0 +1,487 @@
+package clone
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"storyblok-sync/internal/sb"
+)
+
+// fakeSpace is the in-memory content of one space.
+type fakeSpace struct {
+	stories     []sb.Story
+	raw         map[int]map[string]interface{}
+	groups      []sb.ComponentGroup
+	tags        []sb.Tag
+	internal    []sb.InternalTag
+	components  []sb.Component
+	presets     []sb.ComponentPreset
+	datasources []sb.Datasource
+	entries     map[int][]sb.DatasourceEntry
+}
+
+// fakeAPI implements API over in-memory spaces.
+type fakeAPI struct {
+	spaces    map[int]*fakeSpace
+	nextID    int
+	failSlugs map[string]bool // story creates failing once
+	creates   map[string]int  // story/folder creates per full slug
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{spaces: map[int]*fakeSpace{}, nextID: 1000, failSlugs: map[string]bool{}, creates: map[string]int{}}
+}
+
+func (f *fakeAPI) space(id int) *fakeSpace {
+	if f.spaces[id] == nil {
+		f.spaces[id] = &fakeSpace{raw: map[int]map[string]interface{}{}, entries: map[int][]sb.DatasourceEntry{}}
+	}
+	return f.spaces[id]
+}
+
+func (f *fakeAPI) id() int { f.nextID++; return f.nextID }
+
+func (f *fakeAPI) addStory(spaceID int, st sb.Story) {
+	sp := f.space(spaceID)
+	sp.stories = append(sp.stories, st)
+	sp.raw[st.ID] = map[string]interface{}{
+		"id": st.ID, "uuid": st.UUID, "name": st.Name, "slug": st.Slug, "full_slug": st.FullSlug,
+		"is_folder": st.IsFolder, "content": map[string]interface{}{"component": "page"},
+	}
+}
+
+func (f *fakeAPI) GetStoriesBySlug(_ context.Context, spaceID int, slug string) ([]sb.Story, error) {
+	for _, st := range f.space(spaceID).stories {
+		if st.FullSlug == slug {
+			return []sb.Story{st}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeAPI) GetStoryWithContent(_ context.Context, spaceID, storyID int) (sb.Story, error) {
+	for _, st := range f.space(spaceID).stories {
+		if st.ID == storyID {
+			return st, nil
+		}
+	}
+	return sb.Story{}, errors.New("not found")
+}
+
+func (f *fakeAPI) UpdateStoryUUID(_ context.Context, spaceID, storyID int, uuid string) error {
+	sp := f.space(spaceID)
+	for i := range sp.stories {
+		if sp.stories[i].ID == storyID {
+			sp.stories[i].UUID = uuid
+		}
+	}
+	return nil
+}
+
+func (f *fakeAPI) GetStoryRaw(_ context.Context, spaceID, storyID int) (map[string]interface{}, error) {
+	raw, ok := f.space(spaceID).raw[storyID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	out := make(map[string]interface{}, len(raw))
+	for k, v := range raw {
+		out[k] = v
+	}
+	return out, nil
+}
+
+func (f *fakeAPI) CreateStoryRawWithPublish(_ context.Context, spaceID int, story map[string]interface{}, publish bool) (sb.Story, error) {
+	slug, _ := story["full_slug"].(string)
+	f.creates[slug]++
+	if f.failSlugs[slug] {
+		delete(f.failSlugs, slug)
+		return sb.Story{}, errors.New("boom")
+	}
+	st := sb.Story{ID: f.id(), Slug: story["slug"].(string), FullSlug: slug, Published: publish}
+	st.IsFolder, _ = story["is_folder"].(bool)
+	if pid, ok := story["parent_id"].(int); ok && pid > 0 {
+		st.FolderID = &pid
+	}
+	f.space(spaceID).stories = append(f.space(spaceID).stories, st)
+	return st, nil
+}
+
+func (f *fakeAPI) UpdateStoryRawWithPublish(_ context.Context, spaceID int, storyID int, story map[string]interface{}, publish bool) (sb.Story, error) {
+	sp := f.space(spaceID)
+	for i := range sp.stories {
+		if sp.stories[i].ID == storyID {
+			sp.stories[i].Published = publish
+			return sp.stories[i], nil
+		}
+	}
+	return sb.Story{}, errors.New("not found")
+}
+
+func (f *fakeAPI) ListStories(_ context.Context, opt sb.ListStoriesOpts) ([]sb.Story, error) {
+	return append([]sb.Story(nil), f.space(opt.SpaceID).stories...), nil
+}
+
+func (f *fakeAPI) UnpublishStory(_ context.Context, spaceID, storyID int) error {
+	sp := f.space(spaceID)
+	for i := range sp.stories {
+		if sp.stories[i].ID == storyID {
+			sp.stories[i].Published = false
+		}
+	}
+	return nil
+}
+
+func (f *fakeAPI) ListComponentGroups(_ context.Context, spaceID int) ([]sb.ComponentGroup, error) {
+	return append([]sb.ComponentGroup(nil), f.space(spaceID).groups...), nil
+}
+
+func (f *fakeAPI) CreateComponentGroup(_ context.Context, spaceID int, name string) (sb.ComponentGroup, error) {
+	g := sb.ComponentGroup{ID: f.id(), Name: name, UUID: "g-" + name}
+	f.space(spaceID).groups = append(f.space(spaceID).groups, g)
+	return g, nil
+}
+
+func (f *fakeAPI) ListTags(_ context.Context, spaceID int) ([]sb.Tag, error) {
+	return f.space(spaceID).tags, nil
+}
+
+func (f *fakeAPI) CreateTag(_ context.Context, spaceID int, name string) (sb.Tag, error) {
+	t := sb.Tag{ID: f.id(), Name: name}
+	f.space(spaceID).tags = append(f.space(spaceID).tags, t)
+	return t, nil
+}
+
+func (f *fakeAPI) ListInternalTags(_ context.Context, spaceID int) ([]sb.InternalTag, error) {
+	return append([]sb.InternalTag(nil), f.space(spaceID).internal...), nil
+}
+
+func (f *fakeAPI) CreateInternalTag(_ context.Context, spaceID int, name string, objectType string) (sb.InternalTag, error) {
+	t := sb.InternalTag{ID: f.id(), Name: name, ObjectType: objectType}
+	f.space(spaceID).internal = append(f.space(spaceID).internal, t)
+	return t, nil
+}
+
+func (f *fakeAPI) ListComponents(_ context.Context, spaceID int) ([]sb.Component, error) {
+	return append([]sb.Component(nil), f.space(spaceID).components...), nil
+}
+
+func (f *fakeAPI) CreateComponent(_ context.Context, spaceID int, comp sb.Component) (sb.Component, error) {
+	comp.ID = f.id()
+	f.space(spaceID).components = append(f.space(spaceID).components, comp)
+	return comp, nil
+}
+
+func (f *fakeAPI) UpdateComponent(_ context.Context, spaceID int, comp sb.Component) (sb.Component, error) {
+	sp := f.space(spaceID)
+	for i := range sp.components {
+		if sp.components[i].ID == comp.ID {
+			sp.components[i] = comp
+		}
+	}
+	return comp, nil
+}
+
+func (f *fakeAPI) ListPresets(_ context.Context, spaceID int) ([]sb.ComponentPreset, error) {
+	return append([]sb.ComponentPreset(nil), f.space(spaceID).presets...), nil
+}
+
+func (f *fakeAPI) CreatePreset(_ context.Context, spaceID int, p sb.ComponentPreset) (sb.ComponentPreset, error) {
+	p.ID = f.id()
+	f.space(spaceID).presets = append(f.space(spaceID).presets, p)
+	return p, nil
+}
+
+func (f *fakeAPI) UpdatePreset(_ context.Context, _ int, p sb.ComponentPreset) (sb.ComponentPreset, error) {
+	return p, nil
+}
+
+func (f *fakeAPI) ListDatasources(_ context.Context, spaceID int) ([]sb.Datasource, error) {
+	return append([]sb.Datasource(nil), f.space(spaceID).datasources...), nil
+}
+
+func (f *fakeAPI) CreateDatasource(_ context.Context, spaceID int, ds sb.Datasource) (sb.Datasource, error) {
+	ds.ID = f.id()
+	f.space(spaceID).datasources = append(f.space(spaceID).datasources, ds)
+	return ds, nil
+}
+
+func (f *fakeAPI) ListDatasourceEntries(_ context.Context, spaceID, datasourceID int) ([]sb.DatasourceEntry, error) {
+	return append([]sb.DatasourceEntry(nil), f.space(spaceID).entries[datasourceID]...), nil
+}
+
+func (f *fakeAPI) CreateDatasourceEntry(_ context.Context, spaceID int, e sb.DatasourceEntry) (sb.DatasourceEntry, error) {
+	e.ID = f.id()
+	sp := f.space(spaceID)
+	sp.entries[e.DatasourceID] = append(sp.entries[e.DatasourceID], e)
+	return e, nil
+}
+
+func (f *fakeAPI) UpdateDatasourceEntry(_ context.Context, spaceID int, e sb.DatasourceEntry) error {
+	for id, list := range f.space(spaceID).entries {
+		for i := range list {
+			if list[i].ID == e.ID {
+				f.spaces[spaceID].entries[id][i] = e
+			}
+		}
+	}
+	return nil
+}
+
+func seedSource(f *fakeAPI) {
+	src := f.space(1)
+	src.groups = []sb.ComponentGroup{{ID: 1, Name: "Layout", UUID: "g-src"}}
+	src.internal = []sb.InternalTag{{ID: 2, Name: "core", ObjectType: "component"}}
+	src.components = []sb.Component{{ID: 3, Name: "hero", ComponentGroupUUID: "g-src", InternalTagsList: []sb.InternalTag{{Name: "core"}}}}
+	src.presets = []sb.ComponentPreset{{ID: 4, Name: "dark", ComponentID: 3}}
+	src.datasources = []sb.Datasource{{ID: 5, Name: "Colors", Slug: "colors"}}
+	src.entries[5] = []sb.DatasourceEntry{{ID: 6, Name: "red", Value: "#f00", DatasourceID: 5}, {ID: 7, Name: "blue", Value: "#00f", DatasourceID: 5}}
+	folderID := 10
+	f.addStory(1, sb.Story{ID: 11, UUID: "u-page", Name: "page", Slug: "page", FullSlug: "app/page", FolderID: &folderID, Published: true})
+	f.addStory(1, sb.Story{ID: 12, UUID: "u-draft", Name: "draft", Slug: "draft", FullSlug: "app/draft", FolderID: &folderID})
+	f.addStory(1, sb.Story{ID: 10, UUID: "u-app", Name: "app", Slug: "app", FullSlug: "app", IsFolder: true})
+}
+
+func findStory(t *testing.T, stories []sb.Story, slug string) sb.Story {
+	t.Helper()
+	for _, st := range stories {
+		if st.FullSlug == slug {
+			return st
+		}
+	}
+	t.Fatalf("story %s not found in %+v", slug, stories)
+	return sb.Story{}
+}
+
+func TestClonerCopiesEverything(t *testing.T) {
+	f := newFakeAPI()
+	seedSource(f)
+	// Target already has the datasource with an outdated value
+	tgt := f.space(2)
+	tgt.datasources = []sb.Datasource{{ID: 50, Name: "Colors", Slug: "colors"}}
+	tgt.entries[50] = []sb.DatasourceEntry{{ID: 51, Name: "red", Value: "red", DatasourceID: 50}}
+
+	cp, err := LoadCheckpoint(filepath.Join(t.TempDir(), "cp.json"), 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(f, sb.Space{ID: 1}, sb.Space{ID: 2}, cp)
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if n := c.Failures(); n != 0 {
+		t.Fatalf("unexpected failures: %+v", cp.EntriesInOrder())
+	}
+
+	if len(tgt.groups) != 1 || tgt.groups[0].Name != "Layout" {
+		t.Fatalf("group not cloned: %+v", tgt.groups)
+	}
+	if len(tgt.internal) != 1 || tgt.internal[0].Name != "core" {
+		t.Fatalf("internal tag not cloned: %+v", tgt.internal)
+	}
+	if len(tgt.components) != 1 {
+		t.Fatalf("component not cloned: %+v", tgt.components)
+	}
+	hero := tgt.components[0]
+	if hero.ComponentGroupUUID != tgt.groups[0].UUID || len(hero.InternalTagIDs) != 1 || hero.InternalTagIDs[0] != tgt.internal[0].ID {
+		t.Fatalf("component groups/tags not remapped: %+v", hero)
+	}
+	if len(tgt.presets) != 1 || tgt.presets[0].ComponentID != hero.ID {
+		t.Fatalf("preset not cloned onto target component: %+v", tgt.presets)
+	}
+	entries := tgt.entries[50]
+	if len(entries) != 2 || entries[0].Value != "#f00" || entries[1].Name != "blue" {
+		t.Fatalf("datasource entries not synced: %+v", entries)
+	}
+
+	folder := findStory(t, tgt.stories, "app")
+	page := findStory(t, tgt.stories, "app/page")
+	draft := findStory(t, tgt.stories, "app/draft")
+	if folder.UUID != "u-app" || page.UUID != "u-page" || draft.UUID != "u-draft" {
+		t.Fatalf("UUIDs not preserved: %s %s %s", folder.UUID, page.UUID, draft.UUID)
+	}
+	if page.FolderID == nil || *page.FolderID != folder.ID {
+		t.Fatalf("story not placed under cloned folder: %+v", page)
+	}
+	if !page.Published || draft.Published {
+		t.Fatalf("publish state not kept: page=%v draft=%v", page.Published, draft.Published)
+	}
+
+	// Kinds appear in dependency order
+	order := []string{}
+	for _, e := range cp.EntriesInOrder() {
+		if len(order) == 0 || order[len(order)-1] != e.Kind {
+			order = append(order, e.Kind)
+		}
+	}
+	want := []string{KindGroup, KindTag, KindPreset, KindComponent, KindDatasource, KindFolder, KindStory}
+	if len(order) != len(want) {
+		t.Fatalf("unexpected entry order %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("unexpected entry order %v, want %v", order, want)
+		}
+	}
+}
+
+func TestClonerResumesFromCheckpoint(t *testing.T) {
+	f := newFakeAPI()
+	seedSource(f)
+	f.failSlugs["app/page"] = true
+	path := filepath.Join(t.TempDir(), "cp.json")
+
+	cp, err := LoadCheckpoint(path, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(f, sb.Space{ID: 1}, sb.Space{ID: 2}, cp)
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if c.Failures() != 1 {
+		t.Fatalf("expected one failure, got %+v", cp.EntriesInOrder())
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("checkpoint not written: %v", err)
+	}
+
+	// Second run picks up the checkpoint and only retries the failed story
+	cp2, err := LoadCheckpoint(path, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cp2.Resumed() {
+		t.Fatal("expected resumed checkpoint")
+	}
+	total := len(cp2.EntriesInOrder())
+	c2 := New(f, sb.Space{ID: 1}, sb.Space{ID: 2}, cp2)
+	if err := c2.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if c2.Failures() != 0 {
+		t.Fatalf("failure not retried: %+v", cp2.EntriesInOrder())
+	}
+	if f.creates["app"] != 1 || f.creates["app/draft"] != 1 || f.creates["app/page"] != 2 {
+		t.Fatalf("unexpected creates: %v", f.creates)
+	}
+	if len(f.space(2).components) != 1 || len(f.space(2).entries) != 1 {
+		t.Fatalf("finished steps must not be repeated")
+	}
+	if got := len(cp2.EntriesInOrder()); got != total {
+		t.Fatalf("report must stay consolidated: %d entries, want %d", got, total)
+	}
+}
+
+func TestLoadCheckpointRejectsOtherSpaces(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cp.json")
+	data, _ := json.Marshal(map[string]int{"from": 1, "to": 2})
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCheckpoint(path, 1, 3); err == nil {
+		t.Fatal("expected error for checkpoint of another clone")
+	}
+}
+
+func TestClonerSyncsSingleItems(t *testing.T) {
+	f := newFakeAPI()
+	seedSource(f)
+	src := f.space(1)
+	src.components = append(src.components, sb.Component{ID: 8, Name: "teaser"})
+	src.datasources = append(src.datasources, sb.Datasource{ID: 9, Name: "Sizes", Slug: "sizes"})
+	// the group of hero exists, as it would after a clone
+	f.space(2).groups = []sb.ComponentGroup{{ID: 60, Name: "Layout", UUID: "g-tgt"}}
+
+	cp, err := LoadCheckpoint("", 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(f, sb.Space{ID: 1}, sb.Space{ID: 2}, cp)
+	if err := c.SyncComponent(context.Background(), "hero"); err != nil {
+		t.Fatalf("SyncComponent: %v", err)
+	}
+	if err := c.SyncDatasource(context.Background(), "colors"); err != nil {
+		t.Fatalf("SyncDatasource: %v", err)
+	}
+	tgt := f.space(2)
+	if len(tgt.components) != 1 || tgt.components[0].Name != "hero" || tgt.components[0].ComponentGroupUUID != "g-tgt" {
+		t.Fatalf("components = %+v", tgt.components)
+	}
+	if len(tgt.datasources) != 1 || len(tgt.entries[tgt.datasources[0].ID]) != 2 {
+		t.Fatalf("datasources = %+v, entries = %+v", tgt.datasources, tgt.entries)
+	}
+	if len(tgt.stories) != 0 {
+		t.Fatalf("stories synced: %+v", tgt.stories)
+	}
+
+	err = c.SyncComponent(context.Background(), "missing")
+	if err == nil || err.Error() != `component "missing" not found in source space` {
+		t.Fatalf("SyncComponent(missing) = %v", err)
+	}
+}
+
+// assetFakeAPI adds asset support to fakeAPI.
+type assetFakeAPI struct {
+	*fakeAPI
+	assets map[int][]sb.Asset
+}
+
+func (f *assetFakeAPI) ListAssets(_ context.Context, spaceID int) ([]sb.Asset, error) {
+	return append([]sb.Asset(nil), f.assets[spaceID]...), nil
+}
+
+func (f *assetFakeAPI) UpdateAssetInternalTags(_ context.Context, spaceID, assetID int, tagIDs []int) error {
+	for i, a := range f.assets[spaceID] {
+		if a.ID == assetID {
+			f.assets[spaceID][i].InternalTagIDs = tagIDs
+			return nil
+		}
+	}
+	return errors.New("asset not found")
+}
+
+func TestClonerReconcilesAssetTags(t *testing.T) {
+	f := &assetFakeAPI{fakeAPI: newFakeAPI(), assets: map[int][]sb.Asset{
+		1: {
+			{ID: 20, Filename: "https://a.storyblok.com/f/1/100x100/abc/hero.png", InternalTagsList: []sb.InternalTag{{Name: "brand"}}},
+			{ID: 21, Filename: "https://a.storyblok.com/f/1/100x100/def/only-source.png", InternalTagsList: []sb.InternalTag{{Name: "brand"}}},
+		},
+		2: {{ID: 70, Filename: "https://a.storyblok.com/f/2/100x100/xyz/hero.png"}},
+	}}
+	seedSource(f.fakeAPI)
+
+	cp, err := LoadCheckpoint("", 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(f, sb.Space{ID: 1}, sb.Space{ID: 2}, cp)
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	var brand sb.InternalTag
+	for _, tag := range f.space(2).internal {
+		if tag.Name == "brand" && tag.ObjectType == "asset" {
+			brand = tag
+		}
+	}
+	if brand.ID == 0 {
+		t.Fatalf("asset tag not created: %+v", f.space(2).internal)
+	}
+	hero := f.assets[2][0]
+	if len(hero.InternalTagIDs) != 1 || hero.InternalTagIDs[0] != brand.ID {
+		t.Fatalf("asset tags not set: %+v", hero)
+	}
+	var assets []Entry
+	for _, e := range cp.EntriesInOrder() {
+		if e.Kind == KindAsset {
+			assets = append(assets, e)
+		}
+	}
+	if len(assets) != 1 || assets[0].Name != "hero.png" || assets[0].Operation != "update" {
+		t.Fatalf("asset entries = %+v", assets)
+	}
+}
@@ -0,0 +1,431 @@
+package clone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"storyblok-sync/internal/core/assetsync"
+	comps "storyblok-sync/internal/core/componentsync"
+	synccore "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/core/tagsync"
+	"storyblok-sync/internal/sb"
+)
+
+// cloneGroups ensures all component groups exist in the target, nested by path.
+func (c *Cloner) cloneGroups(ctx context.Context) error {
+	src, err := c.api.ListComponentGroups(ctx, c.source.ID)
+	if err != nil {
+		return err
+	}
+	tgt, err := c.api.ListComponentGroups(ctx, c.target.ID)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(tgt))
+	for _, p := range comps.GroupPaths(tgt) {
+		existing[p] = true
+	}
+	srcPaths := comps.GroupPaths(src)
+	paths := make([]string, 0, len(srcPaths))
+	pending := false
+	for _, p := range srcPaths {
+		paths = append(paths, p)
+		pending = pending || !c.cp.IsDone(itemKey(KindGroup, p))
+	}
+	if !pending {
+		return nil
+	}
+	sort.Strings(paths)
+
+	start := time.Now()
+	_ = c.limiter.WaitWrite(ctx, c.target.ID)
+	_, ensureErr := comps.EnsureTargetGroups(ctx, c.api, c.target.ID, src)
+	for _, p := range paths {
+		key := itemKey(KindGroup, p)
+		if c.cp.IsDone(key) {
+			continue
+		}
+		op := synccore.OperationCreate
+		if existing[p] {
+			op = synccore.OperationSkip
+		}
+		if err := c.record(key, result(KindGroup, p, op, start, ensureErr, "")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cloneInternalTags ensures all internal tags exist in the target per object type.
+func (c *Cloner) cloneInternalTags(ctx context.Context) error {
+	src, err := c.api.ListInternalTags(ctx, c.source.ID)
+	if err != nil {
+		return err
+	}
+	byType := make(map[string][]string)
+	for _, t := range src {
+		if t.Name == "" {
+			continue
+		}
+		typ := t.ObjectType
+		if typ == "" {
+			typ = tagsync.ObjectTypeComponent
+		}
+		if !c.cp.IsDone(itemKey(KindTag, typ+"/"+t.Name)) {
+			byType[typ] = append(byType[typ], t.Name)
+		}
+	}
+	types := make([]string, 0, len(byType))
+	for typ := range byType {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+
+	r := tagsync.NewReconciler(nil, c.api, c.target.ID)
+	for _, typ := range types {
+		names := byType[typ]
+		sort.Strings(names)
+		start := time.Now()
+		_, created, err := r.EnsureInternalTags(ctx, typ, names)
+		isNew := make(map[string]bool, len(created))
+		for _, n := range created {
+			isNew[n] = true
+		}
+		for _, n := range names {
+			op := synccore.OperationSkip
+			itemErr := err
+			if isNew[n] {
+				op, itemErr = synccore.OperationCreate, nil
+			}
+			name := typ + "/" + n
+			if err := c.record(itemKey(KindTag, name), result(KindTag, name, op, start, itemErr, "")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// cloneComponents creates or updates every component with remapped groups and
+// internal tags, followed by its presets.
+func (c *Cloner) cloneComponents(ctx context.Context) error {
+	srcComps, err := c.api.ListComponents(ctx, c.source.ID)
+	if err != nil {
+		return err
+	}
+	tgtComps, err := c.api.ListComponents(ctx, c.target.ID)
+	if err != nil {
+		return err
+	}
+	srcGroups, err := c.api.ListComponentGroups(ctx, c.source.ID)
+	if err != nil {
+		return err
+	}
+	tgtGroups, err := c.api.ListComponentGroups(ctx, c.target.ID)
+	if err != nil {
+		return err
+	}
+	srcPresets, err := c.api.ListPresets(ctx, c.source.ID)
+	if err != nil {
+		return err
+	}
+	tgtPresets, err := c.api.ListPresets(ctx, c.target.ID)
+	if err != nil {
+		return err
+	}
+	srcUUIDToName, tgtNameToUUID := comps.BuildGroupNameMaps(srcGroups, tgtGroups)
+	var tagNames []string
+	for _, sc := range srcComps {
+		if c.skip(itemKey(KindComponent, sc.Name)) {
+			continue
+		}
+		for _, t := range sc.InternalTagsList {
+			tagNames = append(tagNames, t.Name)
+		}
+	}
+	tagIDs, err := comps.EnsureTagNameIDs(ctx, c.api, c.target.ID, tagNames)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range comps.BuildPlan(srcComps, tgtComps, nil) {
+		key := itemKey(KindComponent, p.Name)
+		if c.skip(key) {
+			continue
+		}
+		start := time.Now()
+		err := c.applyComponent(ctx, p, srcUUIDToName, tgtNameToUUID, tagIDs, srcPresets, tgtPresets)
+		if err := c.record(key, result(KindComponent, p.Name, p.Action, start, err, "")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyComponent writes one component and its presets. Preset failures are
+// recorded per preset and fail the component, so a resumed run retries them.
+func (c *Cloner) applyComponent(ctx context.Context, p comps.PlanItem, srcUUIDToName, tgtNameToUUID map[string]string, tagIDs map[string]int, srcPresets, tgtPresets []sb.ComponentPreset) error {
+	comp := p.Source
+	comp.Name = p.Name
+	mapped, _, err := comps.RemapComponentGroups(comp, srcUUIDToName, tgtNameToUUID)
+	if err != nil {
+		return err
+	}
+	ids := make([]int, 0, len(mapped.InternalTagsList))
+	for _, t := range mapped.InternalTagsList {
+		if id := tagIDs[t.Name]; id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	mapped.InternalTagIDs = sb.IntSlice(ids)
+
+	_ = c.limiter.WaitWrite(ctx, c.target.ID)
+	targetID := p.TargetID
+	if p.Action == synccore.OperationCreate {
+		mapped.ID = 0
+		created, err := c.api.CreateComponent(ctx, c.target.ID, mapped)
+		if err != nil {
+			return err
+		}
+		targetID = created.ID
+	} else {
+		mapped.ID = targetID
+		if _, err := c.api.UpdateComponent(ctx, c.target.ID, mapped); err != nil {
+			return err
+		}
+	}
+
+	newPresets, updPresets := comps.DiffPresetsByName(
+		comps.FilterPresetsForComponentID(srcPresets, p.Source.ID),
+		comps.FilterPresetsForComponentID(tgtPresets, targetID),
+	)
+	failed := 0
+	write := func(sp sb.ComponentPreset, op string) error {
+		start := time.Now()
+		norm := comps.NormalizePresetForTarget(sp, targetID)
+		_ = c.limiter.WaitWrite(ctx, c.target.ID)
+		var err error
+		if op == synccore.OperationCreate {
+			_, err = c.api.CreatePreset(ctx, c.target.ID, norm)
+		} else {
+			_, err = c.api.UpdatePreset(ctx, c.target.ID, norm)
+		}
+		if err != nil {
+			failed++
+		}
+		name := p.Name + "/" + sp.Name
+		return c.record(itemKey(KindPreset, name), result(KindPreset, name, op, start, err, ""))
+	}
+	for _, sp := range newPresets {
+		if err := write(sp, synccore.OperationCreate); err != nil {
+			return err
+		}
+	}
+	for _, sp := range updPresets {
+		if err := write(sp, synccore.OperationUpdate); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d preset(s) failed", failed)
+	}
+	return nil
+}
+
+// cloneDatasources creates missing datasources by slug and copies their
+// entries (default dimension), updating values that differ.
+func (c *Cloner) cloneDatasources(ctx context.Context) error {
+	src, err := c.api.ListDatasources(ctx, c.source.ID)
+	if err != nil {
+		return err
+	}
+	tgt, err := c.api.ListDatasources(ctx, c.target.ID)
+	if err != nil {
+		return err
+	}
+	tgtBySlug := make(map[string]sb.Datasource, len(tgt))
+	for _, ds := range tgt {
+		tgtBySlug[ds.Slug] = ds
+	}
+	for _, ds := range src {
+		key := itemKey(KindDatasource, ds.Slug)
+		if c.skip(key) {
+			continue
+		}
+		start := time.Now()
+		op := synccore.OperationUpdate
+		tds, ok := tgtBySlug[ds.Slug]
+		var err error
+		if !ok {
+			op = synccore.OperationCreate
+			_ = c.limiter.WaitWrite(ctx, c.target.ID)
+			tds, err = c.api.CreateDatasource(ctx, c.target.ID, sb.Datasource{Name: ds.Name, Slug: ds.Slug})
+		}
+		if err == nil {
+			err = c.copyDatasourceEntries(ctx, ds.ID, tds.ID, !ok)
+		}
+		if err := c.record(key, result(KindDatasource, ds.Slug, op, start, err, "")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyDatasourceEntries creates missing entries by name and updates changed values.
+func (c *Cloner) copyDatasourceEntries(ctx context.Context, srcID, tgtID int, isNew bool) error {
+	_ = c.limiter.WaitRead(ctx, c.source.ID)
+	entries, err := c.api.ListDatasourceEntries(ctx, c.source.ID, srcID)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]sb.DatasourceEntry)
+	if !isNew {
+		_ = c.limiter.WaitRead(ctx, c.target.ID)
+		tgtEntries, err := c.api.ListDatasourceEntries(ctx, c.target.ID, tgtID)
+		if err != nil {
+			return err
+		}
+		for _, e := range tgtEntries {
+			existing[e.Name] = e
+		}
+	}
+	var errs []error
+	for _, e := range entries {
+		te, ok := existing[e.Name]
+		if ok && te.Value == e.Value {
+			continue
+		}
+		_ = c.limiter.WaitWrite(ctx, c.target.ID)
+		if ok {
+			te.Value = e.Value
+			err = c.api.UpdateDatasourceEntry(ctx, c.target.ID, te)
+		} else {
+			_, err = c.api.CreateDatasourceEntry(ctx, c.target.ID, sb.DatasourceEntry{Name: e.Name, Value: e.Value, DatasourceID: tgtID})
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("entry %q: %w", e.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// cloneAssetTags reconciles the internal tags of assets present in both
+// spaces, matched by file name. Asset files are not copied, so source assets
+// without a target counterpart are left out. Clients without asset support
+// skip the step.
+func (c *Cloner) cloneAssetTags(ctx context.Context) error {
+	api, ok := any(c.api).(assetsync.API)
+	if !ok {
+		return nil
+	}
+	_ = c.limiter.WaitRead(ctx, c.source.ID)
+	src, err := api.ListAssets(ctx, c.source.ID)
+	if err != nil {
+		return err
+	}
+	_ = c.limiter.WaitRead(ctx, c.target.ID)
+	tgt, err := api.ListAssets(ctx, c.target.ID)
+	if err != nil {
+		return err
+	}
+	pairs, _ := assetsync.Match(src, tgt)
+	r := tagsync.NewReconciler(nil, c.api, c.target.ID)
+	for _, p := range pairs {
+		key := itemKey(KindAsset, p.Name())
+		if c.skip(key) {
+			continue
+		}
+		start := time.Now()
+		_ = c.limiter.WaitWrite(ctx, c.target.ID)
+		updated, err := assetsync.SyncTags(ctx, api, r, c.target.ID, p)
+		op := synccore.OperationSkip
+		if updated {
+			op = synccore.OperationUpdate
+		}
+		if err := c.record(key, result(KindAsset, p.Name(), op, start, err, "")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cloneStories syncs folders (shallow first) and then stories, keeping the
+// source UUIDs and publish state.
+func (c *Cloner) cloneStories(ctx context.Context) error {
+	src, err := c.api.ListStories(ctx, sb.ListStoriesOpts{SpaceID: c.source.ID, PerPage: 1000})
+	if err != nil {
+		return err
+	}
+	tgt, err := c.api.ListStories(ctx, sb.ListStoriesOpts{SpaceID: c.target.ID, PerPage: 1000})
+	if err != nil {
+		return err
+	}
+	index := make(map[string]sb.Story, len(tgt))
+	for _, st := range tgt {
+		index[st.FullSlug] = st
+	}
+	items := make([]synccore.PreflightItem, 0, len(src))
+	for _, st := range src {
+		items = append(items, synccore.PreflightItem{Story: st, Selected: true})
+	}
+	synccore.SortForSync(items)
+
+	so := synccore.NewSyncOrchestrator(c.api, nil, &c.source, &c.target, index)
+	so.SetSpaceLimiter(c.limiter)
+	so.SetTagReconciler(tagsync.NewReconciler(c.api, c.api, c.target.ID))
+	so.SetTransforms(c.transforms)
+
+	for _, it := range items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		story := it.Story
+		kind := KindStory
+		if story.IsFolder {
+			kind = KindFolder
+		}
+		key := itemKey(kind, story.FullSlug)
+		if c.cp.IsDone(key) {
+			continue
+		}
+		before, existed := index[story.FullSlug]
+		start := time.Now()
+		var res *synccore.SyncItemResult
+		if story.IsFolder {
+			res, err = so.SyncFolderDetailed(story)
+		} else {
+			res, err = so.SyncStoryDetailed(story)
+		}
+		op, warning := synccore.OperationCreate, ""
+		if existed {
+			op = synccore.OperationUpdate
+		}
+		if err == nil && res != nil {
+			op, warning = res.Operation, res.Warning
+			if res.TargetStory != nil {
+				// Folder creates drop the UUID; stories restore it themselves
+				if story.IsFolder && story.UUID != "" && res.TargetStory.UUID != story.UUID {
+					if uerr := c.api.UpdateStoryUUID(ctx, c.target.ID, res.TargetStory.ID, story.UUID); uerr != nil {
+						warning = "uuid: " + uerr.Error()
+					} else {
+						res.TargetStory.UUID = story.UUID
+					}
+				}
+				index[story.FullSlug] = *res.TargetStory
+				// Drafts stay drafts: take back a publication the target had before
+				if !story.IsFolder && !story.Published && existed && before.Published {
+					if uerr := c.api.UnpublishStory(ctx, c.target.ID, res.TargetStory.ID); uerr != nil {
+						warning = "unpublish: " + uerr.Error()
+					}
+				}
+			}
+		}
+		if err := c.record(key, result(kind, story.FullSlug, op, start, err, warning)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package componentsync
+
+import (
+	"sort"
+	"strings"
+
+	"storyblok-sync/internal/sb"
+)
+
+// groupNameEscaper escapes the path separator inside group names, so a group
+// named "A/B" is not mistaken for group "B" below "A".
+var groupNameEscaper = strings.NewReplacer(`\`, `\\`, "/", `\/`)
+
+// GroupPaths returns the full path ("Parent/Child") of every group keyed by
+// UUID. Parents are resolved via parent_uuid, falling back to parent_id.
+// A "/" or "\" in a name is escaped with a backslash. Groups without a name
+// are omitted.
+func GroupPaths(groups []sb.ComponentGroup) map[string]string {
+	byUUID := make(map[string]sb.ComponentGroup, len(groups))
+	byID := make(map[int]sb.ComponentGroup, len(groups))
+	for _, g := range groups {
+		if g.UUID != "" {
+			byUUID[g.UUID] = g
+		}
+		if g.ID > 0 {
+			byID[g.ID] = g
+		}
+	}
+	parentOf := func(g sb.ComponentGroup) (sb.ComponentGroup, bool) {
+		if g.ParentUUID != "" {
+			if p, ok := byUUID[g.ParentUUID]; ok {
+				return p, true
+			}
+		}
+		if g.ParentID > 0 {
+			if p, ok := byID[g.ParentID]; ok {
+				return p, true
+			}
+		}
+		return sb.ComponentGroup{}, false
+	}
+	out := make(map[string]string, len(groups))
+	for _, g := range groups {
+		if g.UUID == "" || g.Name == "" {
+			continue
+		}
+		parts := []string{groupNameEscaper.Replace(g.Name)}
+		cur := g
+		// Bounded walk guards against cyclic parent references
+		for depth := 0; depth < len(groups); depth++ {
+			p, ok := parentOf(cur)
+			if !ok || p.UUID == g.UUID {
+				break
+			}
+			parts = append([]string{groupNameEscaper.Replace(p.Name)}, parts...)
+			cur = p
+		}
+		out[g.UUID] = strings.Join(parts, "/")
+	}
+	return out
+}
+
+// groupSeparators returns the positions of the unescaped "/" in path.
+func groupSeparators(path string) []int {
+	var out []int
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			i++
+		case '/':
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+func parentGroupPath(path string) string {
+	if seps := groupSeparators(path); len(seps) > 0 {
+		return path[:seps[len(seps)-1]]
+	}
+	return ""
+}
+
+// sortGroupsByPath orders groups by depth, then path, skipping unnamed groups.
+func sortGroupsByPath(groups []sb.ComponentGroup, paths map[string]string) []sb.ComponentGroup {
+	out := make([]sb.ComponentGroup, 0, len(groups))
+	for _, g := range groups {
+		if paths[g.UUID] != "" {
+			out = append(out, g)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		pi, pj := paths[out[i].UUID], paths[out[j].UUID]
+		di, dj := len(groupSeparators(pi)), len(groupSeparators(pj))
+		if di != dj {
+			return di < dj
+		}
+		return pi < pj
+	})
+	return out
+}
+
+// Group diff kinds
+const (
+	GroupDiffCreate = "create" // missing in target
+	GroupDiffRename = "rename" // same UUID in target under another name
+	GroupDiffMove   = "move"   // same UUID in target under another parent
+	GroupDiffExtra  = "extra"  // only in target (never removed automatically)
+)
+
+// GroupDiff describes a difference between the source and target group trees.
+type GroupDiff struct {
+	Path string // source path (target path for extras)
+	Kind string
+	From string // current target path for rename/move
+}
+
+// DiffGroups compares source and target group trees by path and UUID.
+func DiffGroups(src, tgt []sb.ComponentGroup) []GroupDiff {
+	srcPaths := GroupPaths(src)
+	tgtPaths := GroupPaths(tgt)
+	tgtByPath := make(map[string]bool, len(tgtPaths))
+	for _, p := range tgtPaths {
+		tgtByPath[p] = true
+	}
+	srcByPath := make(map[string]bool, len(srcPaths))
+	for _, p := range srcPaths {
+		srcByPath[p] = true
+	}
+
+	var out []GroupDiff
+	for uuid, path := range srcPaths {
+		if tgtByPath[path] {
+			continue
+		}
+		if from, ok := tgtPaths[uuid]; ok {
+			kind := GroupDiffRename
+			if parentGroupPath(from) != parentGroupPath(path) {
+				kind = GroupDiffMove
+			}
+			out = append(out, GroupDiff{Path: path, Kind: kind, From: from})
+			continue
+		}
+		out = append(out, GroupDiff{Path: path, Kind: GroupDiffCreate})
+	}
+	for uuid, path := range tgtPaths {
+		if srcByPath[path] {
+			continue
+		}
+		if _, renamed := srcPaths[uuid]; renamed {
+			continue
+		}
+		out = append(out, GroupDiff{Path: path, Kind: GroupDiffExtra})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Path != out[j].Path {
+			return out[i].Path < out[j].Path
+		}
+		return out[i].Kind < out[j].Kind
+	})
+	return out
+}
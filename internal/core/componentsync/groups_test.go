@@ -0,0 +1,164 @@
+package componentsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"storyblok-sync/internal/sb"
+)
+
+func TestGroupPaths_Nested(t *testing.T) {
+	groups := []sb.ComponentGroup{
+		{ID: 1, UUID: "a", Name: "Layout"},
+		{ID: 2, UUID: "b", Name: "Teasers", ParentUUID: "a"},
+		{ID: 3, UUID: "c", Name: "Small", ParentID: 2},
+	}
+	p := GroupPaths(groups)
+	if p["a"] != "Layout" || p["b"] != "Layout/Teasers" || p["c"] != "Layout/Teasers/Small" {
+		t.Fatalf("unexpected paths: %+v", p)
+	}
+}
+
+func TestGroupPaths_SlashInName(t *testing.T) {
+	groups := []sb.ComponentGroup{
+		{ID: 1, UUID: "a", Name: "A"},
+		{ID: 2, UUID: "b", Name: "B", ParentID: 1},
+		{ID: 3, UUID: "ab", Name: "A/B"},
+		{ID: 4, UUID: "c", Name: `C\`, ParentID: 3},
+	}
+	p := GroupPaths(groups)
+	if p["b"] != "A/B" || p["ab"] != `A\/B` || p["c"] != `A\/B/C\\` {
+		t.Fatalf("unexpected paths: %+v", p)
+	}
+	if parentGroupPath(p["ab"]) != "" || parentGroupPath(p["c"]) != p["ab"] {
+		t.Fatalf("unexpected parents of %q and %q", p["ab"], p["c"])
+	}
+
+	api := &fakeGroupTreeAPI{groups: []sb.ComponentGroup{{ID: 1, UUID: "t-a", Name: "A"}}}
+	got, err := EnsureTargetGroups(context.Background(), api, 1, groups[2:3])
+	if err != nil {
+		t.Fatalf("ensure: %v", err)
+	}
+	for _, g := range got {
+		if g.Name == "A/B" && g.ParentID != 0 {
+			t.Fatalf("group A/B created below A: %+v", g)
+		}
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected A/B to be created next to A, got %+v", got)
+	}
+}
+
+func TestRemapComponentGroups_SameNameDifferentParents(t *testing.T) {
+	src := []sb.ComponentGroup{
+		{ID: 1, UUID: "s-blog", Name: "Blog"},
+		{ID: 2, UUID: "s-shop", Name: "Shop"},
+		{ID: 3, UUID: "s-blog-cards", Name: "Cards", ParentID: 1},
+		{ID: 4, UUID: "s-shop-cards", Name: "Cards", ParentID: 2},
+	}
+	tgt := []sb.ComponentGroup{
+		{ID: 10, UUID: "t-blog", Name: "Blog"},
+		{ID: 11, UUID: "t-shop", Name: "Shop"},
+		{ID: 12, UUID: "t-shop-cards", Name: "Cards", ParentID: 11},
+		{ID: 13, UUID: "t-blog-cards", Name: "Cards", ParentID: 10},
+	}
+	s2n, n2t := BuildGroupNameMaps(src, tgt)
+	schema, _ := json.Marshal(map[string]any{"body": map[string]any{"type": "bloks", "component_group_whitelist": []any{"s-blog-cards"}}})
+	out, _, err := RemapComponentGroups(sb.Component{Name: "x", ComponentGroupUUID: "s-shop-cards", Schema: schema}, s2n, n2t)
+	if err != nil {
+		t.Fatalf("remap: %v", err)
+	}
+	if out.ComponentGroupUUID != "t-shop-cards" {
+		t.Fatalf("expected shop cards group, got %s", out.ComponentGroupUUID)
+	}
+	var v map[string]map[string]any
+	_ = json.Unmarshal(out.Schema, &v)
+	if wl := v["body"]["component_group_whitelist"].([]any); wl[0] != "t-blog-cards" {
+		t.Fatalf("expected blog cards in whitelist, got %v", wl)
+	}
+}
+
+type fakeGroupTreeAPI struct {
+	groups  []sb.ComponentGroup
+	next    int
+	updates []sb.ComponentGroup
+}
+
+func (f *fakeGroupTreeAPI) ListComponentGroups(ctx context.Context, spaceID int) ([]sb.ComponentGroup, error) {
+	return append([]sb.ComponentGroup(nil), f.groups...), nil
+}
+
+func (f *fakeGroupTreeAPI) CreateComponentGroup(ctx context.Context, spaceID int, name string) (sb.ComponentGroup, error) {
+	return f.CreateComponentGroupWithParent(ctx, spaceID, name, 0)
+}
+
+func (f *fakeGroupTreeAPI) CreateComponentGroupWithParent(ctx context.Context, spaceID int, name string, parentID int) (sb.ComponentGroup, error) {
+	f.next++
+	g := sb.ComponentGroup{ID: 100 + f.next, UUID: fmt.Sprintf("n%d", f.next), Name: name, ParentID: parentID}
+	f.groups = append(f.groups, g)
+	return g, nil
+}
+
+func (f *fakeGroupTreeAPI) UpdateComponentGroup(ctx context.Context, spaceID int, g sb.ComponentGroup) (sb.ComponentGroup, error) {
+	f.updates = append(f.updates, g)
+	for i := range f.groups {
+		if f.groups[i].ID == g.ID {
+			f.groups[i].Name = g.Name
+			f.groups[i].ParentID = g.ParentID
+			f.groups[i].ParentUUID = ""
+		}
+	}
+	return g, nil
+}
+
+func TestEnsureTargetGroups_Tree(t *testing.T) {
+	api := &fakeGroupTreeAPI{groups: []sb.ComponentGroup{
+		{ID: 1, UUID: "same", Name: "Old Name"},
+	}}
+	src := []sb.ComponentGroup{
+		{ID: 1, UUID: "root", Name: "Layout"},
+		{ID: 2, UUID: "child", Name: "Teasers", ParentID: 1},
+		{ID: 3, UUID: "same", Name: "New Name"},
+	}
+	got, err := EnsureTargetGroups(context.Background(), api, 1, src)
+	if err != nil {
+		t.Fatalf("ensure: %v", err)
+	}
+	paths := map[string]bool{}
+	for _, p := range GroupPaths(got) {
+		paths[p] = true
+	}
+	if !paths["Layout"] || !paths["Layout/Teasers"] || !paths["New Name"] || paths["Old Name"] {
+		t.Fatalf("unexpected target tree: %+v", paths)
+	}
+	if len(api.updates) != 1 || api.updates[0].ID != 1 {
+		t.Fatalf("expected renamed group by UUID, got %+v", api.updates)
+	}
+}
+
+func TestDiffGroups(t *testing.T) {
+	src := []sb.ComponentGroup{
+		{ID: 1, UUID: "a", Name: "Layout"},
+		{ID: 2, UUID: "b", Name: "Teasers", ParentID: 1},
+		{ID: 3, UUID: "c", Name: "Forms"},
+		{ID: 4, UUID: "d", Name: "Moved", ParentID: 1},
+	}
+	tgt := []sb.ComponentGroup{
+		{ID: 10, UUID: "x", Name: "Layout"},
+		{ID: 11, UUID: "c", Name: "Formulare"},
+		{ID: 12, UUID: "d", Name: "Moved"},
+		{ID: 13, UUID: "y", Name: "Legacy"},
+	}
+	got := DiffGroups(src, tgt)
+	want := []GroupDiff{
+		{Path: "Forms", Kind: GroupDiffRename, From: "Formulare"},
+		{Path: "Layout/Moved", Kind: GroupDiffMove, From: "Moved"},
+		{Path: "Layout/Teasers", Kind: GroupDiffCreate},
+		{Path: "Legacy", Kind: GroupDiffExtra},
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("unexpected diff:\n got %+v\nwant %+v", got, want)
+	}
+}
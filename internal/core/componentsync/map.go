@@ -7,23 +7,16 @@ import (
 	"storyblok-sync/internal/sb"
 )
 
-// BuildGroupNameMaps builds helper maps for mapping component groups
-// - srcUUIDToName: source group UUID -> group name
-// - tgtNameToUUID: target group name -> target group UUID
+// BuildGroupNameMaps builds helper maps for mapping component groups.
+// Groups are keyed by their full path ("Parent/Child") so that groups with
+// the same name under different parents are not confused.
+// - srcUUIDToName: source group UUID -> group path
+// - tgtNameToUUID: target group path -> target group UUID
 func BuildGroupNameMaps(src []sb.ComponentGroup, tgt []sb.ComponentGroup) (map[string]string, map[string]string) {
-	srcUUIDToName := make(map[string]string, len(src))
-	for _, g := range src {
-		if g.UUID == "" || g.Name == "" {
-			continue
-		}
-		srcUUIDToName[g.UUID] = g.Name
-	}
+	srcUUIDToName := GroupPaths(src)
 	tgtNameToUUID := make(map[string]string, len(tgt))
-	for _, g := range tgt {
-		if g.UUID == "" || g.Name == "" {
-			continue
-		}
-		tgtNameToUUID[g.Name] = g.UUID
+	for uuid, path := range GroupPaths(tgt) {
+		tgtNameToUUID[path] = uuid
 	}
 	return srcUUIDToName, tgtNameToUUID
 }
@@ -120,27 +113,84 @@ type GroupAPI interface {
 	CreateComponentGroup(ctx context.Context, spaceID int, name string) (sb.ComponentGroup, error)
 }
 
-// EnsureTargetGroups makes sure all source group names exist in the target space.
-// It returns the refreshed target group slice after creating any missing ones.
+// GroupTreeAPI is optionally implemented by a GroupAPI to create nested groups
+// and to rename/move existing ones.
+type GroupTreeAPI interface {
+	CreateComponentGroupWithParent(ctx context.Context, spaceID int, name string, parentID int) (sb.ComponentGroup, error)
+	UpdateComponentGroup(ctx context.Context, spaceID int, g sb.ComponentGroup) (sb.ComponentGroup, error)
+}
+
+// EnsureTargetGroups makes sure all source groups exist in the target space.
+// With a GroupTreeAPI, groups are matched by full path, created under their
+// parent, and target groups sharing a source UUID are renamed/moved in place.
+// Without it, groups are created flat by name.
+// It returns the refreshed target group slice after applying changes.
 func EnsureTargetGroups(ctx context.Context, api GroupAPI, targetSpaceID int, source []sb.ComponentGroup) ([]sb.ComponentGroup, error) {
 	tgt, err := api.ListComponentGroups(ctx, targetSpaceID)
 	if err != nil {
 		return nil, err
 	}
-	have := make(map[string]bool, len(tgt))
+	tree, hasTree := api.(GroupTreeAPI)
+	if !hasTree {
+		have := make(map[string]bool, len(tgt))
+		for _, g := range tgt {
+			have[g.Name] = true
+		}
+		for _, sg := range source {
+			if sg.Name == "" {
+				continue
+			}
+			if !have[sg.Name] {
+				if _, err := api.CreateComponentGroup(ctx, targetSpaceID, sg.Name); err != nil {
+					return nil, err
+				}
+				have[sg.Name] = true
+			}
+		}
+		// Fetch final list to return UUIDs of newly created groups
+		return api.ListComponentGroups(ctx, targetSpaceID)
+	}
+
+	tgtPaths := GroupPaths(tgt)
+	byPath := make(map[string]sb.ComponentGroup, len(tgt))
+	byUUID := make(map[string]sb.ComponentGroup, len(tgt))
 	for _, g := range tgt {
-		have[g.Name] = true
+		if p := tgtPaths[g.UUID]; p != "" {
+			byPath[p] = g
+		}
+		byUUID[g.UUID] = g
 	}
-	for _, sg := range source {
-		if sg.Name == "" {
+	srcPaths := GroupPaths(source)
+	// Parents first so children can be attached to their (new) parent ID
+	for _, sg := range sortGroupsByPath(source, srcPaths) {
+		path := srcPaths[sg.UUID]
+		if _, ok := byPath[path]; ok {
 			continue
 		}
-		if !have[sg.Name] {
-			if _, err := api.CreateComponentGroup(ctx, targetSpaceID, sg.Name); err != nil {
+		parentID := 0
+		if pp := parentGroupPath(path); pp != "" {
+			if parent, ok := byPath[pp]; ok {
+				parentID = parent.ID
+			}
+		}
+		if existing, ok := byUUID[sg.UUID]; ok && existing.ID > 0 && sg.UUID != "" {
+			existing.Name = sg.Name
+			existing.ParentID = parentID
+			updated, err := tree.UpdateComponentGroup(ctx, targetSpaceID, existing)
+			if err != nil {
 				return nil, err
 			}
-			have[sg.Name] = true
+			if updated.ID == 0 {
+				updated = existing
+			}
+			byPath[path] = updated
+			continue
+		}
+		created, err := tree.CreateComponentGroupWithParent(ctx, targetSpaceID, sg.Name, parentID)
+		if err != nil {
+			return nil, err
 		}
+		byPath[path] = created
 	}
 	// Fetch final list to return UUIDs of newly created groups
 	return api.ListComponentGroups(ctx, targetSpaceID)
@@ -6,10 +6,11 @@ import (
 )
 
 // Decision represents a user choice from preflight per component name.
-// Action: create | update | skip | fork
+// Action: create | update | skip | fork | rename
 type Decision struct {
-	Action   string
-	ForkName string // when Action=fork, new component name to create
+	Action     string
+	ForkName   string // when Action=fork, new component name to create
+	RenameFrom string // when Action=rename, current name of the target component
 }
 
 // PlanItem is a concrete execution step for the executor
@@ -18,12 +19,15 @@ type PlanItem struct {
 	Action   string // create|update
 	TargetID int    // for update
 	Name     string // final name (fork may change it)
+	// RenameFrom is the previous target name when the update renames a component
+	RenameFrom string
 }
 
 // BuildPlan classifies actions using target name→ID and applies decisions.
 // - default: if source name exists in target => update; else create
 // - skip: drop from plan
 // - fork: force create with Name=ForkName (or source name + "-copy")
+// - rename: update the target component named RenameFrom to the source name
 func BuildPlan(selected []sb.Component, target []sb.Component, decisions map[string]Decision) []PlanItem {
 	tgtByName := make(map[string]int, len(target))
 	for _, t := range target {
@@ -51,6 +55,14 @@ func BuildPlan(selected []sb.Component, target []sb.Component, decisions map[str
 			out = append(out, PlanItem{Source: s, Action: action, TargetID: 0, Name: name})
 			continue
 		}
+		if action == "rename" {
+			if id, ok := tgtByName[strings.ToLower(d.RenameFrom)]; ok && d.RenameFrom != "" {
+				out = append(out, PlanItem{Source: s, Action: "update", TargetID: id, Name: name, RenameFrom: d.RenameFrom})
+				continue
+			}
+			// rename target vanished: fall back to default classification
+			action = ""
+		}
 		// default classification
 		if action != "create" && action != "update" {
 			if id, ok := tgtByName[strings.ToLower(s.Name)]; ok {
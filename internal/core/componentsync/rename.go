@@ -0,0 +1,193 @@
+package componentsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"storyblok-sync/internal/sb"
+)
+
+// DefaultRenameThreshold is the minimum schema similarity for a rename candidate.
+const DefaultRenameThreshold = 0.6
+
+// RenameCandidate pairs a source component that has no name match in the
+// target with a target component that is likely its previous name.
+type RenameCandidate struct {
+	Source sb.Component
+	Target sb.Component
+	Score  float64 // Jaccard similarity of schema fingerprints (0..1)
+}
+
+// SchemaFingerprint returns the set of "field:type" entries of a component
+// schema. Editor-only tabs and sections are ignored.
+func SchemaFingerprint(c sb.Component) map[string]bool {
+	out := make(map[string]bool)
+	if len(c.Schema) == 0 {
+		return out
+	}
+	var fields map[string]map[string]any
+	if err := json.Unmarshal(c.Schema, &fields); err != nil {
+		return out
+	}
+	for name, def := range fields {
+		typ, _ := def["type"].(string)
+		if typ == "tab" || typ == "section" {
+			continue
+		}
+		out[name+":"+typ] = true
+	}
+	return out
+}
+
+// Similarity computes the Jaccard similarity of two components' schema fingerprints.
+// Two empty schemas are not considered similar.
+func Similarity(a, b sb.Component) float64 {
+	fa, fb := SchemaFingerprint(a), SchemaFingerprint(b)
+	if len(fa) == 0 || len(fb) == 0 {
+		return 0
+	}
+	inter := 0
+	for k := range fa {
+		if fb[k] {
+			inter++
+		}
+	}
+	union := len(fa) + len(fb) - inter
+	return float64(inter) / float64(union)
+}
+
+// DetectRenames matches source components without a same-named target
+// component against target components without a same-named source component.
+// Pairs are assigned greedily by descending similarity; each component is used
+// at most once.
+func DetectRenames(source, target []sb.Component, threshold float64) []RenameCandidate {
+	srcNames := make(map[string]bool, len(source))
+	for _, s := range source {
+		srcNames[strings.ToLower(s.Name)] = true
+	}
+	tgtNames := make(map[string]bool, len(target))
+	for _, t := range target {
+		tgtNames[strings.ToLower(t.Name)] = true
+	}
+
+	var pairs []RenameCandidate
+	for _, s := range source {
+		if s.Name == "" || tgtNames[strings.ToLower(s.Name)] {
+			continue
+		}
+		for _, t := range target {
+			if t.Name == "" || srcNames[strings.ToLower(t.Name)] {
+				continue
+			}
+			if score := Similarity(s, t); score >= threshold {
+				pairs = append(pairs, RenameCandidate{Source: s, Target: t, Score: score})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if pairs[i].Score != pairs[j].Score {
+			return pairs[i].Score > pairs[j].Score
+		}
+		if pairs[i].Source.Name != pairs[j].Source.Name {
+			return pairs[i].Source.Name < pairs[j].Source.Name
+		}
+		return pairs[i].Target.Name < pairs[j].Target.Name
+	})
+
+	usedSrc := make(map[string]bool)
+	usedTgt := make(map[string]bool)
+	out := make([]RenameCandidate, 0)
+	for _, p := range pairs {
+		if usedSrc[p.Source.Name] || usedTgt[p.Target.Name] {
+			continue
+		}
+		usedSrc[p.Source.Name] = true
+		usedTgt[p.Target.Name] = true
+		out = append(out, p)
+	}
+	return out
+}
+
+// RewriteComponentName replaces the "component" value oldName with newName in
+// all bloks of a content tree (including richtext-embedded bloks). It returns
+// the number of bloks changed.
+func RewriteComponentName(node any, oldName, newName string) int {
+	n := 0
+	switch v := node.(type) {
+	case map[string]any:
+		if name, ok := v["component"].(string); ok && name == oldName {
+			v["component"] = newName
+			n++
+		}
+		for _, child := range v {
+			n += RewriteComponentName(child, oldName, newName)
+		}
+	case []any:
+		for _, child := range v {
+			n += RewriteComponentName(child, oldName, newName)
+		}
+	}
+	return n
+}
+
+// RenameStoryAPI is the subset of the client used to propagate a rename into stories.
+type RenameStoryAPI interface {
+	ListStories(ctx context.Context, opt sb.ListStoriesOpts) ([]sb.Story, error)
+	GetStoryRaw(ctx context.Context, spaceID, storyID int) (map[string]interface{}, error)
+	UpdateStoryRawWithPublish(ctx context.Context, spaceID int, storyID int, story map[string]interface{}, publish bool) (sb.Story, error)
+}
+
+// WriteLimiter throttles writes to a space (satisfied by sync.SpaceLimiter).
+type WriteLimiter interface {
+	WaitWrite(ctx context.Context, spaceID int) error
+}
+
+// RenameResult summarizes a rename propagation run.
+type RenameResult struct {
+	Scanned int
+	Updated int
+	Failed  []string // full slugs of stories that could not be updated
+}
+
+// PropagateRename rewrites oldName to newName in the content of all target
+// stories that use the component. Stories that were published without pending
+// changes are republished so the rename does not leave them in draft state.
+// Each story write waits for lim (nil: unthrottled); cancelling ctx stops the run.
+func PropagateRename(ctx context.Context, api RenameStoryAPI, lim WriteLimiter, spaceID int, oldName, newName string) (RenameResult, error) {
+	var res RenameResult
+	stories, err := api.ListStories(ctx, sb.ListStoriesOpts{SpaceID: spaceID, PerPage: 100, ContainComponent: oldName})
+	if err != nil {
+		return res, fmt.Errorf("list stories using %s: %w", oldName, err)
+	}
+	for _, st := range stories {
+		if st.IsFolder {
+			continue
+		}
+		res.Scanned++
+		raw, err := api.GetStoryRaw(ctx, spaceID, st.ID)
+		if err != nil {
+			res.Failed = append(res.Failed, st.FullSlug)
+			continue
+		}
+		content, ok := raw["content"].(map[string]interface{})
+		if !ok || RewriteComponentName(content, oldName, newName) == 0 {
+			continue
+		}
+		published, _ := raw["published"].(bool)
+		pending, _ := raw["unpublished_changes"].(bool)
+		if lim != nil {
+			if err := lim.WaitWrite(ctx, spaceID); err != nil {
+				return res, err
+			}
+		}
+		if _, err := api.UpdateStoryRawWithPublish(ctx, spaceID, st.ID, raw, published && !pending); err != nil {
+			res.Failed = append(res.Failed, st.FullSlug)
+			continue
+		}
+		res.Updated++
+	}
+	return res, nil
+}
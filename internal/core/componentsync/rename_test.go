@@ -0,0 +1,142 @@
+package componentsync
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"storyblok-sync/internal/sb"
+)
+
+func TestDetectRenames_MatchesSimilarSchemas(t *testing.T) {
+	src := []sb.Component{
+		{Name: "hero_banner", Schema: json.RawMessage(`{"title":{"type":"text"},"image":{"type":"asset"},"cta":{"type":"bloks"},"tab-1":{"type":"tab"}}`)},
+		{Name: "teaser", Schema: json.RawMessage(`{"title":{"type":"text"}}`)},
+		{Name: "fresh", Schema: json.RawMessage(`{"x":{"type":"number"}}`)},
+	}
+	tgt := []sb.Component{
+		{ID: 7, Name: "hero", Schema: json.RawMessage(`{"title":{"type":"text"},"image":{"type":"asset"},"cta":{"type":"bloks"}}`)},
+		{ID: 8, Name: "teaser", Schema: json.RawMessage(`{"title":{"type":"text"}}`)},
+		{ID: 9, Name: "legacy", Schema: json.RawMessage(`{"body":{"type":"richtext"}}`)},
+	}
+	got := DetectRenames(src, tgt, DefaultRenameThreshold)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 candidate, got %+v", got)
+	}
+	if got[0].Source.Name != "hero_banner" || got[0].Target.Name != "hero" || got[0].Score != 1 {
+		t.Fatalf("unexpected candidate: %+v", got[0])
+	}
+}
+
+func TestSimilarity_Jaccard(t *testing.T) {
+	a := sb.Component{Schema: json.RawMessage(`{"a":{"type":"text"},"b":{"type":"text"}}`)}
+	b := sb.Component{Schema: json.RawMessage(`{"a":{"type":"text"},"c":{"type":"text"}}`)}
+	if s := Similarity(a, b); s < 0.33 || s > 0.34 {
+		t.Fatalf("expected 1/3, got %f", s)
+	}
+	if s := Similarity(sb.Component{}, sb.Component{}); s != 0 {
+		t.Fatalf("empty schemas must not match, got %f", s)
+	}
+}
+
+func TestBuildPlan_Rename(t *testing.T) {
+	src := []sb.Component{{Name: "hero_banner"}}
+	tgt := []sb.Component{{ID: 7, Name: "hero"}}
+	plan := BuildPlan(src, tgt, map[string]Decision{"hero_banner": {Action: "rename", RenameFrom: "hero"}})
+	if len(plan) != 1 || plan[0].Action != "update" || plan[0].TargetID != 7 || plan[0].RenameFrom != "hero" || plan[0].Name != "hero_banner" {
+		t.Fatalf("unexpected rename plan: %+v", plan)
+	}
+}
+
+type fakeRenameAPI struct {
+	stories []sb.Story
+	raw     map[int]map[string]interface{}
+	opts    sb.ListStoriesOpts
+	updated map[int]bool
+	publish map[int]bool
+}
+
+func (f *fakeRenameAPI) ListStories(ctx context.Context, opt sb.ListStoriesOpts) ([]sb.Story, error) {
+	f.opts = opt
+	return f.stories, nil
+}
+
+func (f *fakeRenameAPI) GetStoryRaw(ctx context.Context, spaceID, storyID int) (map[string]interface{}, error) {
+	return f.raw[storyID], nil
+}
+
+func (f *fakeRenameAPI) UpdateStoryRawWithPublish(ctx context.Context, spaceID int, storyID int, story map[string]interface{}, publish bool) (sb.Story, error) {
+	f.updated[storyID] = true
+	f.publish[storyID] = publish
+	return sb.Story{ID: storyID}, nil
+}
+
+type countingLimiter struct {
+	waits int
+	space int
+	err   error
+}
+
+func (l *countingLimiter) WaitWrite(ctx context.Context, spaceID int) error {
+	l.waits++
+	l.space = spaceID
+	return l.err
+}
+
+func TestPropagateRename_RewritesStories(t *testing.T) {
+	api := &fakeRenameAPI{
+		stories: []sb.Story{{ID: 1, FullSlug: "a"}, {ID: 2, FullSlug: "b"}},
+		raw: map[int]map[string]interface{}{
+			1: {"published": true, "content": map[string]interface{}{"component": "page", "body": []interface{}{
+				map[string]interface{}{"component": "hero"},
+				map[string]interface{}{"component": "text", "rich": map[string]interface{}{"type": "doc", "content": []interface{}{
+					map[string]interface{}{"type": "blok", "attrs": map[string]interface{}{"body": []interface{}{map[string]interface{}{"component": "hero"}}}},
+				}}},
+			}}},
+			2: {"content": map[string]interface{}{"component": "page"}},
+		},
+		updated: map[int]bool{},
+		publish: map[int]bool{},
+	}
+	lim := &countingLimiter{}
+	res, err := PropagateRename(context.Background(), api, lim, 42, "hero", "hero_banner")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if api.opts.ContainComponent != "hero" || api.opts.SpaceID != 42 {
+		t.Fatalf("expected filtered listing, got %+v", api.opts)
+	}
+	if res.Scanned != 2 || res.Updated != 1 || !api.updated[1] || api.updated[2] {
+		t.Fatalf("unexpected result %+v updated=%v", res, api.updated)
+	}
+	if lim.waits != 1 || lim.space != 42 {
+		t.Fatalf("expected one throttled write in space 42, got %+v", lim)
+	}
+	if !api.publish[1] {
+		t.Fatalf("published story without pending changes should be republished")
+	}
+	body := api.raw[1]["content"].(map[string]interface{})["body"].([]interface{})
+	if body[0].(map[string]interface{})["component"] != "hero_banner" {
+		t.Fatalf("top-level blok not rewritten")
+	}
+	if n := RewriteComponentName(api.raw[1]["content"], "hero", "x"); n != 0 {
+		t.Fatalf("expected all occurrences rewritten, %d left", n)
+	}
+}
+
+func TestPropagateRename_StopsWhenLimiterIsCancelled(t *testing.T) {
+	api := &fakeRenameAPI{
+		stories: []sb.Story{{ID: 1, FullSlug: "a"}, {ID: 2, FullSlug: "b"}},
+		raw: map[int]map[string]interface{}{
+			1: {"content": map[string]interface{}{"component": "hero"}},
+			2: {"content": map[string]interface{}{"component": "hero"}},
+		},
+		updated: map[int]bool{},
+		publish: map[int]bool{},
+	}
+	lim := &countingLimiter{err: context.Canceled}
+	res, err := PropagateRename(context.Background(), api, lim, 42, "hero", "hero_banner")
+	if err != context.Canceled || res.Updated != 0 || len(api.updated) != 0 || lim.waits != 1 {
+		t.Fatalf("expected cancellation before the first write, got %+v err=%v updated=%v", res, err, api.updated)
+	}
+}
@@ -3,14 +3,13 @@ package componentsync
 import (
 	"context"
 	"sort"
+
+	"storyblok-sync/internal/core/tagsync"
 	"storyblok-sync/internal/sb"
 )
 
 // TagAPI defines the minimal API surface for ensuring internal tags.
-type TagAPI interface {
-	ListInternalTags(ctx context.Context, spaceID int) ([]sb.InternalTag, error)
-	CreateInternalTag(ctx context.Context, spaceID int, name string, objectType string) (sb.InternalTag, error)
-}
+type TagAPI = tagsync.InternalTagAPI
 
 // PrepareTagIDsForTarget ensures that all source internal tags exist in the target
 // and returns their IDs in a stable order (sorted by name for determinism unless
@@ -19,51 +18,32 @@ func PrepareTagIDsForTarget(ctx context.Context, api TagAPI, targetSpaceID int,
 	if len(source) == 0 {
 		return nil, nil
 	}
-	tgt, err := api.ListInternalTags(ctx, targetSpaceID)
-	if err != nil {
-		return nil, err
-	}
-	byName := make(map[string]int, len(tgt))
-	for _, t := range tgt {
-		byName[t.Name] = t.ID
-	}
-
-	// Collect distinct names from source (object_type component preferred)
 	names := make([]string, 0, len(source))
 	seen := make(map[string]bool)
 	for _, s := range source {
-		n := s.Name
-		if n == "" {
+		if s.Name == "" || seen[s.Name] {
 			continue
 		}
-		if !seen[n] {
-			names = append(names, n)
-			seen[n] = true
-		}
+		names = append(names, s.Name)
+		seen[s.Name] = true
 	}
 	if !retainOrder {
 		sort.Strings(names)
 	}
-
+	byName, err := EnsureTagNameIDs(ctx, api, targetSpaceID, names)
+	if err != nil {
+		return nil, err
+	}
 	ids := make([]int, 0, len(names))
-	for _, name := range names {
-		if id, ok := byName[name]; ok {
-			ids = append(ids, id)
-			continue
-		}
-		// Create missing tag for components
-		created, err := api.CreateInternalTag(ctx, targetSpaceID, name, "component")
-		if err != nil {
-			return nil, err
-		}
-		byName[name] = created.ID
-		ids = append(ids, created.ID)
+	for _, n := range names {
+		ids = append(ids, byName[n])
 	}
 	return ids, nil
 }
 
 // EnsureTagNameIDs ensures that all provided tag names exist in the target space
-// (object_type=component) and returns a name->ID mapping for them.
+// and returns a name->ID mapping for them. Existing tags match by name whatever
+// their object type; missing ones are created with object_type=component.
 func EnsureTagNameIDs(ctx context.Context, api TagAPI, targetSpaceID int, names []string) (map[string]int, error) {
 	// Deduplicate names
 	unique := make([]string, 0, len(names))
@@ -92,7 +72,7 @@ func EnsureTagNameIDs(ctx context.Context, api TagAPI, targetSpaceID int, names
 		if _, ok := byName[n]; ok {
 			continue
 		}
-		created, err := api.CreateInternalTag(ctx, targetSpaceID, n, "component")
+		created, err := api.CreateInternalTag(ctx, targetSpaceID, n, tagsync.ObjectTypeComponent)
 		if err != nil {
 			return nil, err
 		}
@@ -77,3 +77,31 @@ func TestEnsureTagNameIDs_CreatesMissingAndMaps(t *testing.T) {
 		t.Fatalf("newtag should be created with non-zero id")
 	}
 }
+
+type typedTagAPI struct {
+	tags    []sb.InternalTag
+	created []string
+}
+
+func (f *typedTagAPI) ListInternalTags(ctx context.Context, spaceID int) ([]sb.InternalTag, error) {
+	return f.tags, nil
+}
+
+func (f *typedTagAPI) CreateInternalTag(ctx context.Context, spaceID int, name string, objectType string) (sb.InternalTag, error) {
+	f.created = append(f.created, objectType+":"+name)
+	return sb.InternalTag{ID: 2000 + len(f.created), Name: name, ObjectType: objectType}, nil
+}
+
+func TestEnsureTagNameIDs_MatchesAnyObjectType(t *testing.T) {
+	api := &typedTagAPI{tags: []sb.InternalTag{{ID: 9, Name: "hero", ObjectType: "asset"}}}
+	m, err := EnsureTagNameIDs(context.Background(), api, 1, []string{"hero", "teaser"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m["hero"] != 9 {
+		t.Fatalf("existing asset tag must be reused, got %+v", m)
+	}
+	if len(api.created) != 1 || api.created[0] != "component:teaser" {
+		t.Fatalf("created = %v", api.created)
+	}
+}
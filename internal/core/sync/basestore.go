@@ -0,0 +1,65 @@
+package sync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// DefaultBaseDir is where base snapshots are stored relative to the working directory.
+const DefaultBaseDir = ".sbsync/base"
+
+// BaseStore persists the last payload written per target story as the common
+// ancestor ("base") for three-way merges: <dir>/<space>/<story id>.json.
+type BaseStore struct {
+	dir string
+}
+
+// NewBaseStore creates a store rooted at dir (empty: DefaultBaseDir).
+func NewBaseStore(dir string) *BaseStore {
+	if dir == "" {
+		dir = DefaultBaseDir
+	}
+	return &BaseStore{dir: dir}
+}
+
+func (bs *BaseStore) path(spaceID, storyID int) string {
+	return filepath.Join(bs.dir, strconv.Itoa(spaceID), strconv.Itoa(storyID)+".json")
+}
+
+// Load returns the stored base payload of a target story; ok is false when
+// none was recorded.
+func (bs *BaseStore) Load(spaceID, storyID int) (map[string]interface{}, bool, error) {
+	data, err := os.ReadFile(bs.path(spaceID, storyID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, false, fmt.Errorf("base %d: %w", storyID, err)
+	}
+	return payload, true, nil
+}
+
+// Save records payload as the new base, replacing the previous file atomically.
+func (bs *BaseStore) Save(spaceID, storyID int, payload map[string]interface{}) error {
+	p := bs.path(spaceID, storyID)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
@@ -0,0 +1,63 @@
+package sync
+
+import (
+	"sort"
+
+	"storyblok-sync/internal/sb"
+)
+
+// CollectComponentNames returns the sorted, de-duplicated names of all
+// components referenced in a content tree, including bloks nested in
+// richtext documents.
+func CollectComponentNames(content map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	collectComponentNames(content, seen)
+	names := make([]string, 0, len(seen))
+	for n := range seen {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func collectComponentNames(node interface{}, seen map[string]bool) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if name, ok := n["component"].(string); ok && name != "" {
+			seen[name] = true
+		}
+		for _, v := range n {
+			collectComponentNames(v, seen)
+		}
+	case []interface{}:
+		for _, e := range n {
+			collectComponentNames(e, seen)
+		}
+	}
+}
+
+// CollectStoryComponents returns the components used by a story, including
+// its content type. Folders yield no components.
+func CollectStoryComponents(story sb.Story) []string {
+	if story.IsFolder {
+		return nil
+	}
+	var names []string
+	if len(story.Content) > 0 {
+		names = CollectComponentNames(ToRawMap(story.Content))
+	}
+	if story.ContentType != "" {
+		found := false
+		for _, n := range names {
+			if n == story.ContentType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			names = append(names, story.ContentType)
+			sort.Strings(names)
+		}
+	}
+	return names
+}
@@ -0,0 +1,29 @@
+package sync
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"storyblok-sync/internal/sb"
+)
+
+func TestCollectStoryComponents(t *testing.T) {
+	content := `{"_uid":"1","component":"page","body":[{"_uid":"2","component":"teaser","text":{"type":"doc","content":[{"type":"blok","attrs":{"body":[{"_uid":"3","component":"cta"}]}}]}},{"_uid":"4","component":"teaser"}]}`
+	st := sb.Story{Content: json.RawMessage(content), ContentType: "page"}
+	got := CollectStoryComponents(st)
+	want := []string{"cta", "page", "teaser"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	// Content type is included even when content was not loaded
+	got = CollectStoryComponents(sb.Story{ContentType: "article"})
+	if !reflect.DeepEqual(got, []string{"article"}) {
+		t.Fatalf("expected content type only, got %v", got)
+	}
+
+	if got := CollectStoryComponents(sb.Story{IsFolder: true, ContentType: "page"}); got != nil {
+		t.Fatalf("expected no components for folder, got %v", got)
+	}
+}
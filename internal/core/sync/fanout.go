@@ -0,0 +1,126 @@
+package sync
+
+import (
+	"storyblok-sync/internal/sb"
+)
+
+// TargetSummary counts what a preflight would do in one target space.
+type TargetSummary struct {
+	SpaceID        int
+	Create         int
+	Update         int
+	Skip           int
+	MissingFolders int // parent folders neither in the target nor in the plan
+}
+
+// SummarizeTarget compares the preflight items with the stories of one target
+// space. Collisions are matched by full slug (the new slug for forks).
+func SummarizeTarget(spaceID int, items []PreflightItem, targetStories []sb.Story) TargetSummary {
+	sum := TargetSummary{SpaceID: spaceID}
+	existing := make(map[string]bool, len(targetStories))
+	folders := make(map[string]bool)
+	for _, st := range targetStories {
+		existing[st.FullSlug] = true
+		if st.IsFolder {
+			folders[st.FullSlug] = true
+		}
+	}
+	planned := make(map[string]bool, len(items))
+	for _, it := range items {
+		if !it.Skip && it.Selected {
+			planned[it.Story.FullSlug] = true
+		}
+	}
+	missing := make(map[string]bool)
+	for _, it := range items {
+		if it.Skip || !it.Selected || it.State == StateSkip {
+			sum.Skip++
+			continue
+		}
+		if existing[targetSlug(it)] {
+			sum.Update++
+		} else {
+			sum.Create++
+		}
+		for _, p := range GetFolderPaths(it.Story.FullSlug) {
+			if !folders[p] && !planned[p] {
+				missing[p] = true
+			}
+		}
+	}
+	sum.MissingFolders = len(missing)
+	return sum
+}
+
+// targetSlug is the full slug an item is written to.
+func targetSlug(it PreflightItem) string {
+	if it.CopyAsNew && it.NewSlug != "" {
+		if parent := ParentSlug(it.Story.FullSlug); parent != "" {
+			return parent + "/" + it.NewSlug
+		}
+		return it.NewSlug
+	}
+	return it.Story.FullSlug
+}
+
+// ExpandForTargets fans a planned preflight out to additional target spaces.
+// The planned items stay first and are assigned to primaryID; each target in
+// targets gets its own copy with collisions re-evaluated against its stories,
+// missing parent folders added and the sync order restored. Workflow stages,
+// merge conflicts and resolutions were checked against the primary target
+// only and are dropped from the copies.
+func ExpandForTargets(items []PreflightItem, sourceStories []sb.Story, primaryID int, targets []sb.Space, storiesByTarget map[int][]sb.Story) []PreflightItem {
+	out := make([]PreflightItem, 0, len(items)*(len(targets)+1))
+	for _, it := range items {
+		it.TargetSpaceID = primaryID
+		out = append(out, it)
+	}
+	for _, sp := range targets {
+		tgtStories := storiesByTarget[sp.ID]
+		existing := make(map[string]bool, len(tgtStories))
+		for _, st := range tgtStories {
+			existing[st.FullSlug] = true
+		}
+		clones := make([]PreflightItem, 0, len(items))
+		for _, it := range items {
+			if it.Skip {
+				continue
+			}
+			it.Issue = ""
+			it.WorkflowStage = ""
+			it.WorkflowLocked = false
+			it.WorkflowBlocked = false
+			it.Conflicts = nil
+			it.Resolutions = nil
+			if !it.CopyAsNew {
+				it.Collision = existing[it.Story.FullSlug]
+				if it.State != StateSkip {
+					it.State = StateCreate
+					if it.Collision {
+						it.State = StateUpdate
+					}
+				}
+			}
+			clones = append(clones, it)
+		}
+		clones = NewPreflightPlanner(sourceStories, tgtStories).OptimizePreflight(clones)
+		for i := range clones {
+			clones[i].TargetSpaceID = sp.ID
+		}
+		out = append(out, clones...)
+	}
+	return out
+}
+
+// NewFanOutLimiter returns one limiter for concurrent syncs into several
+// spaces. Every space keeps its own read/write budget based on its plan. Only
+// these shared limiters publish their rates as metrics.
+func NewFanOutLimiter(spaces ...sb.Space) *SpaceLimiter {
+	l := NewSpaceLimiter(DefaultLimitsForPlan(0))
+	l.metrics = true
+	for _, sp := range spaces {
+		r, w, b := DefaultLimitsForPlan(sp.PlanLevel)
+		l.SetSpaceLimits(sp.ID, r, w, b)
+	}
+	return l
+}
@@ -0,0 +1,85 @@
+package sync
+
+import (
+	"testing"
+
+	"storyblok-sync/internal/sb"
+)
+
+func TestExpandForTargets_PerTargetCollisionsAndFolders(t *testing.T) {
+	folderID := 10
+	source := []sb.Story{
+		{ID: 10, Slug: "blog", FullSlug: "blog", IsFolder: true},
+		{ID: 11, Slug: "a", FullSlug: "blog/a", FolderID: &folderID},
+		{ID: 12, Slug: "home", FullSlug: "home"},
+	}
+	items := []PreflightItem{
+		{Story: source[1], Selected: true, Collision: true, State: StateUpdate, Run: RunPending,
+			WorkflowStage: "Review", Conflicts: []MergeConflict{{Path: "title"}}, Resolutions: map[string]string{"title": ResolveSource}},
+		{Story: source[2], Selected: true, State: StateCreate, Run: RunPending},
+	}
+	targets := []sb.Space{{ID: 2, Name: "B"}}
+	stories := map[int][]sb.Story{
+		2: {{ID: 90, FullSlug: "home"}},
+	}
+
+	out := ExpandForTargets(items, source, 1, targets, stories)
+
+	if len(out) != 5 {
+		t.Fatalf("expected 2 primary + 3 target items, got %d: %+v", len(out), out)
+	}
+	for _, it := range out[:2] {
+		if it.TargetSpaceID != 1 {
+			t.Fatalf("primary items must be assigned to the primary target: %+v", it)
+		}
+	}
+	if len(out[0].Resolutions) != 1 || out[0].WorkflowStage != "Review" {
+		t.Fatalf("primary item must keep its checks: %+v", out[0])
+	}
+
+	byslug := map[string]PreflightItem{}
+	for _, it := range out[2:] {
+		if it.TargetSpaceID != 2 {
+			t.Fatalf("copy not assigned to target 2: %+v", it)
+		}
+		byslug[it.Story.FullSlug] = it
+	}
+	if !out[2].Story.IsFolder || out[2].Story.FullSlug != "blog" || out[2].State != StateCreate {
+		t.Fatalf("missing parent folder must be added first for target 2: %+v", out[2])
+	}
+	if a := byslug["blog/a"]; a.Collision || a.State != StateCreate || a.Conflicts != nil || a.Resolutions != nil || a.WorkflowStage != "" {
+		t.Fatalf("blog/a must be a clean create in target 2: %+v", a)
+	}
+	if h := byslug["home"]; !h.Collision || h.State != StateUpdate {
+		t.Fatalf("home exists in target 2 and must be an update: %+v", h)
+	}
+}
+
+func TestSummarizeTarget(t *testing.T) {
+	folderID := 10
+	items := []PreflightItem{
+		{Story: sb.Story{FullSlug: "blog/a", FolderID: &folderID}, Selected: true, State: StateCreate},
+		{Story: sb.Story{FullSlug: "home"}, Selected: true, State: StateCreate},
+		{Story: sb.Story{FullSlug: "about"}, Selected: true, State: StateSkip, Skip: true},
+	}
+	target := []sb.Story{{FullSlug: "home"}}
+
+	sum := SummarizeTarget(7, items, target)
+
+	if sum.SpaceID != 7 || sum.Create != 1 || sum.Update != 1 || sum.Skip != 1 || sum.MissingFolders != 1 {
+		t.Fatalf("unexpected summary: %+v", sum)
+	}
+}
+
+func TestNewFanOutLimiter_BudgetPerSpace(t *testing.T) {
+	l := NewFanOutLimiter(sb.Space{ID: 1, PlanLevel: 0}, sb.Space{ID: 2, PlanLevel: 1})
+	if got := l.get(1).write.rps; got != 3 {
+		t.Fatalf("dev space write rps = %v, want 3", got)
+	}
+	if got := l.get(2).write.rps; got != 7 {
+		t.Fatalf("paid space write rps = %v, want 7", got)
+	}
+	if l.get(1) == l.get(2) {
+		t.Fatal("spaces must not share buckets")
+	}
+}
@@ -37,6 +37,7 @@ type FolderPathBuilder struct {
 	srcSpaceID int
 	tgtSpaceID int
 	publish    bool
+	limiter    *SpaceLimiter // optional
 }
 
 // NewFolderPathBuilder creates a new folder path builder
@@ -53,8 +54,31 @@ func NewFolderPathBuilder(api FolderAPI, report Report, sourceStories []sb.Story
 	}
 }
 
+// SetLimiter throttles the folder lookups and writes with a shared per-space
+// limiter.
+func (fpb *FolderPathBuilder) SetLimiter(l *SpaceLimiter) {
+	fpb.limiter = l
+}
+
+func (fpb *FolderPathBuilder) waitRead(ctx context.Context, spaceID int) error {
+	if fpb.limiter == nil {
+		return nil
+	}
+	return fpb.limiter.WaitRead(ctx, spaceID)
+}
+
+func (fpb *FolderPathBuilder) waitWrite(ctx context.Context) error {
+	if fpb.limiter == nil {
+		return nil
+	}
+	return fpb.limiter.WaitWrite(ctx, fpb.tgtSpaceID)
+}
+
 // CheckExistingFolder checks if a folder exists in the target space
 func (fpb *FolderPathBuilder) CheckExistingFolder(ctx context.Context, path string) (*sb.Story, error) {
+	if err := fpb.waitRead(ctx, fpb.tgtSpaceID); err != nil {
+		return nil, err
+	}
 	existing, err := fpb.api.GetStoriesBySlug(ctx, fpb.tgtSpaceID, path)
 	if err != nil {
 		return nil, err
@@ -72,6 +96,9 @@ func (fpb *FolderPathBuilder) CheckExistingFolder(ctx context.Context, path stri
 // PrepareSourceFolder prepares a source folder for creation in target space
 func (fpb *FolderPathBuilder) PrepareSourceFolder(ctx context.Context, path string, parentID *int) (map[string]interface{}, error) {
 	// Look up the source folder by slug using API (not the preloaded map)
+	if err := fpb.waitRead(ctx, fpb.srcSpaceID); err != nil {
+		return nil, err
+	}
 	matches, err := fpb.api.GetStoriesBySlug(ctx, fpb.srcSpaceID, path)
 	if err != nil {
 		return nil, err
@@ -85,6 +112,9 @@ func (fpb *FolderPathBuilder) PrepareSourceFolder(ctx context.Context, path stri
 	}
 
 	// Fetch raw story payload from API to preserve unknown fields
+	if err := fpb.waitRead(ctx, fpb.srcSpaceID); err != nil {
+		return nil, err
+	}
 	raw, err := fpb.api.GetStoryRaw(ctx, fpb.srcSpaceID, source.ID)
 	if err != nil {
 		log.Printf("DEBUG: Failed to fetch raw payload for folder %s: %v", path, err)
@@ -134,6 +164,9 @@ func (fpb *FolderPathBuilder) CreateFolder(ctx context.Context, folder map[strin
 	// Omit push raw payload dump
 	log.Printf("DEBUG: PUSH_RAW folder %s (payload omitted)", slug)
 
+	if err := fpb.waitWrite(ctx); err != nil {
+		return sb.Story{}, err
+	}
 	created, err := fpb.api.CreateStoryRawWithPublish(ctx, fpb.tgtSpaceID, folder, false /* never publish folders */)
 	if err != nil {
 		log.Printf("DEBUG: Failed to create folder %s: %v", slug, err)
@@ -144,6 +177,9 @@ func (fpb *FolderPathBuilder) CreateFolder(ctx context.Context, folder map[strin
 
 	// Update UUID after creation if source provided one
 	if uuidVal, ok := folder["uuid"].(string); ok && uuidVal != "" && uuidVal != created.UUID {
+		if err := fpb.waitWrite(ctx); err != nil {
+			return created, nil
+		}
 		if err := fpb.api.UpdateStoryUUID(ctx, fpb.tgtSpaceID, created.ID, uuidVal); err != nil {
 			log.Printf("DEBUG: Failed to update UUID for created folder %s: %v", slug, err)
 		}
@@ -153,6 +189,12 @@ func (fpb *FolderPathBuilder) CreateFolder(ctx context.Context, folder map[strin
 
 // EnsureFolderPath creates missing folders in a path hierarchy
 func (fpb *FolderPathBuilder) EnsureFolderPath(slug string) ([]sb.Story, error) {
+	return fpb.EnsureFolderPathContext(context.Background(), slug)
+}
+
+// EnsureFolderPathContext is EnsureFolderPath bounded by ctx: a cancelled
+// ctx stops before the next request.
+func (fpb *FolderPathBuilder) EnsureFolderPathContext(parent context.Context, slug string) ([]sb.Story, error) {
 	parts := strings.Split(slug, "/")
 	if len(parts) <= 1 {
 		return nil, nil
@@ -165,7 +207,7 @@ func (fpb *FolderPathBuilder) EnsureFolderPath(slug string) ([]sb.Story, error)
 	for i := 0; i < len(parts)-1; i++ {
 		path := strings.Join(parts[:i+1], "/")
 
-		ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+		ctx, cancel := context.WithTimeout(parent, DefaultTimeout)
 
 		// Check if folder already exists
 		existing, err := fpb.CheckExistingFolder(ctx, path)
@@ -182,7 +224,7 @@ func (fpb *FolderPathBuilder) EnsureFolderPath(slug string) ([]sb.Story, error)
 		}
 
 		// Folder doesn't exist, create it
-		ctx, cancel = context.WithTimeout(context.Background(), DefaultTimeout)
+		ctx, cancel = context.WithTimeout(parent, DefaultTimeout)
 		folder, err := fpb.PrepareSourceFolder(ctx, path, parentID)
 		cancel()
 
@@ -191,7 +233,7 @@ func (fpb *FolderPathBuilder) EnsureFolderPath(slug string) ([]sb.Story, error)
 		}
 
 		// Create the folder
-		ctx, cancel = context.WithTimeout(context.Background(), DefaultTimeout)
+		ctx, cancel = context.WithTimeout(parent, DefaultTimeout)
 		createdFolder, err := fpb.CreateFolder(ctx, folder)
 		cancel()
 
@@ -0,0 +1,221 @@
+package sync
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	gosync "sync"
+	"time"
+)
+
+// DefaultJournalPath is where the sync journal is kept relative to the working directory.
+const DefaultJournalPath = ".sbsync/journal.jsonl"
+
+// Journal record types
+const (
+	journalPlan   = "plan"
+	journalResult = "result"
+	journalDone   = "done"
+)
+
+// JournalPlan is the first journal record: the planned items and the
+// settings needed to run them again.
+type JournalPlan struct {
+	SourceSpaceID int               `json:"source_space_id"`
+	TargetSpaceID int               `json:"target_space_id"`
+	FanOutTargets []int             `json:"fanout_targets,omitempty"`
+	Items         []PreflightItem   `json:"items"`
+	PublishModes  map[string]string `json:"publish_modes,omitempty"`
+	PublishAt     map[string]string `json:"publish_at,omitempty"`
+	ReleaseID     int               `json:"release_id,omitempty"`
+	ReleaseName   string            `json:"release_name,omitempty"`
+	Languages     []string          `json:"languages,omitempty"`
+	MergePolicy   string            `json:"merge_policy,omitempty"`
+}
+
+// ItemKey identifies an item of the plan across targets: its full slug, or
+// "<space id>:<full slug>" for fan-out targets other than the primary one.
+func (p JournalPlan) ItemKey(it PreflightItem) string {
+	if it.TargetSpaceID == 0 || it.TargetSpaceID == p.TargetSpaceID {
+		return it.Story.FullSlug
+	}
+	return fmt.Sprintf("%d:%s", it.TargetSpaceID, it.Story.FullSlug)
+}
+
+type journalRecord struct {
+	Type   string       `json:"type"`
+	Time   time.Time    `json:"time"`
+	Plan   *JournalPlan `json:"plan,omitempty"`
+	Key    string       `json:"key,omitempty"`
+	Status string       `json:"status,omitempty"` // RunSuccess | RunFailed
+	Error  string       `json:"error,omitempty"`
+}
+
+// Journal is an append-only JSONL log of a sync run: the plan, one record per
+// finished item and a final done record. A journal without a done record
+// belongs to an interrupted run.
+type Journal struct {
+	mu gosync.Mutex
+	f  *os.File
+}
+
+// StartJournal replaces the journal at path with a new one for plan.
+func StartJournal(path string, plan JournalPlan) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	j := &Journal{f: f}
+	if err := j.append(journalRecord{Type: journalPlan, Plan: &plan}); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return j, nil
+}
+
+// Result records the outcome of one item (errMsg empty: success).
+func (j *Journal) Result(key, errMsg string) error {
+	rec := journalRecord{Type: journalResult, Key: key, Status: RunSuccess}
+	if errMsg != "" {
+		rec.Status = RunFailed
+		rec.Error = errMsg
+	}
+	return j.append(rec)
+}
+
+// Finish marks the run as complete and closes the journal.
+func (j *Journal) Finish() error {
+	if err := j.append(journalRecord{Type: journalDone}); err != nil {
+		return err
+	}
+	return j.Close()
+}
+
+// Close closes the journal without marking the run complete.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.f == nil {
+		return nil
+	}
+	err := j.f.Close()
+	j.f = nil
+	return err
+}
+
+func (j *Journal) append(rec journalRecord) error {
+	rec.Time = time.Now().UTC()
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.f == nil {
+		return errors.New("journal closed")
+	}
+	if _, err := j.f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return j.f.Sync()
+}
+
+// JournalState is the replayed content of an unfinished journal.
+type JournalState struct {
+	Plan    JournalPlan
+	Started time.Time
+	Results map[string]string // item key -> RunSuccess | RunFailed
+	Errors  map[string]string // item key -> last error
+}
+
+// LoadUnfinishedJournal replays the journal at path. It returns nil when
+// there is no journal or the run finished. A torn last line (the process
+// died mid-write) is ignored.
+func LoadUnfinishedJournal(path string) (*JournalState, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var st *JournalState
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for sc.Scan() {
+		var rec journalRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			continue
+		}
+		switch rec.Type {
+		case journalPlan:
+			if rec.Plan == nil {
+				continue
+			}
+			st = &JournalState{Plan: *rec.Plan, Started: rec.Time, Results: map[string]string{}, Errors: map[string]string{}}
+		case journalResult:
+			if st == nil {
+				continue
+			}
+			st.Results[rec.Key] = rec.Status
+			if rec.Error != "" {
+				st.Errors[rec.Key] = rec.Error
+			} else {
+				delete(st.Errors, rec.Key)
+			}
+		case journalDone:
+			st = nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("journal %s: %w", path, err)
+	}
+	return st, nil
+}
+
+// Counts returns how many planned items succeeded, failed or never finished.
+func (s *JournalState) Counts() (done, failed, pending int) {
+	for _, it := range s.Plan.Items {
+		switch s.Results[s.Plan.ItemKey(it)] {
+		case RunSuccess:
+			done++
+		case RunFailed:
+			failed++
+		default:
+			pending++
+		}
+	}
+	return done, failed, pending
+}
+
+// ResumeItems returns the planned items that did not succeed, reset to
+// pending. Failed items carry their last error as issue.
+func (s *JournalState) ResumeItems() []PreflightItem {
+	var out []PreflightItem
+	for _, it := range s.Plan.Items {
+		key := s.Plan.ItemKey(it)
+		if s.Results[key] == RunSuccess {
+			continue
+		}
+		it.Run = RunPending
+		it.Issue = s.Errors[key]
+		out = append(out, it)
+	}
+	return out
+}
+
+// DiscardJournal removes the journal at path.
+func DiscardJournal(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"storyblok-sync/internal/sb"
+)
+
+func journalFixture() JournalPlan {
+	return JournalPlan{
+		SourceSpaceID: 1,
+		TargetSpaceID: 2,
+		FanOutTargets: []int{3},
+		Items: []PreflightItem{
+			{Story: sb.Story{FullSlug: "a", IsFolder: true}, State: StateCreate, Run: RunPending},
+			{Story: sb.Story{FullSlug: "a/b"}, State: StateUpdate, Run: RunPending},
+			{Story: sb.Story{FullSlug: "a/c"}, State: StateCreate, Run: RunPending},
+			{Story: sb.Story{FullSlug: "a/b"}, State: StateCreate, Run: RunPending, TargetSpaceID: 3},
+		},
+		PublishModes: map[string]string{"a/b": "publish"},
+	}
+}
+
+func TestJournal_UnfinishedRunResumesPendingAndFailedItems(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := StartJournal(path, journalFixture())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if err := j.Result("a", ""); err != nil {
+		t.Fatalf("result: %v", err)
+	}
+	if err := j.Result("a/b", "boom"); err != nil {
+		t.Fatalf("result: %v", err)
+	}
+	if err := j.Result("3:a/b", ""); err != nil {
+		t.Fatalf("result: %v", err)
+	}
+	if err := j.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	st, err := LoadUnfinishedJournal(path)
+	if err != nil || st == nil {
+		t.Fatalf("expected unfinished journal, got %v, %v", st, err)
+	}
+	if st.Plan.SourceSpaceID != 1 || st.Plan.TargetSpaceID != 2 || st.Plan.PublishModes["a/b"] != "publish" {
+		t.Fatalf("plan not restored: %+v", st.Plan)
+	}
+	if done, failed, pending := st.Counts(); done != 2 || failed != 1 || pending != 1 {
+		t.Fatalf("counts = %d/%d/%d, want 2/1/1", done, failed, pending)
+	}
+	items := st.ResumeItems()
+	if len(items) != 2 || items[0].Story.FullSlug != "a/b" || items[1].Story.FullSlug != "a/c" {
+		t.Fatalf("unexpected resume items: %+v", items)
+	}
+	if items[0].Issue != "boom" || items[0].Run != RunPending || items[0].TargetSpaceID != 0 {
+		t.Fatalf("failed item not reset: %+v", items[0])
+	}
+}
+
+func TestJournal_LaterSuccessReplacesFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := StartJournal(path, journalFixture())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	_ = j.Result("a/c", "boom")
+	_ = j.Result("a/c", "")
+	_ = j.Close()
+
+	st, _ := LoadUnfinishedJournal(path)
+	if st.Results["a/c"] != RunSuccess || st.Errors["a/c"] != "" {
+		t.Fatalf("expected success without error, got %q %q", st.Results["a/c"], st.Errors["a/c"])
+	}
+}
+
+func TestJournal_FinishedOrMissingJournalIsNotResumable(t *testing.T) {
+	dir := t.TempDir()
+	if st, err := LoadUnfinishedJournal(filepath.Join(dir, "missing.jsonl")); st != nil || err != nil {
+		t.Fatalf("missing journal: %v, %v", st, err)
+	}
+
+	path := filepath.Join(dir, "journal.jsonl")
+	j, err := StartJournal(path, journalFixture())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	_ = j.Result("a", "")
+	if err := j.Finish(); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	if st, err := LoadUnfinishedJournal(path); st != nil || err != nil {
+		t.Fatalf("finished journal: %v, %v", st, err)
+	}
+	if err := j.Result("a/b", ""); err == nil {
+		t.Fatalf("expected error writing to finished journal")
+	}
+
+	if err := DiscardJournal(path); err != nil {
+		t.Fatalf("discard: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("journal not removed: %v", err)
+	}
+}
+
+func TestJournal_IgnoresTornLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := StartJournal(path, journalFixture())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	_ = j.Result("a", "")
+	_ = j.Close()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"type":"result","key":"a/b","sta`)
+	f.Close()
+
+	st, err := LoadUnfinishedJournal(path)
+	if err != nil || st == nil {
+		t.Fatalf("expected unfinished journal, got %v, %v", st, err)
+	}
+	if done, _, pending := st.Counts(); done != 1 || pending != 3 {
+		t.Fatalf("counts done=%d pending=%d, want 1/3", done, pending)
+	}
+}
@@ -0,0 +1,196 @@
+package sync
+
+import (
+	"fmt"
+	"strings"
+
+	"storyblok-sync/internal/sb"
+)
+
+// i18nMarker separates a translatable field name from its language code
+// (field-level translation: "title__i18n__fr").
+const i18nMarker = "__i18n__"
+
+// NormalizeLanguages parses a comma/space separated list of language codes,
+// dropping empty and duplicate entries while keeping the input order.
+func NormalizeLanguages(input string) []string {
+	fields := strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' || r == ';' })
+	seen := make(map[string]bool, len(fields))
+	var out []string
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		if f == "" || seen[f] {
+			continue
+		}
+		seen[f] = true
+		out = append(out, f)
+	}
+	return out
+}
+
+// ValidateLanguages reports the chosen codes the space has not configured.
+func ValidateLanguages(chosen []string, available []sb.Language) error {
+	have := make(map[string]bool, len(available))
+	for _, l := range available {
+		have[l.Code] = true
+	}
+	var missing []string
+	for _, c := range chosen {
+		if !have[c] {
+			missing = append(missing, c)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("sprachen im Ziel nicht konfiguriert: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// isLanguageKey reports whether key is a translated field of one of langs.
+func isLanguageKey(key string, langs []string) bool {
+	i := strings.LastIndex(key, i18nMarker)
+	if i <= 0 {
+		return false
+	}
+	code := key[i+len(i18nMarker):]
+	for _, l := range langs {
+		if code == l {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeLanguages returns a copy of target content in which only the translated
+// fields of langs are taken from source. Bloks are matched by _uid; bloks that
+// exist only in the source cannot be added without touching the default
+// language and are reported as unmatched _uids.
+func MergeLanguages(target, source map[string]interface{}, langs []string) (map[string]interface{}, []string) {
+	merged, _ := deepCopyJSON(target).(map[string]interface{})
+	if merged == nil {
+		merged = map[string]interface{}{}
+	}
+	sourceBloks := map[string]map[string]interface{}{}
+	indexBloks(source, sourceBloks)
+	targetBloks := map[string]map[string]interface{}{}
+	indexBloks(merged, targetBloks)
+
+	for uid, tb := range targetBloks {
+		sbk, ok := sourceBloks[uid]
+		if !ok {
+			continue
+		}
+		for k := range tb {
+			if isLanguageKey(k, langs) {
+				delete(tb, k)
+			}
+		}
+		for k, v := range sbk {
+			if isLanguageKey(k, langs) {
+				tb[k] = deepCopyJSON(v)
+			}
+		}
+	}
+
+	var unmatched []string
+	for uid, sbk := range sourceBloks {
+		if _, ok := targetBloks[uid]; ok {
+			continue
+		}
+		for k := range sbk {
+			if isLanguageKey(k, langs) {
+				unmatched = append(unmatched, uid)
+				break
+			}
+		}
+	}
+	return merged, unmatched
+}
+
+// MergeTranslatedSlugs keeps the target's translated slugs for all other
+// languages and replaces the entries of langs with the source ones. The result
+// is shaped for translated_slugs_attributes; source IDs are dropped, target IDs
+// are kept so the API updates the existing entries.
+func MergeTranslatedSlugs(target, source []interface{}, langs []string) []map[string]interface{} {
+	chosen := make(map[string]bool, len(langs))
+	for _, l := range langs {
+		chosen[l] = true
+	}
+	targetIDs := map[string]interface{}{}
+	out := make([]map[string]interface{}, 0, len(target)+len(source))
+	for _, item := range target {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		lang, _ := m["lang"].(string)
+		if chosen[lang] {
+			if id, ok := m["id"]; ok {
+				targetIDs[lang] = id
+			}
+			continue
+		}
+		out = append(out, copyMap(m))
+	}
+	for _, item := range source {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		lang, _ := m["lang"].(string)
+		if !chosen[lang] {
+			continue
+		}
+		entry := copyMap(m)
+		delete(entry, "id")
+		if id, ok := targetIDs[lang]; ok {
+			entry["id"] = id
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// indexBloks collects all maps carrying a _uid, keyed by that _uid.
+func indexBloks(v interface{}, out map[string]map[string]interface{}) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if uid, ok := t["_uid"].(string); ok && uid != "" {
+			if _, dup := out[uid]; !dup {
+				out[uid] = t
+			}
+		}
+		for _, child := range t {
+			indexBloks(child, out)
+		}
+	case []interface{}:
+		for _, child := range t {
+			indexBloks(child, out)
+		}
+	}
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	out, _ := deepCopyJSON(m).(map[string]interface{})
+	return out
+}
+
+// deepCopyJSON copies decoded JSON values (maps, slices, scalars).
+func deepCopyJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, child := range t {
+			out[k] = deepCopyJSON(child)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, child := range t {
+			out[i] = deepCopyJSON(child)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,107 @@
+package sync
+
+import (
+	"reflect"
+	"testing"
+
+	"storyblok-sync/internal/sb"
+)
+
+func TestMergeLanguages_OnlySelectedTranslations(t *testing.T) {
+	target := map[string]interface{}{
+		"_uid":            "root",
+		"component":       "page",
+		"title":           "Titel",
+		"title__i18n__de": "Titel DE",
+		"title__i18n__fr": "ancien",
+		"body": []interface{}{
+			map[string]interface{}{"_uid": "b1", "text": "Text", "text__i18n__fr": "vieux"},
+			map[string]interface{}{"_uid": "b2", "text": "Nur Ziel"},
+		},
+	}
+	source := map[string]interface{}{
+		"_uid":            "root",
+		"component":       "page",
+		"title":           "Changed default",
+		"title__i18n__de": "Geändert",
+		"title__i18n__fr": "nouveau",
+		"body": []interface{}{
+			map[string]interface{}{"_uid": "b1", "text": "Other", "text__i18n__fr": "neuf"},
+			map[string]interface{}{"_uid": "b3", "text__i18n__fr": "absent"},
+		},
+	}
+
+	merged, unmatched := MergeLanguages(target, source, []string{"fr"})
+
+	if merged["title"] != "Titel" || merged["title__i18n__de"] != "Titel DE" {
+		t.Fatalf("other languages must stay untouched: %+v", merged)
+	}
+	if merged["title__i18n__fr"] != "nouveau" {
+		t.Fatalf("expected fr title from source, got %v", merged["title__i18n__fr"])
+	}
+	body := merged["body"].([]interface{})
+	b1 := body[0].(map[string]interface{})
+	if b1["text"] != "Text" || b1["text__i18n__fr"] != "neuf" {
+		t.Fatalf("unexpected nested blok: %+v", b1)
+	}
+	if len(body) != 2 {
+		t.Fatalf("source-only bloks must not be added, got %d", len(body))
+	}
+	if !reflect.DeepEqual(unmatched, []string{"b3"}) {
+		t.Fatalf("expected unmatched b3, got %v", unmatched)
+	}
+	if target["title__i18n__fr"] != "ancien" {
+		t.Fatalf("target input must not be mutated")
+	}
+}
+
+func TestMergeLanguages_RemovesTranslationMissingInSource(t *testing.T) {
+	target := map[string]interface{}{"_uid": "r", "title__i18n__fr": "x", "title__i18n__de": "y"}
+	source := map[string]interface{}{"_uid": "r"}
+	merged, _ := MergeLanguages(target, source, []string{"fr"})
+	if _, ok := merged["title__i18n__fr"]; ok {
+		t.Fatalf("fr field should be cleared like in source")
+	}
+	if merged["title__i18n__de"] != "y" {
+		t.Fatalf("de field must stay")
+	}
+}
+
+func TestMergeTranslatedSlugs(t *testing.T) {
+	target := []interface{}{
+		map[string]interface{}{"id": 1, "lang": "de", "slug": "seite", "name": "Seite"},
+		map[string]interface{}{"id": 2, "lang": "fr", "slug": "ancienne", "name": "Ancienne"},
+	}
+	source := []interface{}{
+		map[string]interface{}{"id": 77, "lang": "de", "slug": "neu", "name": "Neu"},
+		map[string]interface{}{"id": 78, "lang": "fr", "slug": "page", "name": "Page"},
+	}
+	got := MergeTranslatedSlugs(target, source, []string{"fr"})
+	want := []map[string]interface{}{
+		{"id": 1, "lang": "de", "slug": "seite", "name": "Seite"},
+		{"id": 2, "lang": "fr", "slug": "page", "name": "Page"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestValidateLanguages(t *testing.T) {
+	avail := []sb.Language{{Code: "de"}, {Code: "fr"}}
+	if err := ValidateLanguages([]string{"fr"}, avail); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateLanguages([]string{"fr", "it"}, avail); err == nil {
+		t.Fatalf("expected error for missing it")
+	}
+}
+
+func TestNormalizeLanguages(t *testing.T) {
+	got := NormalizeLanguages(" fr, it ,fr;;de ")
+	if !reflect.DeepEqual(got, []string{"fr", "it", "de"}) {
+		t.Fatalf("got %v", got)
+	}
+	if NormalizeLanguages("  ") != nil {
+		t.Fatalf("expected nil for empty input")
+	}
+}
@@ -0,0 +1,361 @@
+package sync
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"storyblok-sync/internal/core/transform"
+)
+
+// Merge policies for updating existing target content
+const (
+	// MergeOverwrite replaces the target content with the source content
+	MergeOverwrite = "overwrite"
+	// MergeSourceWins takes every field present in source; target-only fields stay
+	MergeSourceWins = "source-wins-per-field"
+	// MergeFillMissing only fills fields that are missing or empty in the target
+	MergeFillMissing = "fill-missing-only"
+)
+
+// Field change actions reported by MergeContent
+const (
+	FieldOverwrite = "overwrite" // target value replaced by source
+	FieldFill      = "fill"      // empty target field filled from source
+	FieldKeep      = "keep"      // differing target value kept by policy
+	FieldProtected = "protected" // differing target value kept by a field rule
+	FieldRemove    = "remove"    // target-only field dropped (overwrite)
+)
+
+// MergePolicies lists the policies in toggle order.
+var MergePolicies = []string{MergeOverwrite, MergeSourceWins, MergeFillMissing}
+
+// ParseMergePolicy maps a policy name to a known policy (empty: overwrite).
+func ParseMergePolicy(v string) (string, error) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if v == "" {
+		return MergeOverwrite, nil
+	}
+	for _, p := range MergePolicies {
+		if v == p {
+			return p, nil
+		}
+	}
+	return MergeOverwrite, fmt.Errorf("unbekannte merge-policy %q", v)
+}
+
+// NextMergePolicy returns the policy following p in toggle order.
+func NextMergePolicy(p string) string {
+	for i, cur := range MergePolicies {
+		if cur == p {
+			return MergePolicies[(i+1)%len(MergePolicies)]
+		}
+	}
+	return MergeOverwrite
+}
+
+// FieldRules restricts which fields of a component may be written from source.
+// Deny entries always keep the target value; when allow entries exist for a
+// component, only those fields are taken from source. The component "*"
+// applies to all components.
+type FieldRules struct {
+	deny  map[string]map[string]bool
+	allow map[string]map[string]bool
+}
+
+// ParseFieldRules parses comma-separated "component.field" lists, e.g.
+// deny "*.seo,page.meta_title" and allow "teaser.headline".
+func ParseFieldRules(deny, allow string) (FieldRules, error) {
+	var r FieldRules
+	var err error
+	if r.deny, err = parseFieldList(deny); err != nil {
+		return FieldRules{}, err
+	}
+	if r.allow, err = parseFieldList(allow); err != nil {
+		return FieldRules{}, err
+	}
+	return r, nil
+}
+
+func parseFieldList(v string) (map[string]map[string]bool, error) {
+	out := map[string]map[string]bool{}
+	for _, part := range strings.Split(v, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		comp, field, ok := strings.Cut(part, ".")
+		comp, field = strings.TrimSpace(comp), strings.TrimSpace(field)
+		if !ok || comp == "" || field == "" {
+			return nil, fmt.Errorf("ungültige feldregel %q (erwartet komponente.feld)", part)
+		}
+		if out[comp] == nil {
+			out[comp] = map[string]bool{}
+		}
+		out[comp][field] = true
+	}
+	return out, nil
+}
+
+// Empty reports whether no rules are configured.
+func (r FieldRules) Empty() bool {
+	return len(r.deny) == 0 && len(r.allow) == 0
+}
+
+// Permits reports whether field of component may be written from source.
+func (r FieldRules) Permits(component, field string) bool {
+	if r.deny[component][field] || r.deny["*"][field] {
+		return false
+	}
+	allowed := r.allow[component]
+	wildcard := r.allow["*"]
+	if len(allowed) == 0 && len(wildcard) == 0 {
+		return true
+	}
+	return allowed[field] || wildcard[field]
+}
+
+// String renders the rules for headers, e.g. "deny *.seo; allow teaser.headline".
+func (r FieldRules) String() string {
+	var parts []string
+	if s := fieldListString(r.deny); s != "" {
+		parts = append(parts, "deny "+s)
+	}
+	if s := fieldListString(r.allow); s != "" {
+		parts = append(parts, "allow "+s)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func fieldListString(m map[string]map[string]bool) string {
+	var out []string
+	for comp, fields := range m {
+		for f := range fields {
+			out = append(out, comp+"."+f)
+		}
+	}
+	sort.Strings(out)
+	return strings.Join(out, ",")
+}
+
+// FieldChange describes how a single differing field was merged.
+type FieldChange struct {
+	Path      string // blok location, e.g. "body[b1]"
+	Component string
+	Field     string
+	Action    string
+}
+
+// String renders a compact description of the change for the diff view.
+func (c FieldChange) String() string {
+	loc := c.Field
+	if c.Path != "" {
+		loc = c.Path + "." + c.Field
+	}
+	return fmt.Sprintf("%-9s %s (%s)", c.Action, loc, c.Component)
+}
+
+// MergeContent merges source content into target content according to policy
+// and rules. Nested bloks are matched by _uid. Only fields whose values differ
+// are reported as changes. Inputs are not modified.
+func MergeContent(target, source map[string]interface{}, policy string, rules FieldRules) (map[string]interface{}, []FieldChange) {
+	mg := merger{policy: policy, rules: rules}
+	if target == nil {
+		target = map[string]interface{}{}
+	}
+	if source == nil {
+		source = map[string]interface{}{}
+	}
+	merged := mg.mergeBlok(target, source, "")
+	return merged, mg.changes
+}
+
+// PreviewMerge reports the field changes an update writes for the raw source
+// and target payloads, in the order SyncStory applies them: with a recorded
+// base content the three-way merge against it, then the merge of the result
+// (or of the source without base) into the target content per policy and
+// rules, then the transforms for the target space. Fields rewritten by a
+// transform are reported as differing from the target like merged ones;
+// conflicts that keep the target value are reported as kept.
+func PreviewMerge(target, source, base map[string]interface{}, resolutions map[string]string, policy string, rules FieldRules, transforms *transform.Pipeline, targetSpaceID int) []FieldChange {
+	tgtContent, _ := target["content"].(map[string]interface{})
+	srcContent, _ := source["content"].(map[string]interface{})
+	var conflicts []MergeConflict
+	if base != nil {
+		srcContent, conflicts = ThreeWayMerge(base, tgtContent, srcContent, resolutions)
+	}
+	merged, changes := MergeContent(tgtContent, srcContent, policy, rules)
+	for _, c := range conflicts {
+		if c.Resolution == ResolveSource {
+			continue
+		}
+		path, field := "", c.Path
+		if i := strings.LastIndex(c.Path, "."); i >= 0 {
+			path, field = c.Path[:i], c.Path[i+1:]
+		}
+		changes = append(changes, FieldChange{Path: path, Field: field, Action: FieldKeep})
+	}
+
+	payload := copyMap(source)
+	if payload == nil {
+		payload = map[string]interface{}{}
+	}
+	payload["content"] = copyMap(merged)
+	if transforms.Apply(payload, targetSpaceID) == 0 {
+		return changes
+	}
+	final, _ := payload["content"].(map[string]interface{})
+	_, transformed := MergeContent(merged, final, MergeOverwrite, FieldRules{})
+	seen := make(map[string]int, len(changes))
+	for i, c := range changes {
+		seen[c.Path+"."+c.Field] = i
+	}
+	for _, c := range transformed {
+		i, ok := seen[c.Path+"."+c.Field]
+		switch {
+		case !ok:
+			changes = append(changes, c)
+		case changes[i].Action == FieldKeep || changes[i].Action == FieldProtected || c.Action == FieldRemove:
+			changes[i].Action = c.Action
+		}
+	}
+	return changes
+}
+
+type merger struct {
+	policy  string
+	rules   FieldRules
+	changes []FieldChange
+}
+
+func (mg *merger) record(path, component, field, action string) {
+	mg.changes = append(mg.changes, FieldChange{Path: path, Component: component, Field: field, Action: action})
+}
+
+func (mg *merger) mergeBlok(target, source map[string]interface{}, path string) map[string]interface{} {
+	out, _ := deepCopyJSON(target).(map[string]interface{})
+	component, _ := source["component"].(string)
+	if component == "" {
+		component, _ = target["component"].(string)
+	}
+
+	for _, k := range sortedKeys(source) {
+		sv := source[k]
+		tv, exists := target[k]
+		if k == "_uid" || k == "component" {
+			if !exists {
+				out[k] = sv
+			}
+			continue
+		}
+		if exists && reflect.DeepEqual(tv, sv) {
+			continue
+		}
+		if !mg.rules.Permits(component, k) {
+			mg.record(path, component, k, FieldProtected)
+			continue
+		}
+		if tl, ok := blokList(tv); ok && exists {
+			if sl, ok := blokList(sv); ok {
+				out[k] = mg.mergeBlokList(tl, sl, joinPath(path, k))
+				continue
+			}
+		}
+		if tm, ok := tv.(map[string]interface{}); ok && isBlok(tm) {
+			if sm, ok := sv.(map[string]interface{}); ok && isBlok(sm) && tm["_uid"] == sm["_uid"] {
+				out[k] = mg.mergeBlok(tm, sm, joinPath(path, k))
+				continue
+			}
+		}
+		switch {
+		case !exists || isEmptyContentValue(tv):
+			out[k] = deepCopyJSON(sv)
+			mg.record(path, component, k, FieldFill)
+		case mg.policy == MergeFillMissing:
+			mg.record(path, component, k, FieldKeep)
+		default:
+			out[k] = deepCopyJSON(sv)
+			mg.record(path, component, k, FieldOverwrite)
+		}
+	}
+
+	// Overwrite drops target-only fields unless a rule protects them
+	if mg.policy == MergeOverwrite {
+		for _, k := range sortedKeys(target) {
+			if _, ok := source[k]; ok {
+				continue
+			}
+			if !mg.rules.Permits(component, k) {
+				mg.record(path, component, k, FieldProtected)
+				continue
+			}
+			delete(out, k)
+			mg.record(path, component, k, FieldRemove)
+		}
+	}
+	return out
+}
+
+// mergeBlokList merges blok lists by _uid. Overwrite and source-wins follow the
+// source's composition; fill-missing keeps the target's bloks and order.
+func (mg *merger) mergeBlokList(target, source []map[string]interface{}, path string) []interface{} {
+	byUID := func(list []map[string]interface{}) map[string]map[string]interface{} {
+		idx := make(map[string]map[string]interface{}, len(list))
+		for _, b := range list {
+			if uid, _ := b["_uid"].(string); uid != "" {
+				idx[uid] = b
+			}
+		}
+		return idx
+	}
+	var out []interface{}
+	if mg.policy == MergeFillMissing {
+		src := byUID(source)
+		for _, tb := range target {
+			uid, _ := tb["_uid"].(string)
+			if sbk, ok := src[uid]; ok {
+				out = append(out, mg.mergeBlok(tb, sbk, blokPath(path, uid)))
+			} else {
+				out = append(out, deepCopyJSON(tb))
+			}
+		}
+		return out
+	}
+	tgt := byUID(target)
+	for _, sbk := range source {
+		uid, _ := sbk["_uid"].(string)
+		if tb, ok := tgt[uid]; ok {
+			out = append(out, mg.mergeBlok(tb, sbk, blokPath(path, uid)))
+		} else {
+			out = append(out, deepCopyJSON(sbk))
+		}
+	}
+	return out
+}
+
+// blokList reports whether v is a non-empty list of bloks.
+func blokList(v interface{}) ([]map[string]interface{}, bool) {
+	arr, ok := v.([]interface{})
+	if !ok || len(arr) == 0 {
+		return nil, false
+	}
+	out := make([]map[string]interface{}, 0, len(arr))
+	for _, item := range arr {
+		m, ok := item.(map[string]interface{})
+		if !ok || !isBlok(m) {
+			return nil, false
+		}
+		out = append(out, m)
+	}
+	return out, true
+}
+
+func isBlok(m map[string]interface{}) bool {
+	uid, _ := m["_uid"].(string)
+	return uid != ""
+}
+
+func blokPath(path, uid string) string {
+	return path + "[" + uid + "]"
+}
@@ -0,0 +1,192 @@
+package sync
+
+import (
+	"reflect"
+	"testing"
+
+	"storyblok-sync/internal/core/transform"
+)
+
+func mergeFixture() (target, source map[string]interface{}) {
+	target = map[string]interface{}{
+		"_uid":      "root",
+		"component": "page",
+		"title":     "Lokal angepasst",
+		"subtitle":  "",
+		"seo":       map[string]interface{}{"title": "Ziel-SEO"},
+		"local":     "nur im Ziel",
+		"body": []interface{}{
+			map[string]interface{}{"_uid": "b1", "component": "teaser", "headline": "Ziel", "cta": "Mehr"},
+			map[string]interface{}{"_uid": "b2", "component": "teaser", "headline": "nur Ziel"},
+		},
+	}
+	source = map[string]interface{}{
+		"_uid":      "root",
+		"component": "page",
+		"title":     "Quelle",
+		"subtitle":  "Untertitel",
+		"seo":       map[string]interface{}{"title": "Quell-SEO"},
+		"body": []interface{}{
+			map[string]interface{}{"_uid": "b1", "component": "teaser", "headline": "Quelle", "cta": "Mehr"},
+			map[string]interface{}{"_uid": "b3", "component": "teaser", "headline": "neu"},
+		},
+	}
+	return target, source
+}
+
+func TestMergeContent_OverwriteMatchesSource(t *testing.T) {
+	target, source := mergeFixture()
+	merged, changes := MergeContent(target, source, MergeOverwrite, FieldRules{})
+	if !reflect.DeepEqual(merged, source) {
+		t.Fatalf("overwrite without rules must equal source:\n%+v", merged)
+	}
+	if len(changes) == 0 {
+		t.Fatalf("expected changes to be reported")
+	}
+}
+
+func TestMergeContent_OverwriteKeepsProtectedFields(t *testing.T) {
+	target, source := mergeFixture()
+	rules, err := ParseFieldRules("*.seo,page.local", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged, changes := MergeContent(target, source, MergeOverwrite, rules)
+	if merged["seo"].(map[string]interface{})["title"] != "Ziel-SEO" || merged["local"] != "nur im Ziel" {
+		t.Fatalf("protected fields must keep target values: %+v", merged)
+	}
+	if merged["title"] != "Quelle" {
+		t.Fatalf("unprotected fields come from source")
+	}
+	protected := 0
+	for _, c := range changes {
+		if c.Action == FieldProtected {
+			protected++
+		}
+	}
+	if protected != 2 {
+		t.Fatalf("expected 2 protected changes, got %+v", changes)
+	}
+}
+
+func TestPreviewMerge_ThreeWayWithBase(t *testing.T) {
+	base := map[string]interface{}{"_uid": "r", "component": "page", "title": "A", "intro": "A", "link": "https://www.example.com/a"}
+	target := map[string]interface{}{"content": map[string]interface{}{"_uid": "r", "component": "page", "title": "Ziel", "intro": "A", "link": "https://www.example.com/a"}}
+	source := map[string]interface{}{"content": map[string]interface{}{"_uid": "r", "component": "page", "title": "Quelle", "intro": "Quelle", "link": "https://www.example.com/a"}}
+	p, err := transform.Parse([]byte(`{"rules":[{"op":"regex_replace","path":"$.content..link","pattern":"example\\.com","replace":"example.ch"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// intro changed in source only, title in both (conflict keeps the target),
+	// link is rewritten by the transform after the merge
+	changes := PreviewMerge(target, source, base, nil, MergeOverwrite, FieldRules{}, p, 20)
+	actions := map[string]string{}
+	for _, c := range changes {
+		actions[c.Field] = c.Action
+	}
+	want := map[string]string{"intro": FieldOverwrite, "title": FieldKeep, "link": FieldOverwrite}
+	if !reflect.DeepEqual(actions, want) {
+		t.Fatalf("actions = %v, want %v (%+v)", actions, want, changes)
+	}
+
+	changes = PreviewMerge(target, source, base, map[string]string{"title": ResolveSource}, MergeOverwrite, FieldRules{}, nil, 20)
+	actions = map[string]string{}
+	for _, c := range changes {
+		actions[c.Field] = c.Action
+	}
+	if !reflect.DeepEqual(actions, map[string]string{"intro": FieldOverwrite, "title": FieldOverwrite}) {
+		t.Fatalf("resolved actions = %v", actions)
+	}
+}
+
+func TestMergeContent_SourceWinsPerField(t *testing.T) {
+	target, source := mergeFixture()
+	merged, _ := MergeContent(target, source, MergeSourceWins, FieldRules{})
+	if merged["title"] != "Quelle" || merged["local"] != "nur im Ziel" {
+		t.Fatalf("unexpected root fields: %+v", merged)
+	}
+	body := merged["body"].([]interface{})
+	if len(body) != 2 || body[0].(map[string]interface{})["headline"] != "Quelle" || body[1].(map[string]interface{})["_uid"] != "b3" {
+		t.Fatalf("blok list should follow source composition: %+v", body)
+	}
+}
+
+func TestMergeContent_FillMissingOnly(t *testing.T) {
+	target, source := mergeFixture()
+	merged, changes := MergeContent(target, source, MergeFillMissing, FieldRules{})
+	if merged["title"] != "Lokal angepasst" || merged["subtitle"] != "Untertitel" {
+		t.Fatalf("only empty fields may be filled: %+v", merged)
+	}
+	body := merged["body"].([]interface{})
+	if len(body) != 2 || body[0].(map[string]interface{})["headline"] != "Ziel" || body[1].(map[string]interface{})["_uid"] != "b2" {
+		t.Fatalf("target bloks must be kept: %+v", body)
+	}
+	want := FieldChange{Path: "body[b1]", Component: "teaser", Field: "headline", Action: FieldKeep}
+	found := false
+	for _, c := range changes {
+		if c == want {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected %+v in %+v", want, changes)
+	}
+}
+
+func TestPreviewMerge_TransformsAfterMerge(t *testing.T) {
+	target := map[string]interface{}{"content": map[string]interface{}{"_uid": "r", "component": "page", "title": "Ziel", "seo": "Ziel"}}
+	source := map[string]interface{}{"content": map[string]interface{}{"_uid": "r", "component": "page", "title": "Quelle", "seo": "Quelle"}}
+	rules, err := ParseFieldRules("page.seo", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := transform.Parse([]byte(`{"rules":[{"op":"set","path":"$.content.seo","value":"Markt"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The transform rewrites the protected field after the merge kept it
+	changes := PreviewMerge(target, source, nil, nil, MergeOverwrite, rules, p, 20)
+	actions := map[string]string{}
+	for _, c := range changes {
+		actions[c.Field] = c.Action
+	}
+	if actions["title"] != FieldOverwrite || actions["seo"] != FieldOverwrite || len(changes) != 2 {
+		t.Fatalf("unexpected preview: %+v", changes)
+	}
+	if source["content"].(map[string]interface{})["seo"] != "Quelle" {
+		t.Fatalf("preview modified the source payload")
+	}
+
+	changes = PreviewMerge(target, source, nil, nil, MergeOverwrite, rules, nil, 20)
+	if len(changes) != 2 || changes[0].Field != "seo" || changes[0].Action != FieldProtected {
+		t.Fatalf("without transforms: %+v", changes)
+	}
+}
+
+func TestFieldRules_AllowList(t *testing.T) {
+	rules, err := ParseFieldRules("", "teaser.headline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rules.Permits("teaser", "headline") || rules.Permits("teaser", "cta") || !rules.Permits("page", "title") {
+		t.Fatalf("unexpected allow semantics")
+	}
+	if _, err := ParseFieldRules("seo", ""); err == nil {
+		t.Fatalf("expected error for rule without component")
+	}
+}
+
+func TestParseMergePolicy(t *testing.T) {
+	if p, err := ParseMergePolicy(" Fill-Missing-Only "); err != nil || p != MergeFillMissing {
+		t.Fatalf("got %q, %v", p, err)
+	}
+	if p, err := ParseMergePolicy(""); err != nil || p != MergeOverwrite {
+		t.Fatalf("empty should default to overwrite")
+	}
+	if _, err := ParseMergePolicy("merge-all"); err == nil {
+		t.Fatalf("expected error for unknown policy")
+	}
+	if NextMergePolicy(MergeFillMissing) != MergeOverwrite {
+		t.Fatalf("toggle should wrap around")
+	}
+}
@@ -0,0 +1,41 @@
+package sync
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"storyblok-sync/internal/infra/metricsx"
+)
+
+var (
+	limiterRPS = metricsx.Factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sbsync_space_limiter_rps",
+		Help: "Current rate of the per-space limiter in requests per second.",
+	}, []string{"space_id", "kind"})
+	itemDuration = metricsx.Factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sbsync_item_duration_seconds",
+		Help:    "Duration of story and folder syncs including retries and limiter waits.",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"kind", "status"})
+)
+
+// publish exposes the current rate of one bucket of a shared limiter.
+func (sl *SpaceLimiter) publish(spaceID int, kind string, rps float64) {
+	if sl.metrics {
+		limiterRPS.WithLabelValues(strconv.Itoa(spaceID), kind).Set(rps)
+	}
+}
+
+// observeItem records the duration of one story or folder sync.
+func observeItem(kind string, d time.Duration, res *SyncItemResult, err error) {
+	status := "success"
+	switch {
+	case err != nil:
+		status = "failure"
+	case res != nil && res.Warning != "":
+		status = "warning"
+	}
+	itemDuration.WithLabelValues(kind, status).Observe(d.Seconds())
+}
@@ -7,6 +7,9 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"storyblok-sync/internal/core/tagsync"
+	"storyblok-sync/internal/core/transform"
+	"storyblok-sync/internal/infra/tracex"
 	"storyblok-sync/internal/sb"
 )
 
@@ -18,6 +21,15 @@ type SyncOrchestrator struct {
 	sourceSpace *sb.Space
 	targetSpace *sb.Space
 	targetIndex map[string]sb.Story
+	tags        *tagsync.Reconciler
+	workflow    *WorkflowGuard
+	releaseID   int
+	languages   []string
+	mergePolicy string
+	fieldRules  FieldRules
+	transforms  *transform.Pipeline
+	base        *BaseStore
+	limiter     *SpaceLimiter
 }
 
 // SyncAPI defines the interface for sync API operations
@@ -43,6 +55,12 @@ type SyncItem interface {
 	IsFolder() bool
 }
 
+// MergeResolver is implemented by sync items that carry conflict resolutions
+// chosen in the preflight resolver.
+type MergeResolver interface {
+	MergeResolutions() map[string]string
+}
+
 // NewSyncOrchestrator creates a new sync orchestrator
 func NewSyncOrchestrator(api SyncAPI, report ReportInterface, sourceSpace, targetSpace *sb.Space, targetIndex map[string]sb.Story) *SyncOrchestrator {
 	return &SyncOrchestrator{
@@ -55,6 +73,51 @@ func NewSyncOrchestrator(api SyncAPI, report ReportInterface, sourceSpace, targe
 	}
 }
 
+// SetTagReconciler shares a target tag reconciler with all syncers of this
+// orchestrator. The reconciler caches target tags across items.
+func (so *SyncOrchestrator) SetTagReconciler(r *tagsync.Reconciler) {
+	so.tags = r
+}
+
+// SetWorkflowGuard applies the target workflow guard to story updates.
+func (so *SyncOrchestrator) SetWorkflowGuard(g *WorkflowGuard) {
+	so.workflow = g
+}
+
+// SetReleaseID stages all story and folder writes in the given target release.
+func (so *SyncOrchestrator) SetReleaseID(id int) {
+	so.releaseID = id
+}
+
+// SetLanguages restricts story updates to the given languages' translations
+// (nil: full sync).
+func (so *SyncOrchestrator) SetLanguages(langs []string) {
+	so.languages = langs
+}
+
+// SetMergePolicy applies a content merge policy and field rules to story updates.
+func (so *SyncOrchestrator) SetMergePolicy(policy string, rules FieldRules) {
+	so.mergePolicy = policy
+	so.fieldRules = rules
+}
+
+// SetTransforms rewrites story payloads with the pipeline before each write.
+func (so *SyncOrchestrator) SetTransforms(p *transform.Pipeline) {
+	so.transforms = p
+}
+
+// SetBaseStore enables three-way merges against recorded bases and records
+// the payload of each successful story write.
+func (so *SyncOrchestrator) SetBaseStore(bs *BaseStore) {
+	so.base = bs
+}
+
+// SetSpaceLimiter shares one limiter with all syncers of this orchestrator
+// (nil: each syncer limits on its own).
+func (so *SyncOrchestrator) SetSpaceLimiter(l *SpaceLimiter) {
+	so.limiter = l
+}
+
 // RunSyncItem executes sync for a single item and returns a Bubble Tea command
 func (so *SyncOrchestrator) RunSyncItem(ctx context.Context, idx int, item SyncItem) tea.Cmd {
 	return func() tea.Msg {
@@ -68,6 +131,14 @@ func (so *SyncOrchestrator) RunSyncItem(ctx context.Context, idx int, item SyncI
 		story := item.GetStory()
 		log.Printf("Starting sync for item %d: %s (folder: %t)", idx, story.FullSlug, story.IsFolder)
 
+		_, span := tracex.Start(ctx, "sync.item",
+			tracex.String("slug", story.FullSlug),
+			tracex.Bool("folder", story.IsFolder))
+		if so.targetSpace != nil {
+			span.SetAttrs(tracex.Int("target_space_id", so.targetSpace.ID))
+		}
+		defer span.End()
+
 		startTime := time.Now()
 		var err error
 		var result *SyncItemResult
@@ -77,18 +148,26 @@ func (so *SyncOrchestrator) RunSyncItem(ctx context.Context, idx int, item SyncI
 		case item.IsFolder():
 			err = so.SyncWithRetry(func() error {
 				var syncErr error
-				result, syncErr = so.SyncFolderDetailed(story)
+				result, syncErr = so.syncFolder(span, story)
 				return syncErr
 			})
 		default:
 			err = so.SyncWithRetry(func() error {
 				var syncErr error
-				result, syncErr = so.SyncStoryDetailed(story)
+				var res map[string]string
+				if r, ok := item.(MergeResolver); ok {
+					res = r.MergeResolutions()
+				}
+				result, syncErr = so.syncStory(span, story, res)
 				return syncErr
 			})
 		}
 
 		duration := time.Since(startTime).Milliseconds()
+		span.SetError(err)
+		if result != nil {
+			span.SetAttrs(tracex.String("operation", result.Operation), tracex.Int("retries", result.RetryTotal))
+		}
 
 		// Log results
 		if err != nil {
@@ -126,18 +205,36 @@ func (so *SyncOrchestrator) ShouldPublish() bool {
 
 // SyncFolderDetailed synchronizes a folder using StorySyncer
 func (so *SyncOrchestrator) SyncFolderDetailed(story sb.Story) (*SyncItemResult, error) {
+	return so.syncFolder(nil, story)
+}
+
+// syncFolder runs a folder sync whose requests are traced below span.
+func (so *SyncOrchestrator) syncFolder(span *tracex.Span, story sb.Story) (*SyncItemResult, error) {
 	plan := 0
 	if so.targetSpace != nil {
 		plan = so.targetSpace.PlanLevel
 	}
 	syncer := NewStorySyncerWithPlan(so.api, so.sourceSpace.ID, so.targetSpace.ID, so.targetIndex, plan)
+	syncer.SetLimiter(so.limiter)
+	syncer.SetReleaseID(so.releaseID)
+	syncer.SetLanguages(so.languages)
+	syncer.SetTraceSpan(span)
 	// Publish folders: never; for completeness compute publish flag but it will be ignored for folders
 	publish := so.ShouldPublish() && story.Published
-	return syncer.SyncFolderDetailed(story, publish)
+	start := time.Now()
+	res, err := syncer.SyncFolderDetailed(story, publish)
+	observeItem("folder", time.Since(start), res, err)
+	return res, err
 }
 
 // SyncStoryDetailed synchronizes a story using StorySyncer
 func (so *SyncOrchestrator) SyncStoryDetailed(story sb.Story) (*SyncItemResult, error) {
+	return so.syncStory(nil, story, nil)
+}
+
+// syncStory runs a story sync with the conflict resolutions of its item;
+// its requests are traced below span.
+func (so *SyncOrchestrator) syncStory(span *tracex.Span, story sb.Story, resolutions map[string]string) (*SyncItemResult, error) {
 	// Compute publish flag from source item and target dev mode
 	publish := so.ShouldPublish() && story.Published
 
@@ -146,7 +243,20 @@ func (so *SyncOrchestrator) SyncStoryDetailed(story sb.Story) (*SyncItemResult,
 		plan = so.targetSpace.PlanLevel
 	}
 	syncer := NewStorySyncerWithPlan(so.api, so.sourceSpace.ID, so.targetSpace.ID, so.targetIndex, plan)
-	return syncer.SyncStoryDetailed(story, publish)
+	syncer.SetLimiter(so.limiter)
+	syncer.SetTagReconciler(so.tags)
+	syncer.SetWorkflowGuard(so.workflow)
+	syncer.SetReleaseID(so.releaseID)
+	syncer.SetLanguages(so.languages)
+	syncer.SetMergePolicy(so.mergePolicy, so.fieldRules)
+	syncer.SetTransforms(so.transforms)
+	syncer.SetBaseStore(so.base)
+	syncer.SetResolutions(resolutions)
+	syncer.SetTraceSpan(span)
+	start := time.Now()
+	res, err := syncer.SyncStoryDetailed(story, publish)
+	observeItem("story", time.Since(start), res, err)
+	return res, err
 }
 
 // removed unused folderReportAdapter
@@ -25,6 +25,24 @@ type PreflightItem struct {
 	NewSlug                string            // normalized final slug for default locale
 	NewTranslatedPaths     map[string]string // lang → new full path (for translated slugs)
 	AppendCopySuffixToName bool              // if true, append " (copy)" to Name
+
+	// SchemaBlocked marks items skipped by schema validation (policy "block"),
+	// so a later validation run can release them again.
+	SchemaBlocked bool
+
+	// Workflow stage of the existing target story (set by the workflow check).
+	// WorkflowBlocked marks items skipped because their stage is locked.
+	WorkflowStage   string
+	WorkflowLocked  bool
+	WorkflowBlocked bool
+
+	// Three-way merge conflicts found by the conflict check and the side
+	// chosen per conflict path in the resolver (ResolveSource/ResolveTarget).
+	Conflicts   []MergeConflict
+	Resolutions map[string]string
+
+	// Target space of a fanned-out item (0: the run's only target).
+	TargetSpaceID int
 }
 
 // State constants for preflight items
@@ -116,9 +134,20 @@ func (pp *PreflightPlanner) OptimizePreflight(items []PreflightItem) []Preflight
 		log.Printf("DEBUG: Auto-added missing folder to preflight: %s", folder.FullSlug)
 	}
 
-	// Sort by sync priority: folders first (by depth), then stories
-	sort.Slice(optimized, func(i, j int) bool {
-		itemI, itemJ := optimized[i], optimized[j]
+	SortForSync(optimized)
+
+	// Update the list
+	log.Printf("Optimized to %d items (%d missing folders auto-added), sync order: folders first, then stories",
+		len(optimized), len(missingFolders))
+
+	return optimized
+}
+
+// SortForSync orders items by sync priority: folders first (shallow before
+// deep), then stories, alphabetically within the same depth.
+func SortForSync(items []PreflightItem) {
+	sort.Slice(items, func(i, j int) bool {
+		itemI, itemJ := items[i], items[j]
 
 		// Folders always come before stories
 		if itemI.Story.IsFolder && !itemJ.Story.IsFolder {
@@ -139,12 +168,6 @@ func (pp *PreflightPlanner) OptimizePreflight(items []PreflightItem) []Preflight
 		// Same depth - sort alphabetically for consistent order
 		return itemI.Story.FullSlug < itemJ.Story.FullSlug
 	})
-
-	// Update the list
-	log.Printf("Optimized to %d items (%d missing folders auto-added), sync order: folders first, then stories",
-		len(optimized), len(missingFolders))
-
-	return optimized
 }
 
 // FindMissingFolderPaths analyzes preflight items and finds missing parent folders
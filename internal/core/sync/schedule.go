@@ -0,0 +1,90 @@
+package sync
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PublishAtLayout is the UTC format written to publish_at.
+const PublishAtLayout = "2006-01-02T15:04:05.000Z"
+
+// scheduleInputLayouts are accepted user inputs, interpreted in the target timezone.
+var scheduleInputLayouts = []string{
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+}
+
+// ErrScheduleInPast is returned when a publish time is not in the future.
+var ErrScheduleInPast = errors.New("publish_at liegt nicht in der Zukunft")
+
+// LoadTimezone resolves an IANA timezone name; unknown or empty names fall back to UTC.
+func LoadTimezone(name string) *time.Location {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ParseSchedule parses a user-entered publish time. Inputs without offset are
+// interpreted in loc (the target space's timezone); RFC3339 inputs keep their offset.
+func ParseSchedule(input string, loc *time.Location) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if loc == nil {
+		loc = time.UTC
+	}
+	if t, err := time.Parse(time.RFC3339, input); err == nil {
+		return t, nil
+	}
+	for _, layout := range scheduleInputLayouts {
+		if t, err := time.ParseInLocation(layout, input, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("ungültiger Zeitpunkt %q (erwartet YYYY-MM-DD HH:MM)", input)
+}
+
+// ParsePublishAt parses a publish_at value as returned by the API.
+func ParsePublishAt(v string) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	for _, layout := range []string{PublishAtLayout, time.RFC3339, "2006-01-02 15:04"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("ungültiges publish_at %q", v)
+}
+
+// ValidateSchedule ensures t lies after now.
+func ValidateSchedule(t, now time.Time) error {
+	if !t.After(now) {
+		return ErrScheduleInPast
+	}
+	return nil
+}
+
+// FormatPublishAt renders t as UTC publish_at value.
+func FormatPublishAt(t time.Time) string {
+	return t.UTC().Format(PublishAtLayout)
+}
+
+// FutureSourceSchedule returns the source story's publish_at when it lies in
+// the future, so it can be carried over to the target.
+func FutureSourceSchedule(publishAt string, now time.Time) (string, bool) {
+	if publishAt == "" {
+		return "", false
+	}
+	t, err := ParsePublishAt(publishAt)
+	if err != nil || ValidateSchedule(t, now) != nil {
+		return "", false
+	}
+	return FormatPublishAt(t), true
+}
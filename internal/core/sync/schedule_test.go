@@ -0,0 +1,40 @@
+package sync
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseScheduleUsesTargetTimezone(t *testing.T) {
+	loc := LoadTimezone("Europe/Berlin")
+	got, err := ParseSchedule("2030-06-01 10:00", loc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// CEST is UTC+2
+	if FormatPublishAt(got) != "2030-06-01T08:00:00.000Z" {
+		t.Fatalf("unexpected UTC value: %s", FormatPublishAt(got))
+	}
+	if _, err := ParseSchedule("tomorrow", loc); err == nil {
+		t.Fatal("expected parse error")
+	}
+	if LoadTimezone("Not/AZone") != time.UTC || LoadTimezone("") != time.UTC {
+		t.Fatal("unknown timezones should fall back to UTC")
+	}
+}
+
+func TestValidateScheduleAndCarryOver(t *testing.T) {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err := ValidateSchedule(now.Add(-time.Minute), now); err != ErrScheduleInPast {
+		t.Fatalf("expected ErrScheduleInPast, got %v", err)
+	}
+	if err := ValidateSchedule(now.Add(time.Hour), now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if at, ok := FutureSourceSchedule("2030-02-01T09:30:00.000Z", now); !ok || at != "2030-02-01T09:30:00.000Z" {
+		t.Fatalf("future source schedule should carry over: %q %v", at, ok)
+	}
+	if _, ok := FutureSourceSchedule("2029-12-01T09:30:00.000Z", now); ok {
+		t.Fatal("past source schedule must not carry over")
+	}
+}
@@ -0,0 +1,256 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"storyblok-sync/internal/sb"
+)
+
+// Schema validation policies for preflight items
+const (
+	SchemaPolicyWarn  = "warn"
+	SchemaPolicyBlock = "block"
+)
+
+// Schema issue kinds
+const (
+	SchemaIssueUnknownComponent = "unknown_component"
+	SchemaIssueUnknownField     = "unknown_field"
+	SchemaIssueMissingRequired  = "missing_required"
+)
+
+// SchemaIssue describes a single mismatch between story content and the
+// target space's component schemas.
+type SchemaIssue struct {
+	Path      string // location inside content, e.g. "body[1]"
+	Component string
+	Field     string
+	Kind      string
+}
+
+// String renders a compact, human-readable description of the issue.
+func (i SchemaIssue) String() string {
+	loc := i.Component
+	if i.Path != "" {
+		loc = i.Path + " (" + i.Component + ")"
+	}
+	switch i.Kind {
+	case SchemaIssueUnknownComponent:
+		return fmt.Sprintf("unknown component %q at %s", i.Component, pathOrRoot(i.Path))
+	case SchemaIssueUnknownField:
+		return fmt.Sprintf("field %q not in schema of %s", i.Field, loc)
+	case SchemaIssueMissingRequired:
+		return fmt.Sprintf("required field %q missing in %s", i.Field, loc)
+	default:
+		return fmt.Sprintf("%s: %s.%s", i.Kind, loc, i.Field)
+	}
+}
+
+func pathOrRoot(p string) string {
+	if p == "" {
+		return "root"
+	}
+	return p
+}
+
+// schemaField captures the subset of a component field definition relevant for validation.
+type schemaField struct {
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+}
+
+// SchemaValidator checks story content against a set of component schemas.
+type SchemaValidator struct {
+	schemas map[string]map[string]schemaField
+}
+
+// NewSchemaValidator builds a validator from the target space's components.
+// Components with unparsable schemas are treated as having no fields.
+func NewSchemaValidator(components []sb.Component) *SchemaValidator {
+	v := &SchemaValidator{schemas: make(map[string]map[string]schemaField, len(components))}
+	for _, c := range components {
+		if c.Name == "" {
+			continue
+		}
+		fields := make(map[string]schemaField)
+		if len(c.Schema) > 0 && string(c.Schema) != "null" {
+			var raw map[string]schemaField
+			if err := json.Unmarshal(c.Schema, &raw); err == nil {
+				for k, f := range raw {
+					// Tabs and sections only structure the editor; they never hold content
+					if f.Type == "tab" || f.Type == "section" {
+						continue
+					}
+					fields[k] = f
+				}
+			}
+		}
+		v.schemas[c.Name] = fields
+	}
+	return v
+}
+
+// HasComponent reports whether the validator knows a component by name.
+func (v *SchemaValidator) HasComponent(name string) bool {
+	_, ok := v.schemas[name]
+	return ok
+}
+
+// ValidateStory validates the typed story's content. Folders and stories
+// without content yield no issues.
+func (v *SchemaValidator) ValidateStory(story sb.Story) []SchemaIssue {
+	if story.IsFolder || len(story.Content) == 0 {
+		return nil
+	}
+	return v.ValidateContent(ToRawMap(story.Content))
+}
+
+// ValidateContent walks a content tree and returns all schema issues found.
+// Every map carrying a string "component" key is treated as a blok; other maps
+// and arrays (e.g. richtext documents) are searched for nested bloks.
+func (v *SchemaValidator) ValidateContent(content map[string]interface{}) []SchemaIssue {
+	var issues []SchemaIssue
+	v.walk(content, "", &issues)
+	return issues
+}
+
+func (v *SchemaValidator) walk(node interface{}, path string, issues *[]SchemaIssue) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if name, ok := n["component"].(string); ok && name != "" {
+			v.validateBlok(n, name, path, issues)
+			return
+		}
+		for _, k := range sortedKeys(n) {
+			v.walk(n[k], joinPath(path, k), issues)
+		}
+	case []interface{}:
+		for i, e := range n {
+			v.walk(e, fmt.Sprintf("%s[%d]", path, i), issues)
+		}
+	}
+}
+
+func (v *SchemaValidator) validateBlok(blok map[string]interface{}, name, path string, issues *[]SchemaIssue) {
+	fields, known := v.schemas[name]
+	if !known {
+		*issues = append(*issues, SchemaIssue{Path: path, Component: name, Kind: SchemaIssueUnknownComponent})
+	}
+	for _, k := range sortedKeys(blok) {
+		if known && !isSystemContentKey(k) {
+			if _, ok := fields[baseFieldName(k)]; !ok {
+				*issues = append(*issues, SchemaIssue{Path: path, Component: name, Field: k, Kind: SchemaIssueUnknownField})
+			}
+		}
+		// Always descend so nested bloks of unknown parents are still validated
+		v.walk(blok[k], joinPath(path, k), issues)
+	}
+	if !known {
+		return
+	}
+	for _, k := range sortedFieldKeys(fields) {
+		if fields[k].Required && isEmptyContentValue(blok[k]) {
+			*issues = append(*issues, SchemaIssue{Path: path, Component: name, Field: k, Kind: SchemaIssueMissingRequired})
+		}
+	}
+}
+
+// isSystemContentKey reports keys Storyblok adds to every blok.
+func isSystemContentKey(k string) bool {
+	switch k {
+	case "_uid", "component", "_editable":
+		return true
+	}
+	return false
+}
+
+// baseFieldName strips the translation suffix (field__i18n__de -> field).
+func baseFieldName(k string) string {
+	if i := strings.Index(k, "__i18n__"); i > 0 {
+		return k[:i]
+	}
+	return k
+}
+
+func isEmptyContentValue(v interface{}) bool {
+	switch vv := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(vv) == ""
+	case []interface{}:
+		return len(vv) == 0
+	}
+	return false
+}
+
+func joinPath(base, key string) string {
+	if base == "" {
+		return key
+	}
+	return base + "." + key
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedFieldKeys(m map[string]schemaField) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ApplySchemaIssues records validation results on a preflight item.
+// With SchemaPolicyBlock, items with issues are skipped; items previously
+// blocked by schema validation are released again when the issues are gone.
+func ApplySchemaIssues(item *PreflightItem, issues []SchemaIssue, policy string) {
+	if item.SchemaBlocked {
+		item.SchemaBlocked = false
+		item.Skip = false
+		item.State = activeState(item)
+	}
+	if len(issues) == 0 {
+		if strings.HasPrefix(item.Issue, "schema: ") {
+			item.Issue = ""
+		}
+		return
+	}
+	item.Issue = "schema: " + SummarizeSchemaIssues(issues)
+	if policy == SchemaPolicyBlock && !item.Skip {
+		item.SchemaBlocked = true
+		item.Skip = true
+		item.State = StateSkip
+	}
+}
+
+// activeState derives the non-skip state of an item from its collision flag.
+func activeState(it *PreflightItem) string {
+	if it.Collision && !it.CopyAsNew {
+		return StateUpdate
+	}
+	return StateCreate
+}
+
+// SummarizeSchemaIssues returns the first issue plus a count of the rest.
+func SummarizeSchemaIssues(issues []SchemaIssue) string {
+	if len(issues) == 0 {
+		return ""
+	}
+	s := issues[0].String()
+	if len(issues) > 1 {
+		s += fmt.Sprintf(" (+%d more)", len(issues)-1)
+	}
+	return s
+}
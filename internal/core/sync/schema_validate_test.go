@@ -0,0 +1,127 @@
+package sync
+
+import (
+	"encoding/json"
+	"testing"
+
+	"storyblok-sync/internal/sb"
+)
+
+func testSchemaComponents() []sb.Component {
+	return []sb.Component{
+		{Name: "page", Schema: json.RawMessage(`{"title":{"type":"text","required":true},"body":{"type":"bloks"},"tab-1":{"type":"tab"}}`)},
+		{Name: "teaser", Schema: json.RawMessage(`{"headline":{"type":"text"},"text":{"type":"richtext"}}`)},
+		{Name: "cta", Schema: json.RawMessage(`{"label":{"type":"text"}}`)},
+	}
+}
+
+func TestSchemaValidator_ValidContent(t *testing.T) {
+	v := NewSchemaValidator(testSchemaComponents())
+	content := map[string]interface{}{
+		"_uid":            "1",
+		"component":       "page",
+		"_editable":       "<!-- -->",
+		"title":           "Hello",
+		"title__i18n__de": "Hallo",
+		"body": []interface{}{
+			map[string]interface{}{"_uid": "2", "component": "teaser", "headline": "x"},
+		},
+	}
+	if issues := v.ValidateContent(content); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %+v", issues)
+	}
+}
+
+func TestSchemaValidator_DetectsIssues(t *testing.T) {
+	v := NewSchemaValidator(testSchemaComponents())
+	content := map[string]interface{}{
+		"_uid":      "1",
+		"component": "page",
+		"extra":     "nope",
+		"body": []interface{}{
+			map[string]interface{}{"_uid": "2", "component": "gallery"},
+		},
+	}
+	issues := v.ValidateContent(content)
+	kinds := map[string]SchemaIssue{}
+	for _, is := range issues {
+		kinds[is.Kind] = is
+	}
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d: %+v", len(issues), issues)
+	}
+	if is := kinds[SchemaIssueUnknownField]; is.Field != "extra" || is.Component != "page" {
+		t.Fatalf("unexpected unknown_field issue: %+v", is)
+	}
+	if is := kinds[SchemaIssueUnknownComponent]; is.Component != "gallery" || is.Path != "body[0]" {
+		t.Fatalf("unexpected unknown_component issue: %+v", is)
+	}
+	if is := kinds[SchemaIssueMissingRequired]; is.Field != "title" {
+		t.Fatalf("unexpected missing_required issue: %+v", is)
+	}
+}
+
+func TestSchemaValidator_FindsBloksInRichtext(t *testing.T) {
+	v := NewSchemaValidator(testSchemaComponents())
+	content := map[string]interface{}{
+		"_uid":      "1",
+		"component": "teaser",
+		"text": map[string]interface{}{
+			"type": "doc",
+			"content": []interface{}{
+				map[string]interface{}{
+					"type": "blok",
+					"attrs": map[string]interface{}{
+						"body": []interface{}{
+							map[string]interface{}{"_uid": "3", "component": "cta", "label": "go", "color": "red"},
+						},
+					},
+				},
+			},
+		},
+	}
+	issues := v.ValidateContent(content)
+	if len(issues) != 1 || issues[0].Kind != SchemaIssueUnknownField || issues[0].Field != "color" {
+		t.Fatalf("expected single unknown field in richtext blok, got %+v", issues)
+	}
+}
+
+func TestSchemaValidator_ValidateStorySkipsFolders(t *testing.T) {
+	v := NewSchemaValidator(nil)
+	if issues := v.ValidateStory(sb.Story{IsFolder: true, Content: json.RawMessage(`{"component":"x"}`)}); issues != nil {
+		t.Fatalf("expected no issues for folder, got %+v", issues)
+	}
+	issues := v.ValidateStory(sb.Story{Content: json.RawMessage(`{"_uid":"1","component":"x"}`)})
+	if len(issues) != 1 || issues[0].Kind != SchemaIssueUnknownComponent {
+		t.Fatalf("expected unknown component, got %+v", issues)
+	}
+}
+
+func TestApplySchemaIssues_Policies(t *testing.T) {
+	issues := []SchemaIssue{{Component: "page", Field: "x", Kind: SchemaIssueUnknownField}, {Component: "gallery", Kind: SchemaIssueUnknownComponent}}
+
+	warn := PreflightItem{Collision: true, State: StateUpdate}
+	ApplySchemaIssues(&warn, issues, SchemaPolicyWarn)
+	if warn.Skip || warn.State != StateUpdate || warn.Issue == "" {
+		t.Fatalf("warn policy should only annotate: %+v", warn)
+	}
+
+	block := PreflightItem{Collision: true, State: StateUpdate}
+	ApplySchemaIssues(&block, issues, SchemaPolicyBlock)
+	if !block.Skip || block.State != StateSkip || !block.SchemaBlocked {
+		t.Fatalf("block policy should skip item: %+v", block)
+	}
+
+	// Re-validation without issues releases the block and clears the message
+	ApplySchemaIssues(&block, nil, SchemaPolicyBlock)
+	if block.Skip || block.State != StateUpdate || block.SchemaBlocked || block.Issue != "" {
+		t.Fatalf("expected item to be released: %+v", block)
+	}
+
+	// Items skipped by the user stay skipped
+	user := PreflightItem{Skip: true, State: StateSkip}
+	ApplySchemaIssues(&user, issues, SchemaPolicyBlock)
+	if user.SchemaBlocked || !user.Skip {
+		t.Fatalf("user skip must not be converted to schema block: %+v", user)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"sync"
 	"time"
+
+	"storyblok-sync/internal/infra/tracex"
 )
 
 // SpaceLimiter provides per-space token buckets for reads and writes.
@@ -15,6 +17,9 @@ type SpaceLimiter struct {
 	defReadRPS  float64
 	defWriteRPS float64
 	burst       float64
+	// metrics publishes the rates; only shared limiters do, so that
+	// short-lived per-syncer limiters don't overwrite them
+	metrics bool
 }
 
 type spaceBuckets struct {
@@ -105,6 +110,19 @@ func DefaultLimitsForPlan(planLevel int) (readRPS, writeRPS float64, burst int)
 	return 7, 7, 7
 }
 
+// SetSpaceLimits gives one space its own read/write ceilings, e.g. when the
+// limiter is shared by spaces on different plans.
+func (sl *SpaceLimiter) SetSpaceLimits(spaceID int, readRPS, writeRPS float64, burst int) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.spaces[spaceID] = &spaceBuckets{
+		read:  newBucket(readRPS, float64(burst)),
+		write: newBucket(writeRPS, float64(burst)),
+	}
+	sl.publish(spaceID, "read", readRPS)
+	sl.publish(spaceID, "write", writeRPS)
+}
+
 func (sl *SpaceLimiter) get(spaceID int) *spaceBuckets {
 	sl.mu.Lock()
 	defer sl.mu.Unlock()
@@ -117,25 +135,37 @@ func (sl *SpaceLimiter) get(spaceID int) *spaceBuckets {
 		write: newBucket(sl.defWriteRPS, sl.burst),
 	}
 	sl.spaces[spaceID] = sb
+	sl.publish(spaceID, "read", sl.defReadRPS)
+	sl.publish(spaceID, "write", sl.defWriteRPS)
 	return sb
 }
 
 func (sl *SpaceLimiter) WaitRead(ctx context.Context, spaceID int) error {
-	return sl.get(spaceID).read.wait(ctx)
+	return traceWait(ctx, spaceID, "read", sl.get(spaceID).read)
 }
 func (sl *SpaceLimiter) WaitWrite(ctx context.Context, spaceID int) error {
-	return sl.get(spaceID).write.wait(ctx)
+	return traceWait(ctx, spaceID, "write", sl.get(spaceID).write)
+}
+
+// traceWait waits on b inside a "space_limiter.wait" span.
+func traceWait(ctx context.Context, spaceID int, kind string, b *tbucket) error {
+	_, span := tracex.Start(ctx, "space_limiter.wait", tracex.Int("space_id", spaceID), tracex.String("kind", kind))
+	err := b.wait(ctx)
+	span.SetError(err)
+	span.End()
+	return err
 }
 
 // Nudge increases/decreases the effective RPS within [min,max].
 func (sl *SpaceLimiter) NudgeRead(spaceID int, delta, min, max float64) {
-	sl.nudge(sl.get(spaceID).read, delta, min, max)
+	sl.publish(spaceID, "read", sl.nudge(sl.get(spaceID).read, delta, min, max))
 }
 func (sl *SpaceLimiter) NudgeWrite(spaceID int, delta, min, max float64) {
-	sl.nudge(sl.get(spaceID).write, delta, min, max)
+	sl.publish(spaceID, "write", sl.nudge(sl.get(spaceID).write, delta, min, max))
 }
 
-func (sl *SpaceLimiter) nudge(b *tbucket, delta, min, max float64) {
+// nudge adjusts the bucket's rate and returns the new rate.
+func (sl *SpaceLimiter) nudge(b *tbucket, delta, min, max float64) float64 {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	r := b.rps + delta
@@ -146,4 +176,5 @@ func (sl *SpaceLimiter) nudge(b *tbucket, delta, min, max float64) {
 		r = max
 	}
 	b.rps = r
+	return r
 }
@@ -5,6 +5,9 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"storyblok-sync/internal/infra/metricsx"
+	"storyblok-sync/internal/sb"
 )
 
 // fakeClock allows deterministic control of time passage for testing
@@ -442,3 +445,40 @@ func TestDefaultLimitsForPlan(t *testing.T) {
 		}
 	}
 }
+
+func TestSpaceLimiterPublishesRPS(t *testing.T) {
+	published := func() map[string]float64 {
+		got := map[string]float64{}
+		families, err := metricsx.Registry.Gather()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range families {
+			if f.GetName() != "sbsync_space_limiter_rps" {
+				continue
+			}
+			for _, m := range f.GetMetric() {
+				labels := map[string]string{}
+				for _, l := range m.GetLabel() {
+					labels[l.GetName()] = l.GetValue()
+				}
+				if labels["space_id"] == "4242" {
+					got[labels["kind"]] = m.GetGauge().GetValue()
+				}
+			}
+		}
+		return got
+	}
+	sl := NewFanOutLimiter(sb.Space{ID: 4242, PlanLevel: 1})
+	sl.NudgeWrite(4242, -1, 1, 7)
+	if got := published(); got["read"] != 7 || got["write"] != 6 {
+		t.Fatalf("unexpected published rates %v", got)
+	}
+
+	// A syncer's own limiter must not overwrite the shared rates
+	ss := NewStorySyncerWithPlan(nil, 1, 4242, nil, 0)
+	ss.limiter.NudgeWrite(4242, -1, 1, 3)
+	if got := published(); got["read"] != 7 || got["write"] != 6 {
+		t.Fatalf("per-syncer limiter overwrote published rates: %v", got)
+	}
+}
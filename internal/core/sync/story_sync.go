@@ -3,10 +3,14 @@ package sync
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"strings"
 	"time"
 
+	"storyblok-sync/internal/core/tagsync"
+	"storyblok-sync/internal/core/transform"
+	"storyblok-sync/internal/infra/tracex"
 	"storyblok-sync/internal/sb"
 )
 
@@ -18,6 +22,19 @@ type StorySyncer struct {
 	targetSpaceID  int
 	existingBySlug map[string]sb.Story
 	limiter        *SpaceLimiter
+	tags           *tagsync.Reconciler
+	createdTags    []string
+	workflow       *WorkflowGuard
+	releaseID      int
+	languages      []string
+	warnings       []string
+	mergePolicy    string
+	fieldRules     FieldRules
+	transforms     *transform.Pipeline
+	base           *BaseStore
+	resolutions    map[string]string
+	conflicts      []MergeConflict
+	trace          *tracex.Span
 }
 
 // storyRawAPI captures optional raw story methods available on the API client
@@ -47,6 +64,118 @@ func NewStorySyncerWithPlan(api SyncAPI, sourceSpaceID, targetSpaceID int, exist
 	return ss
 }
 
+// SetLimiter replaces the syncer's own limiter with a shared one, so that
+// concurrent syncers draw from the same per-space budgets.
+func (ss *StorySyncer) SetLimiter(l *SpaceLimiter) {
+	if l != nil {
+		ss.limiter = l
+	}
+}
+
+// SetTagReconciler enables creating missing story tags in the target before writes.
+func (ss *StorySyncer) SetTagReconciler(r *tagsync.Reconciler) {
+	ss.tags = r
+}
+
+// SetWorkflowGuard makes updates preserve (and, if allowed, temporarily leave)
+// the target story's workflow stage.
+func (ss *StorySyncer) SetWorkflowGuard(g *WorkflowGuard) {
+	ss.workflow = g
+}
+
+// SetReleaseID stages all writes of this syncer in the given target release (0: live).
+func (ss *StorySyncer) SetReleaseID(id int) {
+	ss.releaseID = id
+}
+
+// SetLanguages restricts story updates to the translated fields and slugs of
+// the given language codes (nil: sync everything).
+func (ss *StorySyncer) SetLanguages(langs []string) {
+	ss.languages = langs
+}
+
+// SetMergePolicy selects how source content is merged into existing target
+// content and which component fields must keep their target value.
+func (ss *StorySyncer) SetMergePolicy(policy string, rules FieldRules) {
+	ss.mergePolicy = policy
+	ss.fieldRules = rules
+}
+
+// SetTransforms rewrites story payloads with the pipeline's rules just
+// before they are written to the target.
+func (ss *StorySyncer) SetTransforms(p *transform.Pipeline) {
+	ss.transforms = p
+}
+
+// SetBaseStore records written payloads as merge bases and three-way merges
+// updates of stories that have a recorded base.
+func (ss *StorySyncer) SetBaseStore(bs *BaseStore) {
+	ss.base = bs
+}
+
+// SetResolutions passes conflict resolutions (path → ResolveSource/ResolveTarget)
+// chosen in the preflight resolver for the next story.
+func (ss *StorySyncer) SetResolutions(res map[string]string) {
+	ss.resolutions = res
+}
+
+// SetTraceSpan makes the requests of the next Detailed sync children of span.
+func (ss *StorySyncer) SetTraceSpan(span *tracex.Span) {
+	ss.trace = span
+}
+
+// applyTransforms runs the transform pipeline on a story payload.
+func (ss *StorySyncer) applyTransforms(raw map[string]interface{}, fullSlug string) {
+	if n := ss.transforms.Apply(raw, ss.targetSpaceID); n > 0 {
+		log.Printf("Transformed story %s: %d change(s)", fullSlug, n)
+	}
+}
+
+// mergesContent reports whether updates need the target content for merging.
+func (ss *StorySyncer) mergesContent() bool {
+	return (ss.mergePolicy != "" && ss.mergePolicy != MergeOverwrite) || !ss.fieldRules.Empty()
+}
+
+// withSource attaches the source story to a write context for the audit log.
+func (ss *StorySyncer) withSource(ctx context.Context, story sb.Story) context.Context {
+	return sb.WithSource(ctx, sb.Source{SpaceID: ss.sourceSpaceID, StoryID: story.ID, FullSlug: story.FullSlug})
+}
+
+// withRelease attaches the configured release to a write context.
+func (ss *StorySyncer) withRelease(ctx context.Context) context.Context {
+	if ss.releaseID > 0 {
+		return sb.WithReleaseID(ctx, ss.releaseID)
+	}
+	return ctx
+}
+
+// applyPublishAt sets the schedule chosen for the story or clears a copied
+// source schedule when none is requested.
+func applyPublishAt(raw map[string]interface{}, story sb.Story) {
+	if story.PublishAt != "" {
+		raw["publish_at"] = story.PublishAt
+		return
+	}
+	delete(raw, "publish_at")
+}
+
+// ensureStoryTags creates tags referenced by the raw payload's tag_list that do
+// not exist in the target yet. Failures are logged only; the write proceeds.
+func (ss *StorySyncer) ensureStoryTags(ctx context.Context, raw map[string]interface{}) {
+	if ss.tags == nil {
+		return
+	}
+	names := tagsync.TagNames(raw["tag_list"])
+	if len(names) == 0 {
+		return
+	}
+	created, err := ss.tags.EnsureStoryTags(ctx, names)
+	ss.createdTags = append(ss.createdTags, created...)
+	if err != nil {
+		log.Printf("Warning: failed to ensure tags %v in target: %v", names, err)
+	}
+}
+
 // Content manager is internal; on-demand MA reads ensure correctness.
 
 // SyncStory synchronizes a single story
@@ -97,6 +226,23 @@ func (ss *StorySyncer) SyncStory(ctx context.Context, story sb.Story, shouldPubl
 				return sb.Story{}, err
 			}
 			ss.limiter.NudgeRead(ss.sourceSpaceID, +0.02, 1, 7)
+			source := copyMap(raw)
+
+			// Language-scoped sync: write the target's content with only the selected translations replaced
+			if len(ss.languages) > 0 {
+				raw, err = ss.mergeLanguagePayload(ctx, rawAPI, existingStory.ID, raw)
+				if err != nil {
+					return sb.Story{}, err
+				}
+			} else if base := ss.loadBase(existingStory.ID); base != nil {
+				if err := ss.threeWayContent(ctx, rawAPI, existingStory.ID, raw, base); err != nil {
+					return sb.Story{}, err
+				}
+			} else if ss.mergesContent() {
+				if err := ss.mergeTargetContent(ctx, rawAPI, existingStory.ID, raw); err != nil {
+					return sb.Story{}, err
+				}
+			}
 
 			// Strip read-only fields and ensure correct parent_id
 			delete(raw, "id")
@@ -121,9 +267,22 @@ func (ss *StorySyncer) SyncStory(ctx context.Context, story sb.Story, shouldPubl
 				delete(raw, "translated_slugs")
 			}
 
+			ss.ensureStoryTags(ctx, raw)
+			applyPublishAt(raw, fullStory)
+			ss.applyTransforms(raw, fullStory.FullSlug)
+
 			// DEBUG: omit raw payload dump to keep logs readable
 			log.Printf("DEBUG: PUSH_RAW_UPDATE story %s (payload omitted)", story.FullSlug)
 
+			// A language-scoped write only takes the selected translations from
+			// source, so the base is what was written rather than the source
+			var base map[string]interface{}
+			if len(ss.languages) > 0 {
+				base = copyMap(raw)
+			} else {
+				base = ss.transformedBase(source)
+			}
+
 			_ = ss.limiter.WaitWrite(ctx, ss.targetSpaceID)
 			updated, err := ss.updateWithFolderFallback(ctx, rawAPI, existingStory.ID, raw, shouldPublish, ParentSlug(story.FullSlug))
 			if err != nil {
@@ -133,6 +292,7 @@ func (ss *StorySyncer) SyncStory(ctx context.Context, story sb.Story, shouldPubl
 				return sb.Story{}, err
 			}
 			ss.limiter.NudgeWrite(ss.targetSpaceID, +0.02, 1, 7)
+			ss.saveBase(existingStory.ID, base)
 
 			// Update UUID if different
 			if updated.UUID != fullStory.UUID && fullStory.UUID != "" {
@@ -149,12 +309,18 @@ func (ss *StorySyncer) SyncStory(ctx context.Context, story sb.Story, shouldPubl
 			return updated, nil
 		}
 
-		// Fallback to typed update
+		// Fallback to typed update. It writes the whole story, which would
+		// overwrite the target's other languages in a language-scoped sync.
+		if len(ss.languages) > 0 {
+			return sb.Story{}, fmt.Errorf("sprach-sync für %s braucht Raw-Story-Zugriff", fullStory.FullSlug)
+		}
 		updateStory := PrepareStoryForUpdate(fullStory, existingStory)
 		// DEBUG: omit typed payload dump to keep logs readable
 		log.Printf("DEBUG: PUSH_TYPED_UPDATE story %s (payload omitted)", story.FullSlug)
+		payload := map[string]interface{}{"uuid": updateStory.UUID, "name": updateStory.Name, "slug": updateStory.Slug, "full_slug": updateStory.FullSlug, "content": toMap(updateStory.Content), "is_folder": updateStory.IsFolder, "parent_id": valueOrZero(updateStory.FolderID)}
+		ss.applyTransforms(payload, fullStory.FullSlug)
 		_ = ss.limiter.WaitWrite(ctx, ss.targetSpaceID)
-		updated, err := ss.api.UpdateStoryRawWithPublish(ctx, ss.targetSpaceID, existingStory.ID, map[string]interface{}{"uuid": updateStory.UUID, "name": updateStory.Name, "slug": updateStory.Slug, "full_slug": updateStory.FullSlug, "content": toMap(updateStory.Content), "is_folder": updateStory.IsFolder, "parent_id": valueOrZero(updateStory.FolderID)}, shouldPublish)
+		updated, err := ss.api.UpdateStoryRawWithPublish(ctx, ss.targetSpaceID, existingStory.ID, payload, shouldPublish)
 		if err != nil {
 			if IsRateLimited(err) {
 				ss.limiter.NudgeWrite(ss.targetSpaceID, -0.2, 1, 7)
@@ -194,6 +360,7 @@ func (ss *StorySyncer) SyncStory(ctx context.Context, story sb.Story, shouldPubl
 				return sb.Story{}, err
 			}
 			ss.limiter.NudgeRead(ss.sourceSpaceID, +0.02, 1, 7)
+			source := copyMap(raw)
 
 			// Strip read-only fields
 			delete(raw, "id")
@@ -241,6 +408,10 @@ func (ss *StorySyncer) SyncStory(ctx context.Context, story sb.Story, shouldPubl
 				delete(raw, "translated_slugs")
 			}
 
+			ss.ensureStoryTags(ctx, raw)
+			applyPublishAt(raw, fullStory)
+			ss.applyTransforms(raw, fullStory.FullSlug)
+
 			// DEBUG: omit raw create payload dump
 			log.Printf("DEBUG: PUSH_RAW_CREATE story %s (payload omitted)", story.FullSlug)
 
@@ -253,6 +424,7 @@ func (ss *StorySyncer) SyncStory(ctx context.Context, story sb.Story, shouldPubl
 				return sb.Story{}, err
 			}
 			ss.limiter.NudgeWrite(ss.targetSpaceID, +0.02, 1, 7)
+			ss.saveBase(created.ID, ss.transformedBase(source))
 
 			// Update UUID if different after create
 			if created.UUID != fullStory.UUID && fullStory.UUID != "" {
@@ -275,8 +447,10 @@ func (ss *StorySyncer) SyncStory(ctx context.Context, story sb.Story, shouldPubl
 		// DEBUG: omit typed create payload dump
 		log.Printf("DEBUG: PUSH_TYPED_CREATE story %s (payload omitted)", story.FullSlug)
 
+		payload := map[string]interface{}{"uuid": createStory.UUID, "name": createStory.Name, "slug": createStory.Slug, "full_slug": createStory.FullSlug, "content": toMap(createStory.Content), "is_folder": createStory.IsFolder, "parent_id": valueOrZero(createStory.FolderID)}
+		ss.applyTransforms(payload, fullStory.FullSlug)
 		_ = ss.limiter.WaitWrite(ctx, ss.targetSpaceID)
-		created, err := ss.api.CreateStoryRawWithPublish(ctx, ss.targetSpaceID, map[string]interface{}{"uuid": createStory.UUID, "name": createStory.Name, "slug": createStory.Slug, "full_slug": createStory.FullSlug, "content": toMap(createStory.Content), "is_folder": createStory.IsFolder, "parent_id": valueOrZero(createStory.FolderID)}, shouldPublish)
+		created, err := ss.api.CreateStoryRawWithPublish(ctx, ss.targetSpaceID, payload, shouldPublish)
 		if err != nil {
 			if IsRateLimited(err) {
 				ss.limiter.NudgeWrite(ss.targetSpaceID, -0.2, 1, 7)
@@ -301,6 +475,120 @@ func (ss *StorySyncer) SyncStory(ctx context.Context, story sb.Story, shouldPubl
 	}
 }
 
+// mergeLanguagePayload builds an update payload from the target story in which
+// only the translated fields and slugs of the selected languages come from source.
+func (ss *StorySyncer) mergeLanguagePayload(ctx context.Context, rawAPI storyRawAPI, targetID int, source map[string]interface{}) (map[string]interface{}, error) {
+	_ = ss.limiter.WaitRead(ctx, ss.targetSpaceID)
+	target, err := rawAPI.GetStoryRaw(ctx, ss.targetSpaceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	ss.limiter.NudgeRead(ss.targetSpaceID, +0.02, 1, 7)
+
+	srcContent, _ := source["content"].(map[string]interface{})
+	tgtContent, _ := target["content"].(map[string]interface{})
+	content, unmatched := MergeLanguages(tgtContent, srcContent, ss.languages)
+	target["content"] = content
+	if len(unmatched) > 0 {
+		ss.warnings = append(ss.warnings, fmt.Sprintf("%d Blok(s) fehlen im Ziel, Übersetzungen übersprungen", len(unmatched)))
+	}
+
+	srcSlugs, _ := source["translated_slugs"].([]interface{})
+	tgtSlugs, _ := target["translated_slugs"].([]interface{})
+	target["translated_slugs_attributes"] = MergeTranslatedSlugs(tgtSlugs, srcSlugs, ss.languages)
+	delete(target, "translated_slugs")
+	return target, nil
+}
+
+// mergeTargetContent replaces the source payload's content with the merge of
+// source into the current target content per merge policy and field rules.
+func (ss *StorySyncer) mergeTargetContent(ctx context.Context, rawAPI storyRawAPI, targetID int, raw map[string]interface{}) error {
+	_ = ss.limiter.WaitRead(ctx, ss.targetSpaceID)
+	target, err := rawAPI.GetStoryRaw(ctx, ss.targetSpaceID, targetID)
+	if err != nil {
+		return err
+	}
+	ss.limiter.NudgeRead(ss.targetSpaceID, +0.02, 1, 7)
+
+	srcContent, _ := raw["content"].(map[string]interface{})
+	tgtContent, _ := target["content"].(map[string]interface{})
+	raw["content"] = ss.policyMerge(tgtContent, srcContent, raw)
+	return nil
+}
+
+// policyMerge merges source into target content per merge policy and field
+// rules.
+func (ss *StorySyncer) policyMerge(tgtContent, srcContent, raw map[string]interface{}) map[string]interface{} {
+	policy := ss.mergePolicy
+	if policy == "" {
+		policy = MergeOverwrite
+	}
+	merged, changes := MergeContent(tgtContent, srcContent, policy, ss.fieldRules)
+	slug, _ := raw["full_slug"].(string)
+	log.Printf("DEBUG: MERGE %s: %d field change(s) (policy %s)", slug, len(changes), policy)
+	return merged
+}
+
+// loadBase returns the recorded base content of a target story (nil: none).
+func (ss *StorySyncer) loadBase(targetID int) map[string]interface{} {
+	if ss.base == nil {
+		return nil
+	}
+	payload, ok, err := ss.base.Load(ss.targetSpaceID, targetID)
+	if err != nil {
+		log.Printf("Warning: failed to load merge base for story %d: %v", targetID, err)
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	content, _ := payload["content"].(map[string]interface{})
+	return content
+}
+
+// transformedBase runs the transforms on the merged source payload, so the
+// recorded base compares like with like against the transformed target.
+func (ss *StorySyncer) transformedBase(source map[string]interface{}) map[string]interface{} {
+	ss.transforms.Apply(source, ss.targetSpaceID)
+	return source
+}
+
+// saveBase records the merged source payload as base for the next sync.
+// Failures are logged only; the write itself succeeded.
+func (ss *StorySyncer) saveBase(targetID int, payload map[string]interface{}) {
+	if ss.base == nil || targetID == 0 {
+		return
+	}
+	if err := ss.base.Save(ss.targetSpaceID, targetID, payload); err != nil {
+		log.Printf("Warning: failed to save merge base for story %d: %v", targetID, err)
+	}
+}
+
+// threeWayContent replaces the source payload's content with the three-way
+// merge of source and current target content against the recorded base. Merge
+// policy and field rules then apply to the merged content as without a base.
+func (ss *StorySyncer) threeWayContent(ctx context.Context, rawAPI storyRawAPI, targetID int, raw, base map[string]interface{}) error {
+	_ = ss.limiter.WaitRead(ctx, ss.targetSpaceID)
+	target, err := rawAPI.GetStoryRaw(ctx, ss.targetSpaceID, targetID)
+	if err != nil {
+		return err
+	}
+	ss.limiter.NudgeRead(ss.targetSpaceID, +0.02, 1, 7)
+
+	srcContent, _ := raw["content"].(map[string]interface{})
+	tgtContent, _ := target["content"].(map[string]interface{})
+	merged, conflicts := ThreeWayMerge(base, tgtContent, srcContent, ss.resolutions)
+	if ss.mergesContent() {
+		merged = ss.policyMerge(tgtContent, merged, raw)
+	}
+	raw["content"] = merged
+	ss.conflicts = append(ss.conflicts, conflicts...)
+	if open := UnresolvedConflicts(conflicts); open > 0 {
+		ss.warnings = append(ss.warnings, fmt.Sprintf("%d Merge-Konflikt(e) ohne Auflösung, Zielwert behalten", open))
+	}
+	return nil
+}
+
 // resolveParentFolderFromIndex resolves and sets the correct parent folder ID using the in-memory target index
 func (ss *StorySyncer) resolveParentFolderFromIndex(story sb.Story) sb.Story {
 	parent := ParentSlug(story.FullSlug)
@@ -518,32 +806,64 @@ func (ss *StorySyncer) SyncStoryDetailed(story sb.Story, shouldPublish bool) (*S
 	defer cancel()
 	// Attach per-item retry counters to context so transport can attribute retries
 	rc := &sb.RetryCounters{}
-	ctx = sb.WithRetryCounters(ctx, rc)
+	ctx = ss.withRelease(sb.WithRetryCounters(tracex.WithSpan(ctx, ss.trace), rc))
+	ctx = ss.withSource(ctx, story)
 
 	// Determine operation type from in-memory index only (avoid extra GET);
 	// fall back to SyncStory internal checks for correctness.
 	operation := OperationCreate
-	if _, ok := ss.existingBySlug[story.FullSlug]; ok {
+	targetID := 0
+	if existing, ok := ss.existingBySlug[story.FullSlug]; ok {
 		operation = OperationUpdate
+		targetID = existing.ID
 	} else {
 		// Fallback to a single GET only when index lacks entry
 		if existing, _ := ss.api.GetStoriesBySlug(ctx, ss.targetSpaceID, story.FullSlug); len(existing) > 0 {
 			operation = OperationUpdate
+			targetID = existing[0].ID
 		}
 	}
 
-	targetStory, err := ss.SyncStory(ctx, story, shouldPublish)
+	// Language-scoped sync only adds translations to existing stories
+	if len(ss.languages) > 0 && operation == OperationCreate {
+		return &SyncItemResult{Operation: OperationSkip, Warning: "Sprach-Sync: Story fehlt im Ziel, übersprungen"}, nil
+	}
+
+	ss.createdTags = nil
+	ss.warnings = nil
+	ss.conflicts = nil
+	var targetStory sb.Story
+	write := func() error {
+		var err error
+		targetStory, err = ss.SyncStory(ctx, story, shouldPublish)
+		return err
+	}
+	// Updates run inside the workflow guard to keep the target's stage
+	var steps []WorkflowStep
+	var err error
+	if ss.workflow != nil && targetID != 0 {
+		steps, err = ss.workflow.Run(ctx, targetID, write)
+	} else {
+		err = write()
+	}
 	if err != nil {
 		// Return counters even on error
-		return &SyncItemResult{Operation: operation, RetryTotal: int(rc.Total), Retry429: int(rc.Status429)}, err
+		return &SyncItemResult{Operation: operation, RetryTotal: int(rc.Total), Retry429: int(rc.Status429), CreatedTags: ss.createdTags, WorkflowSteps: steps, MergeConflicts: ss.conflicts}, err
 	}
 
+	warnings := ss.warnings
+	if w := FailedWorkflowSteps(steps); w != "" {
+		warnings = append(warnings, w)
+	}
 	return &SyncItemResult{
-		Operation:   operation,
-		TargetStory: &targetStory,
-		Warning:     "",
-		RetryTotal:  int(rc.Total),
-		Retry429:    int(rc.Status429),
+		Operation:      operation,
+		TargetStory:    &targetStory,
+		Warning:        strings.Join(warnings, "; "),
+		RetryTotal:     int(rc.Total),
+		Retry429:       int(rc.Status429),
+		CreatedTags:    ss.createdTags,
+		WorkflowSteps:  steps,
+		MergeConflicts: ss.conflicts,
 	}, nil
 }
 
@@ -553,7 +873,13 @@ func (ss *StorySyncer) SyncFolderDetailed(folder sb.Story, shouldPublish bool) (
 	defer cancel()
 	// Attach per-item retry counters to context
 	rc := &sb.RetryCounters{}
-	ctx = sb.WithRetryCounters(ctx, rc)
+	ctx = ss.withRelease(sb.WithRetryCounters(tracex.WithSpan(ctx, ss.trace), rc))
+	ctx = ss.withSource(ctx, folder)
+
+	// Language-scoped sync leaves the folder structure untouched
+	if len(ss.languages) > 0 {
+		return &SyncItemResult{Operation: OperationSkip}, nil
+	}
 
 	// Determine operation type from in-memory index only (avoid extra GET)
 	operation := OperationCreate
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"testing"
 
+	"storyblok-sync/internal/core/tagsync"
+	"storyblok-sync/internal/core/transform"
 	"storyblok-sync/internal/sb"
 )
 
@@ -386,3 +388,328 @@ func TestSyncFolder_Create_UpdatesTranslatedPaths(t *testing.T) {
 		t.Fatalf("expected either translated_slugs_attributes or translated_slugs to be present")
 	}
 }
+
+type fakeStoryTagAPI struct {
+	existing []sb.Tag
+	created  []string
+}
+
+func (f *fakeStoryTagAPI) ListTags(ctx context.Context, spaceID int) ([]sb.Tag, error) {
+	return f.existing, nil
+}
+
+func (f *fakeStoryTagAPI) CreateTag(ctx context.Context, spaceID int, name string) (sb.Tag, error) {
+	f.created = append(f.created, name)
+	return sb.Tag{ID: len(f.created), Name: name}, nil
+}
+
+func TestSyncStoryDetailed_CreatesMissingTags(t *testing.T) {
+	api := newMockStoryRawSyncAPI()
+	sourceID := 1
+	api.sourceTypedByID[sourceID] = sb.Story{ID: sourceID, Slug: "page", FullSlug: "page"}
+	api.sourceRawByID[sourceID] = map[string]interface{}{
+		"name":      "Page",
+		"slug":      "page",
+		"full_slug": "page",
+		"tag_list":  []interface{}{"news", "blog"},
+		"content":   map[string]interface{}{"component": "page"},
+	}
+
+	tags := &fakeStoryTagAPI{existing: []sb.Tag{{ID: 1, Name: "news"}}}
+	syncer := NewStorySyncer(api, 1, 2, map[string]sb.Story{})
+	syncer.SetTagReconciler(tagsync.NewReconciler(tags, nil, 2))
+
+	res, err := syncer.SyncStoryDetailed(api.sourceTypedByID[sourceID], false)
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if !reflect.DeepEqual(tags.created, []string{"blog"}) {
+		t.Fatalf("created tags = %v", tags.created)
+	}
+	if !reflect.DeepEqual(res.CreatedTags, []string{"blog"}) {
+		t.Fatalf("result CreatedTags = %v", res.CreatedTags)
+	}
+	if len(api.rawCreates) != 1 {
+		t.Fatalf("expected raw create, got %d", len(api.rawCreates))
+	}
+}
+
+// releaseRecordingAPI records the release and source attached to each raw write
+type releaseRecordingAPI struct {
+	*mockStoryRawSyncAPI
+	releases []int
+	sources  []sb.Source
+}
+
+func (r *releaseRecordingAPI) CreateStoryRawWithPublish(ctx context.Context, spaceID int, story map[string]interface{}, publish bool) (sb.Story, error) {
+	r.releases = append(r.releases, sb.ReleaseIDFrom(ctx))
+	r.sources = append(r.sources, sb.SourceFrom(ctx))
+	return r.mockStoryRawSyncAPI.CreateStoryRawWithPublish(ctx, spaceID, story, publish)
+}
+
+func TestSyncStoryDetailed_WritesIntoRelease(t *testing.T) {
+	api := &releaseRecordingAPI{mockStoryRawSyncAPI: newMockStoryRawSyncAPI()}
+	api.sourceTypedByID[1] = sb.Story{ID: 1, Slug: "page", FullSlug: "page"}
+	api.sourceRawByID[1] = map[string]interface{}{"name": "Page", "slug": "page", "full_slug": "page"}
+
+	syncer := NewStorySyncer(api, 1, 2, map[string]sb.Story{})
+	syncer.SetReleaseID(77)
+	if _, err := syncer.SyncStoryDetailed(api.sourceTypedByID[1], false); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if !reflect.DeepEqual(api.releases, []int{77}) {
+		t.Fatalf("expected write staged in release 77, got %v", api.releases)
+	}
+	if want := []sb.Source{{SpaceID: 1, StoryID: 1, FullSlug: "page"}}; !reflect.DeepEqual(api.sources, want) {
+		t.Fatalf("expected write tagged with source %v, got %v", want, api.sources)
+	}
+}
+
+func TestSyncStoryDetailed_LanguageScopedUpdate(t *testing.T) {
+	api := newMockStoryRawSyncAPI()
+	existing := sb.Story{ID: 900, FullSlug: "page"}
+	api.targetBySlug[existing.FullSlug] = existing
+	// The mock serves raw stories by ID regardless of space: 900 is the target copy
+	api.sourceRawByID[900] = map[string]interface{}{
+		"id": 900, "name": "Seite", "slug": "page", "full_slug": "page",
+		"content":          map[string]interface{}{"_uid": "r", "component": "page", "title": "Ziel", "title__i18n__de": "Ziel DE"},
+		"translated_slugs": []interface{}{map[string]interface{}{"id": 5, "lang": "de", "slug": "seite"}},
+	}
+	api.sourceRawByID[1] = map[string]interface{}{
+		"id": 1, "name": "Source", "slug": "page", "full_slug": "page",
+		"content":          map[string]interface{}{"_uid": "r", "component": "page", "title": "Quelle", "title__i18n__fr": "Source FR"},
+		"translated_slugs": []interface{}{map[string]interface{}{"id": 9, "lang": "fr", "slug": "page-fr"}},
+	}
+
+	bs := NewBaseStore(t.TempDir())
+	syncer := NewStorySyncer(api, 10, 20, map[string]sb.Story{existing.FullSlug: existing})
+	syncer.SetLanguages([]string{"fr"})
+	syncer.SetBaseStore(bs)
+	res, err := syncer.SyncStoryDetailed(sb.Story{ID: 1, FullSlug: "page"}, false)
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if res.Operation != OperationUpdate || len(api.rawUpdates) != 1 {
+		t.Fatalf("expected one update, got %+v / %d", res, len(api.rawUpdates))
+	}
+	payload := api.rawUpdates[0]
+	if payload["name"] != "Seite" {
+		t.Fatalf("expected target name to be kept, got %v", payload["name"])
+	}
+	content := payload["content"].(map[string]interface{})
+	if content["title"] != "Ziel" || content["title__i18n__de"] != "Ziel DE" || content["title__i18n__fr"] != "Source FR" {
+		t.Fatalf("unexpected merged content: %+v", content)
+	}
+	slugs := payload["translated_slugs_attributes"].([]map[string]interface{})
+	if len(slugs) != 2 || slugs[0]["lang"] != "de" || slugs[1]["slug"] != "page-fr" {
+		t.Fatalf("unexpected translated slugs: %+v", slugs)
+	}
+	// The base is the written payload, not the full source
+	base, ok, err := bs.Load(20, 900)
+	if err != nil || !ok {
+		t.Fatalf("base not saved: ok=%v err=%v", ok, err)
+	}
+	if !reflect.DeepEqual(base["content"], content) {
+		t.Fatalf("base content = %+v, want written %+v", base["content"], content)
+	}
+
+	// Stories missing in the target are skipped instead of created
+	res, err = syncer.SyncStoryDetailed(sb.Story{ID: 2, FullSlug: "new"}, false)
+	if err != nil || res.Operation != OperationSkip || len(api.rawCreates) != 0 {
+		t.Fatalf("expected skip without create, got %+v err=%v creates=%d", res, err, len(api.rawCreates))
+	}
+}
+
+func TestSyncStory_UpdateMergesWithPolicy(t *testing.T) {
+	api := newMockStoryRawSyncAPI()
+	existing := sb.Story{ID: 901, FullSlug: "page"}
+	api.targetBySlug[existing.FullSlug] = existing
+	api.sourceRawByID[901] = map[string]interface{}{
+		"id": 901, "full_slug": "page",
+		"content": map[string]interface{}{"_uid": "r", "component": "page", "title": "Ziel", "seo": "Ziel-SEO", "intro": ""},
+	}
+	api.sourceRawByID[3] = map[string]interface{}{
+		"id": 3, "name": "Page", "slug": "page", "full_slug": "page",
+		"content": map[string]interface{}{"_uid": "r", "component": "page", "title": "Quelle", "seo": "Quell-SEO", "intro": "Intro"},
+	}
+	rules, _ := ParseFieldRules("page.seo", "")
+
+	syncer := NewStorySyncer(api, 10, 20, map[string]sb.Story{existing.FullSlug: existing})
+	syncer.SetMergePolicy(MergeSourceWins, rules)
+	if _, err := syncer.SyncStory(context.Background(), sb.Story{ID: 3, FullSlug: "page"}, false); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	content := api.rawUpdates[0]["content"].(map[string]interface{})
+	if content["title"] != "Quelle" || content["seo"] != "Ziel-SEO" || content["intro"] != "Intro" {
+		t.Fatalf("unexpected merged content: %+v", content)
+	}
+}
+
+func TestSyncStory_AppliesTransformsBeforeWrite(t *testing.T) {
+	api := newMockStoryRawSyncAPI()
+	api.sourceRawByID[4] = map[string]interface{}{
+		"id": 4, "name": "Page", "slug": "page", "full_slug": "page",
+		"content": map[string]interface{}{"_uid": "r", "component": "page", "link": "https://www.example.com/a", "body": []interface{}{
+			map[string]interface{}{"_uid": "b1", "component": "internal_note"},
+			map[string]interface{}{"_uid": "b2", "component": "text"},
+		}},
+	}
+	p, err := transform.Parse([]byte(`{"rules":[
+		{"op":"regex_replace","path":"$.content..link","pattern":"www\\.example\\.com","replace":"www.example.ch"},
+		{"op":"drop_blok","components":["internal_note"]},
+		{"op":"set","path":"$.content.market","value":"ch","target":20}
+	]}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	syncer := NewStorySyncer(api, 10, 20, map[string]sb.Story{})
+	syncer.SetTransforms(p)
+	if _, err := syncer.SyncStory(context.Background(), sb.Story{ID: 4, FullSlug: "page"}, false); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	content := api.rawCreates[0]["content"].(map[string]interface{})
+	body := content["body"].([]interface{})
+	if content["link"] != "https://www.example.ch/a" || content["market"] != "ch" || len(body) != 1 {
+		t.Fatalf("unexpected transformed content: %+v", content)
+	}
+}
+
+func TestSyncStoryDetailed_ThreeWayMergeWithBase(t *testing.T) {
+	api := newMockStoryRawSyncAPI()
+	existing := sb.Story{ID: 902, FullSlug: "page"}
+	api.targetBySlug[existing.FullSlug] = existing
+	api.sourceRawByID[4] = map[string]interface{}{
+		"id": 4, "name": "Page", "slug": "page", "full_slug": "page",
+		"content": map[string]interface{}{"_uid": "r", "component": "page", "title": "A", "intro": "A", "seo": "A"},
+	}
+	bs := NewBaseStore(t.TempDir())
+	syncer := NewStorySyncer(api, 10, 20, map[string]sb.Story{existing.FullSlug: existing})
+	syncer.SetBaseStore(bs)
+
+	// First sync without base: plain overwrite, records the base
+	if _, err := syncer.SyncStoryDetailed(sb.Story{ID: 4, FullSlug: "page"}, false); err != nil {
+		t.Fatalf("first sync: %v", err)
+	}
+	if _, ok, _ := bs.Load(20, 902); !ok {
+		t.Fatalf("expected base to be recorded")
+	}
+
+	// Target edits seo and title, source edits intro and title
+	api.sourceRawByID[902] = map[string]interface{}{
+		"id": 902, "full_slug": "page",
+		"content": map[string]interface{}{"_uid": "r", "component": "page", "title": "Ziel", "intro": "A", "seo": "Ziel"},
+	}
+	api.sourceRawByID[4]["content"] = map[string]interface{}{"_uid": "r", "component": "page", "title": "Quelle", "intro": "Quelle", "seo": "A"}
+
+	res, err := syncer.SyncStoryDetailed(sb.Story{ID: 4, FullSlug: "page"}, false)
+	if err != nil {
+		t.Fatalf("second sync: %v", err)
+	}
+	content := api.rawUpdates[1]["content"].(map[string]interface{})
+	if content["intro"] != "Quelle" || content["seo"] != "Ziel" || content["title"] != "Ziel" {
+		t.Fatalf("unexpected merge: %+v", content)
+	}
+	if len(res.MergeConflicts) != 1 || res.MergeConflicts[0].Path != "title" || res.Warning == "" {
+		t.Fatalf("expected unresolved title conflict with warning, got %+v", res)
+	}
+
+	// Resolution chosen in the resolver is written
+	syncer.SetResolutions(map[string]string{"title": ResolveSource})
+	api.sourceRawByID[902]["content"] = map[string]interface{}{"_uid": "r", "component": "page", "title": "Ziel 2", "intro": "Quelle", "seo": "Ziel"}
+	api.sourceRawByID[4]["content"] = map[string]interface{}{"_uid": "r", "component": "page", "title": "Quelle 2", "intro": "Quelle", "seo": "A"}
+	res, err = syncer.SyncStoryDetailed(sb.Story{ID: 4, FullSlug: "page"}, false)
+	if err != nil {
+		t.Fatalf("third sync: %v", err)
+	}
+	content = api.rawUpdates[2]["content"].(map[string]interface{})
+	if content["title"] != "Quelle 2" || res.Warning != "" {
+		t.Fatalf("expected resolved source title, got %+v / %q", content, res.Warning)
+	}
+}
+
+func TestSyncStoryDetailed_ThreeWayMergeAppliesFieldRules(t *testing.T) {
+	api := newMockStoryRawSyncAPI()
+	existing := sb.Story{ID: 903, FullSlug: "page"}
+	api.targetBySlug[existing.FullSlug] = existing
+	api.sourceRawByID[5] = map[string]interface{}{
+		"id": 5, "name": "Page", "slug": "page", "full_slug": "page",
+		"content": map[string]interface{}{"_uid": "r", "component": "page", "title": "Quelle", "seo": "Quelle"},
+	}
+	api.sourceRawByID[903] = map[string]interface{}{
+		"id": 903, "full_slug": "page",
+		"content": map[string]interface{}{"_uid": "r", "component": "page", "title": "A", "seo": "A"},
+	}
+	bs := NewBaseStore(t.TempDir())
+	if err := bs.Save(20, 903, map[string]interface{}{"content": map[string]interface{}{"_uid": "r", "component": "page", "title": "A", "seo": "A"}}); err != nil {
+		t.Fatal(err)
+	}
+	rules, _ := ParseFieldRules("page.seo", "")
+	p, err := transform.Parse([]byte(`{"rules":[{"op":"set","path":"$.content.title","value":"Transformiert"}]}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	syncer := NewStorySyncer(api, 10, 20, map[string]sb.Story{existing.FullSlug: existing})
+	syncer.SetBaseStore(bs)
+	syncer.SetMergePolicy(MergeOverwrite, rules)
+	syncer.SetTransforms(p)
+
+	if _, err := syncer.SyncStoryDetailed(sb.Story{ID: 5, FullSlug: "page"}, false); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	content := api.rawUpdates[0]["content"].(map[string]interface{})
+	if content["seo"] != "A" || content["title"] != "Transformiert" {
+		t.Fatalf("deny rule not applied to three-way merge: %+v", content)
+	}
+
+	// The base is the merged source content as transformed, not the written payload
+	base, ok, err := bs.Load(20, 903)
+	if err != nil || !ok {
+		t.Fatalf("base not recorded: %v", err)
+	}
+	got := base["content"].(map[string]interface{})
+	if got["seo"] != "Quelle" || got["title"] != "Transformiert" {
+		t.Fatalf("base = %+v, want transformed source content", got)
+	}
+}
+
+func TestSyncStoryDetailed_ThreeWayMergeWithTransforms(t *testing.T) {
+	api := newMockStoryRawSyncAPI()
+	existing := sb.Story{ID: 904, FullSlug: "page"}
+	api.targetBySlug[existing.FullSlug] = existing
+	source := func(link string) map[string]interface{} {
+		return map[string]interface{}{
+			"id": 6, "name": "Page", "slug": "page", "full_slug": "page",
+			"content": map[string]interface{}{"_uid": "r", "component": "page", "title": "A", "link": link},
+		}
+	}
+	p, err := transform.Parse([]byte(`{"rules":[{"op":"regex_replace","path":"$.content..link","pattern":"www\\.example\\.com","replace":"www.example.ch"}]}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	syncer := NewStorySyncer(api, 10, 20, map[string]sb.Story{existing.FullSlug: existing})
+	syncer.SetBaseStore(NewBaseStore(t.TempDir()))
+	syncer.SetTransforms(p)
+
+	api.sourceRawByID[6] = source("https://www.example.com/a")
+	api.sourceRawByID[904] = map[string]interface{}{"id": 904, "full_slug": "page", "content": map[string]interface{}{"_uid": "r", "component": "page"}}
+	if _, err := syncer.SyncStoryDetailed(sb.Story{ID: 6, FullSlug: "page"}, false); err != nil {
+		t.Fatalf("first sync: %v", err)
+	}
+	written := api.rawUpdates[0]["content"].(map[string]interface{})
+	if written["link"] != "https://www.example.ch/a" {
+		t.Fatalf("first write = %+v", written)
+	}
+
+	// The target now holds the transformed link; a source edit must still sync
+	api.sourceRawByID[904] = map[string]interface{}{"id": 904, "full_slug": "page", "content": copyMap(written)}
+	api.sourceRawByID[6] = source("https://www.example.com/b")
+	res, err := syncer.SyncStoryDetailed(sb.Story{ID: 6, FullSlug: "page"}, false)
+	if err != nil {
+		t.Fatalf("second sync: %v", err)
+	}
+	written = api.rawUpdates[1]["content"].(map[string]interface{})
+	if written["link"] != "https://www.example.ch/b" || len(res.MergeConflicts) != 0 {
+		t.Fatalf("second write = %+v, conflicts %+v", written, res.MergeConflicts)
+	}
+}
@@ -0,0 +1,216 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Conflict resolutions
+const (
+	ResolveTarget = "target"
+	ResolveSource = "source"
+)
+
+// MergeConflict is a field both source and target changed differently since
+// the base. Missing values are nil.
+type MergeConflict struct {
+	Path       string      `json:"path"` // blok location plus field, e.g. "body[b1].headline"
+	Base       interface{} `json:"base,omitempty"`
+	Target     interface{} `json:"target,omitempty"`
+	Source     interface{} `json:"source,omitempty"`
+	Resolution string      `json:"resolution,omitempty"` // ResolveTarget (default) or ResolveSource
+}
+
+// String renders the conflict with its (effective) resolution.
+func (c MergeConflict) String() string {
+	res := c.Resolution
+	if res == "" {
+		res = ResolveTarget
+	}
+	return fmt.Sprintf("%s → %s", c.Path, res)
+}
+
+// UnresolvedConflicts counts conflicts without an explicit resolution.
+func UnresolvedConflicts(conflicts []MergeConflict) int {
+	n := 0
+	for _, c := range conflicts {
+		if c.Resolution == "" {
+			n++
+		}
+	}
+	return n
+}
+
+// ValuePreview renders a conflict value as compact JSON, truncated to max runes.
+func ValuePreview(v interface{}, max int) string {
+	if v == nil {
+		return "∅"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	r := []rune(string(b))
+	if max > 0 && len(r) > max {
+		return string(r[:max]) + "…"
+	}
+	return string(r)
+}
+
+// ThreeWayMerge merges source and target content against their common base.
+// Fields changed on one side only are applied automatically; fields changed
+// differently on both sides become conflicts. A conflict takes the side named
+// in resolutions (keyed by path) and keeps the target value otherwise. Nested
+// bloks are matched by _uid. Inputs are not modified.
+func ThreeWayMerge(base, target, source map[string]interface{}, resolutions map[string]string) (map[string]interface{}, []MergeConflict) {
+	tw := threeWay{resolutions: resolutions}
+	merged := tw.mergeMap(base, target, source, "")
+	return merged, tw.conflicts
+}
+
+type threeWay struct {
+	resolutions map[string]string
+	conflicts   []MergeConflict
+}
+
+// absent marks a missing value; nil is a valid JSON value.
+type absentValue struct{}
+
+var absent = absentValue{}
+
+func present(v interface{}) bool {
+	_, missing := v.(absentValue)
+	return !missing
+}
+
+func orNil(v interface{}) interface{} {
+	if !present(v) {
+		return nil
+	}
+	return v
+}
+
+// merge returns the merged value or absent when the field should be removed.
+func (tw *threeWay) merge(b, t, s interface{}, path string) interface{} {
+	switch {
+	case reflect.DeepEqual(t, s):
+		return deepCopyJSON(t)
+	case reflect.DeepEqual(b, t):
+		return deepCopyJSON(s)
+	case reflect.DeepEqual(b, s):
+		return deepCopyJSON(t)
+	}
+
+	// Both sides changed: descend into matching bloks and objects
+	tm, tok := t.(map[string]interface{})
+	sm, sok := s.(map[string]interface{})
+	if tok && sok && tm["_uid"] == sm["_uid"] {
+		bm, _ := b.(map[string]interface{})
+		if bm != nil && bm["_uid"] != tm["_uid"] {
+			bm = nil
+		}
+		return tw.mergeMap(bm, tm, sm, path)
+	}
+	if tl, ok := blokList(t); ok {
+		if sl, ok := blokList(s); ok {
+			bl, _ := blokList(b)
+			return tw.mergeBlokList(bl, tl, sl, path)
+		}
+	}
+	return tw.conflict(b, t, s, path)
+}
+
+func (tw *threeWay) conflict(b, t, s interface{}, path string) interface{} {
+	res := tw.resolutions[path]
+	tw.conflicts = append(tw.conflicts, MergeConflict{Path: path, Base: orNil(b), Target: orNil(t), Source: orNil(s), Resolution: res})
+	if res == ResolveSource {
+		return deepCopyJSON(s)
+	}
+	return deepCopyJSON(t)
+}
+
+func (tw *threeWay) mergeMap(b, t, s map[string]interface{}, path string) map[string]interface{} {
+	keys := map[string]interface{}{}
+	for _, m := range []map[string]interface{}{b, t, s} {
+		for k := range m {
+			keys[k] = true
+		}
+	}
+	out := make(map[string]interface{}, len(keys))
+	for _, k := range sortedKeys(keys) {
+		v := tw.merge(lookup(b, k), lookup(t, k), lookup(s, k), joinPath(path, k))
+		if present(v) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func lookup(m map[string]interface{}, k string) interface{} {
+	if m == nil {
+		return absent
+	}
+	v, ok := m[k]
+	if !ok {
+		return absent
+	}
+	return v
+}
+
+// mergeBlokList merges blok lists by _uid: bloks added on either side are
+// kept, bloks removed on one side are dropped unless the other side changed
+// them (then the removal is a conflict). Order follows the source, followed
+// by bloks only the target added.
+func (tw *threeWay) mergeBlokList(b, t, s []map[string]interface{}, path string) []interface{} {
+	index := func(list []map[string]interface{}) map[string]map[string]interface{} {
+		idx := make(map[string]map[string]interface{}, len(list))
+		for _, bk := range list {
+			uid, _ := bk["_uid"].(string)
+			idx[uid] = bk
+		}
+		return idx
+	}
+	bi, ti, si := index(b), index(t), index(s)
+
+	var order []string
+	seen := map[string]bool{}
+	for _, list := range [][]map[string]interface{}{s, t} {
+		for _, bk := range list {
+			uid, _ := bk["_uid"].(string)
+			if !seen[uid] {
+				seen[uid] = true
+				order = append(order, uid)
+			}
+		}
+	}
+
+	var out []interface{}
+	for _, uid := range order {
+		var bv, tv, sv interface{} = absent, absent, absent
+		if v, ok := bi[uid]; ok {
+			bv = v
+		}
+		if v, ok := ti[uid]; ok {
+			tv = v
+		}
+		if v, ok := si[uid]; ok {
+			sv = v
+		}
+		p := blokPath(path, uid)
+		var v interface{}
+		if present(tv) && present(sv) {
+			var bm map[string]interface{}
+			if present(bv) {
+				bm = bv.(map[string]interface{})
+			}
+			v = tw.mergeMap(bm, tv.(map[string]interface{}), sv.(map[string]interface{}), p)
+		} else {
+			v = tw.merge(bv, tv, sv, p)
+		}
+		if present(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
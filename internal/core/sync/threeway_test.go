@@ -0,0 +1,111 @@
+package sync
+
+import (
+	"reflect"
+	"testing"
+)
+
+func threeWayFixture() (base, target, source map[string]interface{}) {
+	base = map[string]interface{}{
+		"_uid": "r", "component": "page",
+		"title": "Alt", "intro": "Alt", "seo": "Alt",
+		"body": []interface{}{
+			map[string]interface{}{"_uid": "b1", "component": "teaser", "headline": "Alt"},
+			map[string]interface{}{"_uid": "b2", "component": "teaser", "headline": "bleibt"},
+		},
+	}
+	target = map[string]interface{}{
+		"_uid": "r", "component": "page",
+		"title": "Ziel", "intro": "Alt", "seo": "Ziel-SEO",
+		"body": []interface{}{
+			map[string]interface{}{"_uid": "b1", "component": "teaser", "headline": "Ziel"},
+			map[string]interface{}{"_uid": "b2", "component": "teaser", "headline": "bleibt"},
+			map[string]interface{}{"_uid": "t1", "component": "teaser", "headline": "neu im Ziel"},
+		},
+	}
+	source = map[string]interface{}{
+		"_uid": "r", "component": "page",
+		"title": "Quelle", "intro": "Neu", "seo": "Alt",
+		"body": []interface{}{
+			map[string]interface{}{"_uid": "b1", "component": "teaser", "headline": "Quelle"},
+			map[string]interface{}{"_uid": "s1", "component": "teaser", "headline": "neu in Quelle"},
+		},
+	}
+	return base, target, source
+}
+
+func TestThreeWayMerge_AutoAppliesOneSidedChanges(t *testing.T) {
+	base, target, source := threeWayFixture()
+	merged, conflicts := ThreeWayMerge(base, target, source, nil)
+
+	if merged["intro"] != "Neu" {
+		t.Fatalf("source-only change must be applied, got %v", merged["intro"])
+	}
+	if merged["seo"] != "Ziel-SEO" {
+		t.Fatalf("target-only change must be kept, got %v", merged["seo"])
+	}
+	var uids []string
+	for _, b := range merged["body"].([]interface{}) {
+		uids = append(uids, b.(map[string]interface{})["_uid"].(string))
+	}
+	// b2 removed in source (unchanged in target) is dropped; additions from both sides stay
+	if !reflect.DeepEqual(uids, []string{"b1", "s1", "t1"}) {
+		t.Fatalf("unexpected blok order: %v", uids)
+	}
+
+	var paths []string
+	for _, c := range conflicts {
+		paths = append(paths, c.Path)
+	}
+	if !reflect.DeepEqual(paths, []string{"body[b1].headline", "title"}) {
+		t.Fatalf("unexpected conflicts: %v", paths)
+	}
+	// Unresolved conflicts keep the target value
+	if merged["title"] != "Ziel" {
+		t.Fatalf("expected target value for unresolved conflict, got %v", merged["title"])
+	}
+}
+
+func TestThreeWayMerge_AppliesResolutions(t *testing.T) {
+	base, target, source := threeWayFixture()
+	merged, conflicts := ThreeWayMerge(base, target, source, map[string]string{"title": ResolveSource})
+	if merged["title"] != "Quelle" {
+		t.Fatalf("resolution should take source, got %v", merged["title"])
+	}
+	for _, c := range conflicts {
+		if c.Path == "title" && c.Resolution != ResolveSource {
+			t.Fatalf("resolution must be reported: %+v", c)
+		}
+	}
+	if base["title"] != "Alt" || target["title"] != "Ziel" {
+		t.Fatalf("inputs must not be modified")
+	}
+}
+
+func TestThreeWayMerge_DeleteModifyConflict(t *testing.T) {
+	base := map[string]interface{}{"_uid": "r", "body": []interface{}{map[string]interface{}{"_uid": "b1", "x": "1"}}}
+	target := map[string]interface{}{"_uid": "r", "body": []interface{}{map[string]interface{}{"_uid": "b1", "x": "2"}}}
+	source := map[string]interface{}{"_uid": "r", "body": []interface{}{map[string]interface{}{"_uid": "s", "x": "3"}}}
+	merged, conflicts := ThreeWayMerge(base, target, source, nil)
+	if len(conflicts) != 1 || conflicts[0].Path != "body[b1]" || conflicts[0].Source != nil {
+		t.Fatalf("expected delete/modify conflict, got %+v", conflicts)
+	}
+	if len(merged["body"].([]interface{})) != 2 {
+		t.Fatalf("target-modified blok kept by default: %+v", merged["body"])
+	}
+}
+
+func TestBaseStore_SaveLoad(t *testing.T) {
+	bs := NewBaseStore(t.TempDir())
+	if _, ok, err := bs.Load(2, 77); ok || err != nil {
+		t.Fatalf("expected no base, got ok=%v err=%v", ok, err)
+	}
+	payload := map[string]interface{}{"content": map[string]interface{}{"title": "x"}}
+	if err := bs.Save(2, 77, payload); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	got, ok, err := bs.Load(2, 77)
+	if err != nil || !ok || !reflect.DeepEqual(got, payload) {
+		t.Fatalf("load mismatch: %+v ok=%v err=%v", got, ok, err)
+	}
+}
@@ -12,6 +12,12 @@ type SyncItemResult struct {
 	// Retry counters (per item) captured from HTTP transport via context
 	RetryTotal int `json:"retryTotal"`
 	Retry429   int `json:"retry429"`
+	// Story tags created in the target while syncing this item
+	CreatedTags []string `json:"createdTags,omitempty"`
+	// Workflow stage transitions performed around the write
+	WorkflowSteps []WorkflowStep `json:"workflowSteps,omitempty"`
+	// Three-way merge conflicts and how they were resolved
+	MergeConflicts []MergeConflict `json:"mergeConflicts,omitempty"`
 }
 
 // SyncResultMsg represents a message containing sync operation results
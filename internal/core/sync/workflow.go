@@ -0,0 +1,274 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	gosync "sync"
+
+	"storyblok-sync/internal/sb"
+)
+
+// Workflow step actions recorded per item
+const (
+	WorkflowStepUnlock  = "unlock"  // moved to an editable stage before the write
+	WorkflowStepRevert  = "revert"  // moved back to the original stage after the write
+	WorkflowStepRestore = "restore" // re-applied a stage the write reset
+)
+
+// WorkflowAPI is the client subset needed to read and change workflow stages.
+type WorkflowAPI interface {
+	ListWorkflowStageChanges(ctx context.Context, spaceID, storyID int) ([]sb.WorkflowStageChange, error)
+	CreateWorkflowStageChange(ctx context.Context, spaceID, storyID, stageID int) (sb.WorkflowStageChange, error)
+}
+
+// WorkflowStageLister loads the workflow stages of a space.
+type WorkflowStageLister interface {
+	ListWorkflowStages(ctx context.Context, spaceID int) ([]sb.WorkflowStage, error)
+}
+
+// WorkflowStep records one stage transition performed around a write.
+type WorkflowStep struct {
+	Action string `json:"action"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Error  string `json:"error,omitempty"`
+}
+
+// String renders "action: from → to" plus the error, if any.
+func (s WorkflowStep) String() string {
+	out := fmt.Sprintf("%s: %s → %s", s.Action, s.From, s.To)
+	if s.Error != "" {
+		out += " (" + s.Error + ")"
+	}
+	return out
+}
+
+// WorkflowGuard keeps target stories in their workflow stage across updates.
+// Stages listed as locked block updates; with
+// transitions allowed, the guard moves the story to an editable stage of the
+// same workflow for the write and back afterwards.
+type WorkflowGuard struct {
+	api             WorkflowAPI
+	spaceID         int
+	stages          map[int]sb.WorkflowStage
+	locked          map[string]bool
+	allowTransition bool
+
+	lister WorkflowStageLister // loads stages on the first Run when set
+	load   gosync.Once
+}
+
+// NewWorkflowGuard builds a guard for the target space. lockedNames are stage
+// names (case-insensitive) that must be treated as read-only.
+func NewWorkflowGuard(api WorkflowAPI, spaceID int, stages []sb.WorkflowStage, lockedNames []string, allowTransition bool) *WorkflowGuard {
+	g := &WorkflowGuard{
+		api:             api,
+		spaceID:         spaceID,
+		stages:          make(map[int]sb.WorkflowStage, len(stages)),
+		locked:          make(map[string]bool, len(lockedNames)),
+		allowTransition: allowTransition,
+	}
+	for _, s := range stages {
+		g.stages[s.ID] = s
+	}
+	for _, n := range lockedNames {
+		if n = strings.TrimSpace(n); n != "" {
+			g.locked[strings.ToLower(n)] = true
+		}
+	}
+	return g
+}
+
+// LoadStagesFrom makes the guard load the space's stages on its first Run
+// instead of relying on stages passed to NewWorkflowGuard. A space without
+// stages (or whose stages cannot be read) is synced without stage handling.
+func (g *WorkflowGuard) LoadStagesFrom(l WorkflowStageLister) {
+	g.lister = l
+}
+
+// loadStages fetches the stages once if a lister is set.
+func (g *WorkflowGuard) loadStages(ctx context.Context) {
+	g.load.Do(func() {
+		if g.lister == nil {
+			return
+		}
+		stages, err := g.lister.ListWorkflowStages(ctx, g.spaceID)
+		if err != nil {
+			log.Printf("Warning: workflow stages of space %d: %v", g.spaceID, err)
+			return
+		}
+		for _, s := range stages {
+			g.stages[s.ID] = s
+		}
+	})
+}
+
+// AllowTransition reports whether the guard may move locked stories.
+func (g *WorkflowGuard) AllowTransition() bool { return g.allowTransition }
+
+// StageName returns the stage name or its ID when unknown.
+func (g *WorkflowGuard) StageName(id int) string {
+	if s, ok := g.stages[id]; ok && s.Name != "" {
+		return s.Name
+	}
+	return fmt.Sprintf("#%d", id)
+}
+
+// IsLocked reports whether stories in the stage cannot be updated, i.e. the
+// stage name is one of the configured locked stages. Stage permissions are
+// not considered: they restrict editors, not the sync token.
+func (g *WorkflowGuard) IsLocked(stageID int) bool {
+	s, ok := g.stages[stageID]
+	return ok && g.locked[strings.ToLower(s.Name)]
+}
+
+// EditableStage picks the stage a locked story is moved to: the default stage
+// of the same workflow, else the first unlocked stage by position.
+func (g *WorkflowGuard) EditableStage(stageID int) (sb.WorkflowStage, bool) {
+	cur, ok := g.stages[stageID]
+	if !ok {
+		return sb.WorkflowStage{}, false
+	}
+	var candidates []sb.WorkflowStage
+	for _, s := range g.stages {
+		if s.WorkflowID == cur.WorkflowID && s.ID != stageID && !g.IsLocked(s.ID) {
+			candidates = append(candidates, s)
+		}
+	}
+	if len(candidates) == 0 {
+		return sb.WorkflowStage{}, false
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].IsDefault != candidates[j].IsDefault {
+			return candidates[i].IsDefault
+		}
+		if candidates[i].Position != candidates[j].Position {
+			return candidates[i].Position < candidates[j].Position
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+	return candidates[0], true
+}
+
+// CurrentStage returns the stage ID of the story's latest stage change (0 if none).
+func (g *WorkflowGuard) CurrentStage(ctx context.Context, storyID int) (int, error) {
+	changes, err := g.api.ListWorkflowStageChanges(ctx, g.spaceID, storyID)
+	if err != nil {
+		return 0, err
+	}
+	return LatestWorkflowStage(changes), nil
+}
+
+// LatestWorkflowStage returns the stage of the newest change (highest ID).
+func LatestWorkflowStage(changes []sb.WorkflowStageChange) int {
+	latest := sb.WorkflowStageChange{}
+	for _, c := range changes {
+		if c.ID > latest.ID {
+			latest = c
+		}
+	}
+	return latest.WorkflowStageID
+}
+
+// Run executes write for the target story while preserving its workflow stage.
+// The returned steps list every transition; a failed revert or restore is
+// recorded in the step and does not turn a successful write into an error.
+func (g *WorkflowGuard) Run(ctx context.Context, storyID int, write func() error) ([]WorkflowStep, error) {
+	g.loadStages(ctx)
+	if g.lister != nil && len(g.stages) == 0 {
+		return nil, write()
+	}
+	stageID, err := g.CurrentStage(ctx, storyID)
+	if err != nil {
+		return nil, fmt.Errorf("workflow stage lookup: %w", err)
+	}
+	if stageID == 0 {
+		return nil, write()
+	}
+
+	var steps []WorkflowStep
+	if g.IsLocked(stageID) {
+		if !g.allowTransition {
+			return nil, fmt.Errorf("workflow stage %q is locked", g.StageName(stageID))
+		}
+		editable, ok := g.EditableStage(stageID)
+		if !ok {
+			return nil, fmt.Errorf("workflow stage %q is locked and no editable stage exists", g.StageName(stageID))
+		}
+		step := WorkflowStep{Action: WorkflowStepUnlock, From: g.StageName(stageID), To: editable.Name}
+		if _, err := g.api.CreateWorkflowStageChange(ctx, g.spaceID, storyID, editable.ID); err != nil {
+			step.Error = err.Error()
+			return append(steps, step), fmt.Errorf("workflow unlock: %w", err)
+		}
+		steps = append(steps, step)
+
+		writeErr := write()
+		revert := WorkflowStep{Action: WorkflowStepRevert, From: editable.Name, To: g.StageName(stageID)}
+		if _, err := g.api.CreateWorkflowStageChange(ctx, g.spaceID, storyID, stageID); err != nil {
+			revert.Error = err.Error()
+		}
+		return append(steps, revert), writeErr
+	}
+
+	if err := write(); err != nil {
+		return nil, err
+	}
+	after, err := g.CurrentStage(ctx, storyID)
+	if err != nil || after == stageID {
+		return nil, nil
+	}
+	step := WorkflowStep{Action: WorkflowStepRestore, From: g.StageName(after), To: g.StageName(stageID)}
+	if after == 0 {
+		step.From = "-"
+	}
+	if _, err := g.api.CreateWorkflowStageChange(ctx, g.spaceID, storyID, stageID); err != nil {
+		step.Error = err.Error()
+	}
+	return append(steps, step), nil
+}
+
+// FailedWorkflowSteps joins the steps that failed into a warning text.
+func FailedWorkflowSteps(steps []WorkflowStep) string {
+	var failed []string
+	for _, s := range steps {
+		if s.Error != "" {
+			failed = append(failed, s.String())
+		}
+	}
+	if len(failed) == 0 {
+		return ""
+	}
+	return "workflow: " + strings.Join(failed, "; ")
+}
+
+// ApplyWorkflowStage records a target story's stage on a preflight item.
+// Items in locked stages are skipped unless transitions are allowed; items
+// previously blocked this way are released when that is no longer the case.
+func ApplyWorkflowStage(item *PreflightItem, stageName string, locked, allowTransition bool) {
+	if item.WorkflowBlocked {
+		item.WorkflowBlocked = false
+		item.Skip = false
+		item.State = activeState(item)
+	}
+	item.WorkflowStage = stageName
+	item.WorkflowLocked = locked
+	if !locked {
+		if strings.HasPrefix(item.Issue, "workflow: ") {
+			item.Issue = ""
+		}
+		return
+	}
+	if allowTransition {
+		item.Issue = fmt.Sprintf("workflow: Stage %q gesperrt, wird temporär gewechselt", stageName)
+		return
+	}
+	item.Issue = fmt.Sprintf("workflow: Stage %q gesperrt", stageName)
+	if !item.Skip {
+		item.WorkflowBlocked = true
+		item.Skip = true
+		item.State = StateSkip
+	}
+}
@@ -0,0 +1,162 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"storyblok-sync/internal/sb"
+)
+
+type fakeWorkflowAPI struct {
+	stage      int   // current stage of the story
+	resetTo    int   // stage the write leaves behind (-1: unchanged)
+	moves      []int // stage changes requested
+	failMoveTo int
+	nextID     int
+}
+
+func (f *fakeWorkflowAPI) ListWorkflowStageChanges(ctx context.Context, spaceID, storyID int) ([]sb.WorkflowStageChange, error) {
+	if f.stage == 0 {
+		return nil, nil
+	}
+	return []sb.WorkflowStageChange{{ID: 1, WorkflowStageID: 99}, {ID: 5 + f.nextID, WorkflowStageID: f.stage}}, nil
+}
+
+func (f *fakeWorkflowAPI) CreateWorkflowStageChange(ctx context.Context, spaceID, storyID, stageID int) (sb.WorkflowStageChange, error) {
+	if stageID == f.failMoveTo {
+		return sb.WorkflowStageChange{}, errors.New("forbidden")
+	}
+	f.moves = append(f.moves, stageID)
+	f.stage = stageID
+	f.nextID++
+	return sb.WorkflowStageChange{ID: 5 + f.nextID, WorkflowStageID: stageID, StoryID: storyID}, nil
+}
+
+func workflowTestStages() []sb.WorkflowStage {
+	return []sb.WorkflowStage{
+		{ID: 1, Name: "Drafting", WorkflowID: 1, IsDefault: true, AllowAllUsers: true},
+		{ID: 2, Name: "Review", WorkflowID: 1, Position: 2, AllowAllUsers: true},
+		{ID: 3, Name: "Approved", WorkflowID: 1, Position: 3, UserIDs: []int{7}},
+		{ID: 4, Name: "Frozen", WorkflowID: 1, Position: 4},
+	}
+}
+
+func TestWorkflowGuard_IsLockedAndEditableStage(t *testing.T) {
+	g := NewWorkflowGuard(nil, 1, workflowTestStages(), []string{"approved"}, false)
+	// Only configured names lock; Frozen allows no user but is not listed
+	if !g.IsLocked(3) || g.IsLocked(4) || g.IsLocked(2) || g.IsLocked(42) {
+		t.Fatalf("unexpected locked detection")
+	}
+	if s, ok := g.EditableStage(3); !ok || s.ID != 1 {
+		t.Fatalf("expected default stage as editable target, got %+v", s)
+	}
+}
+
+func TestWorkflowGuard_LockedWithoutOptIn(t *testing.T) {
+	api := &fakeWorkflowAPI{stage: 4}
+	g := NewWorkflowGuard(api, 1, workflowTestStages(), []string{"Frozen"}, false)
+	wrote := false
+	_, err := g.Run(context.Background(), 10, func() error { wrote = true; return nil })
+	if err == nil || wrote || !strings.Contains(err.Error(), "Frozen") {
+		t.Fatalf("expected locked error without write, err=%v wrote=%v", err, wrote)
+	}
+}
+
+func TestWorkflowGuard_TransitionsAndReverts(t *testing.T) {
+	api := &fakeWorkflowAPI{stage: 4}
+	g := NewWorkflowGuard(api, 1, workflowTestStages(), []string{"Frozen"}, true)
+	steps, err := g.Run(context.Background(), 10, func() error {
+		if api.stage != 1 {
+			t.Fatalf("write should run in editable stage, got %d", api.stage)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(steps) != 2 || steps[0].Action != WorkflowStepUnlock || steps[1].Action != WorkflowStepRevert || api.stage != 4 {
+		t.Fatalf("unexpected steps %+v (stage %d)", steps, api.stage)
+	}
+	if FailedWorkflowSteps(steps) != "" {
+		t.Fatalf("no failed steps expected")
+	}
+}
+
+func TestWorkflowGuard_FailedRevertIsRecorded(t *testing.T) {
+	api := &fakeWorkflowAPI{stage: 4, failMoveTo: 4}
+	g := NewWorkflowGuard(api, 1, workflowTestStages(), []string{"Frozen"}, true)
+	steps, err := g.Run(context.Background(), 10, func() error { return nil })
+	if err != nil {
+		t.Fatalf("write succeeded, revert failure must not be an error: %v", err)
+	}
+	if w := FailedWorkflowSteps(steps); !strings.Contains(w, "revert") || !strings.Contains(w, "forbidden") {
+		t.Fatalf("expected failed revert warning, got %q", w)
+	}
+}
+
+func TestWorkflowGuard_RestoresResetStage(t *testing.T) {
+	api := &fakeWorkflowAPI{stage: 2}
+	g := NewWorkflowGuard(api, 1, workflowTestStages(), nil, false)
+	steps, err := g.Run(context.Background(), 10, func() error {
+		api.stage = 1 // update resets the stage
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(steps) != 1 || steps[0].Action != WorkflowStepRestore || api.stage != 2 {
+		t.Fatalf("expected restore to Review, got %+v (stage %d)", steps, api.stage)
+	}
+}
+
+type fakeStageLister struct {
+	stages []sb.WorkflowStage
+	calls  int
+}
+
+func (f *fakeStageLister) ListWorkflowStages(ctx context.Context, spaceID int) ([]sb.WorkflowStage, error) {
+	f.calls++
+	return f.stages, nil
+}
+
+func TestWorkflowGuard_LoadsStagesOnFirstRun(t *testing.T) {
+	api := &fakeWorkflowAPI{stage: 4}
+	lister := &fakeStageLister{stages: workflowTestStages()}
+	g := NewWorkflowGuard(api, 1, nil, []string{"Frozen"}, false)
+	g.LoadStagesFrom(lister)
+	for i := 0; i < 2; i++ {
+		if _, err := g.Run(context.Background(), 10, func() error { return nil }); err == nil || !strings.Contains(err.Error(), "Frozen") {
+			t.Fatalf("expected loaded stages to lock the story, got %v", err)
+		}
+	}
+	if lister.calls != 1 {
+		t.Fatalf("stages loaded %d times, want once", lister.calls)
+	}
+
+	// a space without stages is written without stage lookups
+	api = &fakeWorkflowAPI{stage: 4, failMoveTo: 4}
+	g = NewWorkflowGuard(api, 1, nil, nil, false)
+	g.LoadStagesFrom(&fakeStageLister{})
+	wrote := false
+	if steps, err := g.Run(context.Background(), 10, func() error { wrote = true; return nil }); err != nil || !wrote || len(steps) != 0 {
+		t.Fatalf("expected plain write, got steps=%v err=%v wrote=%v", steps, err, wrote)
+	}
+}
+
+func TestApplyWorkflowStage(t *testing.T) {
+	it := PreflightItem{Collision: true, Selected: true, State: StateUpdate}
+	ApplyWorkflowStage(&it, "Frozen", true, false)
+	if !it.Skip || !it.WorkflowBlocked || it.State != StateSkip {
+		t.Fatalf("locked item should be blocked: %+v", it)
+	}
+	ApplyWorkflowStage(&it, "Frozen", true, true)
+	if it.Skip || it.WorkflowBlocked || it.State != StateUpdate || it.Issue == "" {
+		t.Fatalf("opt-in should release but keep the note: %+v", it)
+	}
+	ApplyWorkflowStage(&it, "Review", false, true)
+	if it.Issue != "" || it.WorkflowLocked {
+		t.Fatalf("unlocked stage should clear the note: %+v", it)
+	}
+}
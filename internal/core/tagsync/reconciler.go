@@ -0,0 +1,276 @@
+// Package tagsync makes sure tags referenced by synced entities exist in the
+// target space. It covers story tags (Management API /tags) as well as
+// internal tags, which Storyblok scopes by object type. Story, component and
+// asset syncs call it.
+package tagsync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"storyblok-sync/internal/sb"
+)
+
+// Internal tag object types
+const (
+	ObjectTypeComponent = "component"
+	ObjectTypeAsset     = "asset"
+)
+
+// KindStory labels story tags in Created().
+const KindStory = "story"
+
+// StoryTagAPI is the client subset for story tags.
+type StoryTagAPI interface {
+	ListTags(ctx context.Context, spaceID int) ([]sb.Tag, error)
+	CreateTag(ctx context.Context, spaceID int, name string) (sb.Tag, error)
+}
+
+// InternalTagAPI is the client subset for internal tags.
+type InternalTagAPI interface {
+	ListInternalTags(ctx context.Context, spaceID int) ([]sb.InternalTag, error)
+	CreateInternalTag(ctx context.Context, spaceID int, name string, objectType string) (sb.InternalTag, error)
+}
+
+// CreatedTag records a tag created by the reconciler.
+type CreatedTag struct {
+	Kind string // KindStory or an internal tag object type
+	Name string
+}
+
+// String renders "kind:name".
+func (t CreatedTag) String() string { return t.Kind + ":" + t.Name }
+
+// Reconciler ensures tags exist in one target space. Target tags are listed
+// once per kind and cached; it is safe for concurrent use by sync workers.
+// mu guards the caches only, so workers don't wait on each other's creates.
+type Reconciler struct {
+	stories  StoryTagAPI
+	internal InternalTagAPI
+	spaceID  int
+
+	listMu    sync.Mutex // serializes the initial listings
+	mu        sync.Mutex
+	storyTags map[string]bool           // nil until listed
+	internals map[string]map[string]int // object type -> name -> id
+	inflight  map[string]chan struct{}  // kind + name of creates in progress
+	created   []CreatedTag
+}
+
+// NewReconciler creates a reconciler for the target space. Either API may be
+// nil when the corresponding tag kind is not needed.
+func NewReconciler(stories StoryTagAPI, internal InternalTagAPI, spaceID int) *Reconciler {
+	return &Reconciler{
+		stories:   stories,
+		internal:  internal,
+		spaceID:   spaceID,
+		internals: make(map[string]map[string]int),
+		inflight:  make(map[string]chan struct{}),
+	}
+}
+
+// EnsureStoryTags creates missing story tags and returns the names it created.
+func (r *Reconciler) EnsureStoryTags(ctx context.Context, names []string) ([]string, error) {
+	names = uniqueNames(names)
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if r.stories == nil {
+		return nil, fmt.Errorf("tagsync: no story tag API configured")
+	}
+	if err := r.listStoryTags(ctx); err != nil {
+		return nil, err
+	}
+
+	var created []string
+	for _, n := range names {
+		_, isNew, err := r.ensure(ctx, KindStory, n,
+			func() (int, bool) { return 0, r.storyTags[n] },
+			func(int) { r.storyTags[n] = true },
+			func() (int, error) {
+				t, err := r.stories.CreateTag(ctx, r.spaceID, n)
+				return t.ID, err
+			})
+		if err != nil {
+			return created, fmt.Errorf("create tag %q: %w", n, err)
+		}
+		if isNew {
+			created = append(created, n)
+		}
+	}
+	return created, nil
+}
+
+// EnsureInternalTags creates missing internal tags of the given object type and
+// returns a name->ID map for all requested names plus the names it created.
+// Existing tags without an object type are treated as matching any type.
+func (r *Reconciler) EnsureInternalTags(ctx context.Context, objectType string, names []string) (map[string]int, []string, error) {
+	names = uniqueNames(names)
+	out := make(map[string]int, len(names))
+	if len(names) == 0 {
+		return out, nil, nil
+	}
+	if r.internal == nil {
+		return nil, nil, fmt.Errorf("tagsync: no internal tag API configured")
+	}
+	if err := r.listInternalTags(ctx, objectType); err != nil {
+		return nil, nil, err
+	}
+
+	var created []string
+	for _, n := range names {
+		id, isNew, err := r.ensure(ctx, objectType, n,
+			func() (int, bool) {
+				id, ok := r.internals[objectType][n]
+				return id, ok
+			},
+			func(id int) { r.internals[objectType][n] = id },
+			func() (int, error) {
+				t, err := r.internal.CreateInternalTag(ctx, r.spaceID, n, objectType)
+				return t.ID, err
+			})
+		if err != nil {
+			return nil, created, fmt.Errorf("create internal tag %q: %w", n, err)
+		}
+		out[n] = id
+		if isNew {
+			created = append(created, n)
+		}
+	}
+	return out, created, nil
+}
+
+// listStoryTags fills the story tag cache on first use.
+func (r *Reconciler) listStoryTags(ctx context.Context) error {
+	r.listMu.Lock()
+	defer r.listMu.Unlock()
+	r.mu.Lock()
+	listed := r.storyTags != nil
+	r.mu.Unlock()
+	if listed {
+		return nil
+	}
+	existing, err := r.stories.ListTags(ctx, r.spaceID)
+	if err != nil {
+		return fmt.Errorf("list tags: %w", err)
+	}
+	byName := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		byName[t.Name] = true
+	}
+	r.mu.Lock()
+	r.storyTags = byName
+	r.mu.Unlock()
+	return nil
+}
+
+// listInternalTags fills the cache of one object type on first use.
+func (r *Reconciler) listInternalTags(ctx context.Context, objectType string) error {
+	r.listMu.Lock()
+	defer r.listMu.Unlock()
+	r.mu.Lock()
+	_, listed := r.internals[objectType]
+	r.mu.Unlock()
+	if listed {
+		return nil
+	}
+	existing, err := r.internal.ListInternalTags(ctx, r.spaceID)
+	if err != nil {
+		return fmt.Errorf("list internal tags: %w", err)
+	}
+	byName := make(map[string]int, len(existing))
+	for _, t := range existing {
+		if t.ObjectType == "" || t.ObjectType == objectType {
+			byName[t.Name] = t.ID
+		}
+	}
+	r.mu.Lock()
+	r.internals[objectType] = byName
+	r.mu.Unlock()
+	return nil
+}
+
+// ensure creates a tag unless it is cached, waiting for a concurrent create of
+// the same tag instead of repeating it. cached and store run under r.mu; the
+// create call does not. It returns the tag ID and whether this call created it.
+func (r *Reconciler) ensure(ctx context.Context, kind, name string, cached func() (int, bool), store func(int), create func() (int, error)) (int, bool, error) {
+	key := kind + "\x00" + name
+	for {
+		r.mu.Lock()
+		if id, ok := cached(); ok {
+			r.mu.Unlock()
+			return id, false, nil
+		}
+		wait, busy := r.inflight[key]
+		if !busy {
+			done := make(chan struct{})
+			r.inflight[key] = done
+			r.mu.Unlock()
+
+			id, err := create()
+
+			r.mu.Lock()
+			delete(r.inflight, key)
+			close(done)
+			if err == nil {
+				store(id)
+				r.created = append(r.created, CreatedTag{Kind: kind, Name: name})
+			}
+			r.mu.Unlock()
+			return id, err == nil, err
+		}
+		r.mu.Unlock()
+		// another worker creates the tag; retry from the cache when it is done
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return 0, false, ctx.Err()
+		}
+	}
+}
+
+// Created returns all tags created so far, sorted by kind and name.
+func (r *Reconciler) Created() []CreatedTag {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := append([]CreatedTag(nil), r.created...)
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// TagNames extracts tag names from a raw tag_list value ([]interface{} or []string).
+func TagNames(v interface{}) []string {
+	switch tl := v.(type) {
+	case []string:
+		return tl
+	case []interface{}:
+		out := make([]string, 0, len(tl))
+		for _, e := range tl {
+			if s, ok := e.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func uniqueNames(names []string) []string {
+	out := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, n := range names {
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		out = append(out, n)
+	}
+	return out
+}
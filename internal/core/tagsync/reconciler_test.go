@@ -0,0 +1,181 @@
+package tagsync
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"storyblok-sync/internal/sb"
+)
+
+type fakeTagAPI struct {
+	tags      []sb.Tag
+	internal  []sb.InternalTag
+	listCalls int
+	created   []string
+	fail      bool
+}
+
+func (f *fakeTagAPI) ListTags(ctx context.Context, spaceID int) ([]sb.Tag, error) {
+	f.listCalls++
+	return f.tags, nil
+}
+
+func (f *fakeTagAPI) CreateTag(ctx context.Context, spaceID int, name string) (sb.Tag, error) {
+	if f.fail {
+		return sb.Tag{}, errors.New("boom")
+	}
+	f.created = append(f.created, "story:"+name)
+	return sb.Tag{ID: len(f.created), Name: name}, nil
+}
+
+func (f *fakeTagAPI) ListInternalTags(ctx context.Context, spaceID int) ([]sb.InternalTag, error) {
+	f.listCalls++
+	return f.internal, nil
+}
+
+func (f *fakeTagAPI) CreateInternalTag(ctx context.Context, spaceID int, name string, objectType string) (sb.InternalTag, error) {
+	if f.fail {
+		return sb.InternalTag{}, errors.New("boom")
+	}
+	f.created = append(f.created, objectType+":"+name)
+	return sb.InternalTag{ID: 100 + len(f.created), Name: name, ObjectType: objectType}, nil
+}
+
+func TestEnsureStoryTags_CreatesMissingOnce(t *testing.T) {
+	api := &fakeTagAPI{tags: []sb.Tag{{ID: 1, Name: "news"}}}
+	r := NewReconciler(api, api, 1)
+
+	created, err := r.EnsureStoryTags(context.Background(), []string{"news", "blog", "blog", ""})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(created, []string{"blog"}) {
+		t.Fatalf("created = %v", created)
+	}
+	// Second call hits the cache: no new list, no new create
+	created, err = r.EnsureStoryTags(context.Background(), []string{"blog"})
+	if err != nil || len(created) != 0 {
+		t.Fatalf("second call: created=%v err=%v", created, err)
+	}
+	if api.listCalls != 1 {
+		t.Fatalf("list calls = %d, want 1", api.listCalls)
+	}
+}
+
+func TestEnsureInternalTags_ScopedByObjectType(t *testing.T) {
+	api := &fakeTagAPI{internal: []sb.InternalTag{
+		{ID: 5, Name: "hero", ObjectType: ObjectTypeComponent},
+		{ID: 6, Name: "legacy"},
+	}}
+	r := NewReconciler(nil, api, 1)
+
+	ids, created, err := r.EnsureInternalTags(context.Background(), ObjectTypeAsset, []string{"hero", "legacy"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// "hero" exists only for components and must be created for assets
+	if !reflect.DeepEqual(created, []string{"hero"}) {
+		t.Fatalf("created = %v", created)
+	}
+	if ids["legacy"] != 6 || ids["hero"] == 5 || ids["hero"] == 0 {
+		t.Fatalf("ids = %v", ids)
+	}
+
+	ids, created, err = r.EnsureInternalTags(context.Background(), ObjectTypeComponent, []string{"hero"})
+	if err != nil || len(created) != 0 || ids["hero"] != 5 {
+		t.Fatalf("component tags: ids=%v created=%v err=%v", ids, created, err)
+	}
+
+	want := []CreatedTag{{Kind: ObjectTypeAsset, Name: "hero"}}
+	if got := r.Created(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Created() = %v, want %v", got, want)
+	}
+}
+
+// blockingTagAPI holds creates of "slow" until release is closed.
+type blockingTagAPI struct {
+	mu      sync.Mutex
+	creates map[string]int
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingTagAPI) ListTags(ctx context.Context, spaceID int) ([]sb.Tag, error) {
+	return nil, nil
+}
+
+func (b *blockingTagAPI) CreateTag(ctx context.Context, spaceID int, name string) (sb.Tag, error) {
+	b.mu.Lock()
+	b.creates[name]++
+	b.mu.Unlock()
+	if name == "slow" {
+		close(b.started)
+		<-b.release
+	}
+	return sb.Tag{Name: name}, nil
+}
+
+func TestEnsureStoryTags_CreatesConcurrently(t *testing.T) {
+	api := &blockingTagAPI{creates: map[string]int{}, started: make(chan struct{}), release: make(chan struct{})}
+	r := NewReconciler(api, nil, 1)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	results := make([][]string, 2)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0], _ = r.EnsureStoryTags(ctx, []string{"slow"})
+	}()
+	<-api.started
+
+	// A different tag is created while "slow" is still in flight
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := r.EnsureStoryTags(ctx, []string{"fast"}); err != nil {
+			t.Errorf("fast: %v", err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("create of another tag waited on the slow create")
+	}
+
+	// The same tag is not created twice
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[1], _ = r.EnsureStoryTags(ctx, []string{"slow"})
+	}()
+	close(api.release)
+	wg.Wait()
+	if api.creates["slow"] != 1 || len(results[0])+len(results[1]) != 1 {
+		t.Fatalf("creates = %v, results = %v", api.creates, results)
+	}
+}
+
+func TestEnsureStoryTags_Errors(t *testing.T) {
+	if _, err := NewReconciler(nil, nil, 1).EnsureStoryTags(context.Background(), []string{"x"}); err == nil {
+		t.Fatal("expected error without story tag API")
+	}
+	api := &fakeTagAPI{fail: true}
+	if _, err := NewReconciler(api, api, 1).EnsureStoryTags(context.Background(), []string{"x"}); err == nil {
+		t.Fatal("expected create error")
+	}
+}
+
+func TestTagNames(t *testing.T) {
+	got := TagNames([]interface{}{"a", 1, "b"})
+	if !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("TagNames = %v", got)
+	}
+	if TagNames(nil) != nil {
+		t.Fatal("expected nil for missing tag_list")
+	}
+}
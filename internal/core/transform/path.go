@@ -0,0 +1,167 @@
+package transform
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// step is one segment of a selector.
+type step struct {
+	key       string // map key; "" with wildcard or index
+	index     int    // array index when isIndex
+	isIndex   bool
+	wildcard  bool
+	recursive bool // ".." – match at any depth below
+}
+
+// location is a selected value with a setter writing back into its parent.
+// missing marks a map key that does not exist yet (only selected for set).
+type location struct {
+	value   interface{}
+	set     func(interface{})
+	missing bool
+}
+
+// parseSelector parses the JSONPath subset used by rules:
+//
+//	$.content.body[0].title   children and array indexes
+//	$.content['seo-title']    quoted keys
+//	$.content.body[*]         all elements (or .* for all map values)
+//	$.content..url            a key at any depth
+func parseSelector(s string) ([]step, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "$") {
+		return nil, fmt.Errorf("selector %q must start with $", s)
+	}
+	var steps []step
+	rest := s[1:]
+	for rest != "" {
+		recursive := false
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			recursive = true
+			rest = rest[2:]
+		case rest[0] == '.':
+			rest = rest[1:]
+		case rest[0] == '[':
+		default:
+			return nil, fmt.Errorf("selector %q: unexpected %q", s, rest)
+		}
+		var st step
+		if strings.HasPrefix(rest, "[") {
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("selector %q: missing ]", s)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				st.wildcard = true
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				st.key = inner[1 : len(inner)-1]
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("selector %q: invalid index [%s]", s, inner)
+				}
+				st.index, st.isIndex = n, true
+			}
+		} else {
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			rest = rest[end:]
+			if name == "" {
+				return nil, fmt.Errorf("selector %q: empty key", s)
+			}
+			if name == "*" {
+				st.wildcard = true
+			} else {
+				st.key = name
+			}
+		}
+		st.recursive = recursive
+		steps = append(steps, st)
+	}
+	return steps, nil
+}
+
+// selectLocations returns all locations matching steps below root. With
+// create, a missing last key under an existing map is selected as well.
+func selectLocations(root map[string]interface{}, steps []step, create bool) []location {
+	var out []location
+	var walk func(node interface{}, i int, set func(interface{}))
+	walk = func(node interface{}, i int, set func(interface{})) {
+		if i == len(steps) {
+			out = append(out, location{value: node, set: set})
+			return
+		}
+		st := steps[i]
+		for _, c := range matchStep(node, st, create && i == len(steps)-1 && !st.recursive) {
+			if c.missing {
+				out = append(out, c)
+				continue
+			}
+			walk(c.value, i+1, c.set)
+		}
+		if st.recursive {
+			for _, c := range children(node) {
+				walk(c.value, i, c.set)
+			}
+		}
+	}
+	walk(root, 0, nil)
+	return out
+}
+
+// matchStep returns the children of node selected by one (non-recursive) step.
+func matchStep(node interface{}, st step, create bool) []location {
+	switch {
+	case st.wildcard:
+		return children(node)
+	case st.isIndex:
+		if a, ok := node.([]interface{}); ok && st.index < len(a) {
+			i := st.index
+			return []location{{value: a[i], set: func(v interface{}) { a[i] = v }}}
+		}
+	default:
+		if m, ok := node.(map[string]interface{}); ok {
+			key := st.key
+			if v, ok := m[key]; ok {
+				return []location{{value: v, set: func(v interface{}) { m[key] = v }}}
+			}
+			if create {
+				return []location{{set: func(v interface{}) { m[key] = v }, missing: true}}
+			}
+		}
+	}
+	return nil
+}
+
+// children returns the map values (sorted by key) or array elements of node.
+func children(node interface{}) []location {
+	var out []location
+	switch t := node.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			k := k
+			out = append(out, location{value: t[k], set: func(v interface{}) { t[k] = v }})
+		}
+	case []interface{}:
+		for i := range t {
+			i := i
+			out = append(out, location{value: t[i], set: func(v interface{}) { t[i] = v }})
+		}
+	}
+	return out
+}
@@ -0,0 +1,327 @@
+// Package transform rewrites story payloads on their way into a target
+// space: rules select values with JSONPath-style selectors and set, delete or
+// regex-replace them, or drop bloks of given components.
+package transform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Operations
+const (
+	OpSet          = "set"
+	OpDelete       = "delete"
+	OpRegexReplace = "regex_replace"
+	OpDropBlok     = "drop_blok"
+)
+
+// DefaultPath is the rules file used when SB_TRANSFORMS is not set.
+var DefaultPath = filepath.Join(".sbsync", "transforms.json")
+
+// Rule is one transformation. Path selects values in the raw story payload
+// ("$.content..url"); drop_blok defaults to "$.content".
+type Rule struct {
+	Target     int         `json:"target,omitempty"` // target space ID; 0 applies to every target
+	Op         string      `json:"op"`
+	Path       string      `json:"path,omitempty"`
+	Value      interface{} `json:"value,omitempty"`      // set
+	Pattern    string      `json:"pattern,omitempty"`    // regex_replace, applied to every string below the selected values
+	Replace    string      `json:"replace,omitempty"`    // regex_replace, with $1-style groups
+	Components []string    `json:"components,omitempty"` // drop_blok
+
+	steps []step
+	re    *regexp.Regexp
+}
+
+// Pipeline is an ordered list of rules. A nil pipeline transforms nothing.
+type Pipeline struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Parse decodes and validates a rules file:
+//
+//	{"rules": [
+//	  {"op": "regex_replace", "path": "$.content..url", "pattern": "www\\.example\\.com", "replace": "www.example.ch"},
+//	  {"op": "drop_blok", "components": ["internal_note"]},
+//	  {"op": "set", "path": "$.content.market", "value": "ch", "target": 654321}
+//	]}
+func Parse(data []byte) (*Pipeline, error) {
+	var p Pipeline
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&p); err != nil {
+		return nil, err
+	}
+	for i := range p.Rules {
+		if err := p.Rules[i].compile(); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+	return &p, nil
+}
+
+// Load reads the rules file at path.
+func Load(path string) (*Pipeline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("transforms %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// FromEnv loads the rules named by SB_TRANSFORMS: a path, "off", or unset
+// for DefaultPath when that file exists.
+func FromEnv() (*Pipeline, error) {
+	v := strings.TrimSpace(os.Getenv("SB_TRANSFORMS"))
+	switch strings.ToLower(v) {
+	case "off", "0", "false", "no":
+		return nil, nil
+	case "":
+		p, err := Load(DefaultPath)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return p, err
+	}
+	return Load(v)
+}
+
+func (r *Rule) compile() error {
+	path := r.Path
+	if path == "" && r.Op == OpDropBlok {
+		path = "$.content"
+	}
+	steps, err := parseSelector(path)
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		return fmt.Errorf("path must select below $")
+	}
+	r.steps = steps
+	switch r.Op {
+	case OpSet:
+		if r.Value == nil {
+			return fmt.Errorf("set needs a value")
+		}
+	case OpDelete:
+	case OpRegexReplace:
+		if r.Pattern == "" {
+			return fmt.Errorf("regex_replace needs a pattern")
+		}
+		if r.re, err = regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("pattern: %w", err)
+		}
+	case OpDropBlok:
+		if len(r.Components) == 0 {
+			return fmt.Errorf("drop_blok needs components")
+		}
+	default:
+		return fmt.Errorf("unknown op %q (set, delete, regex_replace, drop_blok)", r.Op)
+	}
+	return nil
+}
+
+// Len returns the number of rules.
+func (p *Pipeline) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.Rules)
+}
+
+// String summarizes the rules per operation, e.g. "2 regex_replace, 1 set".
+func (p *Pipeline) String() string {
+	if p.Len() == 0 {
+		return ""
+	}
+	counts := map[string]int{}
+	for _, r := range p.Rules {
+		counts[r.Op]++
+	}
+	var parts []string
+	for _, op := range []string{OpSet, OpDelete, OpRegexReplace, OpDropBlok} {
+		if n := counts[op]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, op))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Apply runs the rules for the target space on a raw story payload in place
+// and returns the number of values changed.
+func (p *Pipeline) Apply(raw map[string]interface{}, targetSpaceID int) int {
+	if p == nil || raw == nil {
+		return 0
+	}
+	changed := 0
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		if r.Target != 0 && r.Target != targetSpaceID {
+			continue
+		}
+		changed += r.apply(raw)
+	}
+	return changed
+}
+
+// removed marks deleted values until compact drops them.
+type removed struct{}
+
+func (r *Rule) apply(raw map[string]interface{}) int {
+	changed := 0
+	for _, loc := range selectLocations(raw, r.steps, r.Op == OpSet) {
+		switch r.Op {
+		case OpSet:
+			if loc.missing || !reflect.DeepEqual(loc.value, r.Value) {
+				loc.set(clone(r.Value))
+				changed++
+			}
+		case OpDelete:
+			loc.set(removed{})
+			changed++
+		case OpRegexReplace:
+			v, n := r.replaceStrings(loc.value)
+			if n > 0 {
+				loc.set(v)
+				changed += n
+			}
+		case OpDropBlok:
+			v, n := r.dropBloks(loc.value)
+			if n > 0 {
+				loc.set(v)
+				changed += n
+			}
+		}
+	}
+	if r.Op == OpDelete && changed > 0 {
+		for k, v := range raw {
+			if _, ok := v.(removed); ok {
+				delete(raw, k)
+			} else {
+				raw[k] = compact(v)
+			}
+		}
+	}
+	return changed
+}
+
+// replaceStrings applies the pattern to every string in v.
+func (r *Rule) replaceStrings(v interface{}) (interface{}, int) {
+	switch t := v.(type) {
+	case string:
+		out := r.re.ReplaceAllString(t, r.Replace)
+		if out != t {
+			return out, 1
+		}
+		return t, 0
+	case map[string]interface{}:
+		n := 0
+		for k, c := range t {
+			var m int
+			t[k], m = r.replaceStrings(c)
+			n += m
+		}
+		return t, n
+	case []interface{}:
+		n := 0
+		for i, c := range t {
+			var m int
+			t[i], m = r.replaceStrings(c)
+			n += m
+		}
+		return t, n
+	}
+	return v, 0
+}
+
+// dropBloks removes bloks of the rule's components from every array in v.
+func (r *Rule) dropBloks(v interface{}) (interface{}, int) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		n := 0
+		for k, c := range t {
+			var m int
+			t[k], m = r.dropBloks(c)
+			n += m
+		}
+		return t, n
+	case []interface{}:
+		n := 0
+		out := t[:0:0]
+		for _, c := range t {
+			if b, ok := c.(map[string]interface{}); ok && r.dropsComponent(b["component"]) {
+				n++
+				continue
+			}
+			c, m := r.dropBloks(c)
+			n += m
+			out = append(out, c)
+		}
+		return out, n
+	}
+	return v, 0
+}
+
+func (r *Rule) dropsComponent(v interface{}) bool {
+	name, _ := v.(string)
+	for _, c := range r.Components {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// compact drops removed values from maps and arrays.
+func compact(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, c := range t {
+			if _, ok := c.(removed); ok {
+				delete(t, k)
+			} else {
+				t[k] = compact(c)
+			}
+		}
+	case []interface{}:
+		out := t[:0:0]
+		for _, c := range t {
+			if _, ok := c.(removed); !ok {
+				out = append(out, compact(c))
+			}
+		}
+		return out
+	}
+	return v
+}
+
+// clone deep-copies a JSON value so set never shares maps between stories.
+func clone(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, c := range t {
+			out[k] = clone(c)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, c := range t {
+			out[i] = clone(c)
+		}
+		return out
+	}
+	return v
+}
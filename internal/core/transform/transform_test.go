@@ -0,0 +1,151 @@
+package transform
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func story() map[string]interface{} {
+	return map[string]interface{}{
+		"name": "Home",
+		"content": map[string]interface{}{
+			"component": "page",
+			"title":     "Welcome",
+			"link":      map[string]interface{}{"url": "https://www.example.com/a"},
+			"body": []interface{}{
+				map[string]interface{}{"component": "teaser", "url": "http://www.example.com/b"},
+				map[string]interface{}{"component": "internal_note", "text": "todo"},
+				map[string]interface{}{"component": "grid", "columns": []interface{}{
+					map[string]interface{}{"component": "internal_note"},
+					map[string]interface{}{"component": "text", "seo-title": "x"},
+				}},
+			},
+		},
+	}
+}
+
+func mustParse(t *testing.T, data string) *Pipeline {
+	t.Helper()
+	p, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return p
+}
+
+func TestParseSelector(t *testing.T) {
+	steps, err := parseSelector(`$.content..url[0]['seo-title'][*].*`)
+	if err != nil {
+		t.Fatalf("parseSelector: %v", err)
+	}
+	want := []step{{key: "content"}, {key: "url", recursive: true}, {index: 0, isIndex: true}, {key: "seo-title"}, {wildcard: true}, {wildcard: true}}
+	if !reflect.DeepEqual(steps, want) {
+		t.Fatalf("steps = %+v", steps)
+	}
+	for _, s := range []string{"content.title", "$.", "$[x]", "$.a[0", "$x"} {
+		if _, err := parseSelector(s); err == nil {
+			t.Errorf("parseSelector(%q): expected error", s)
+		}
+	}
+}
+
+func TestApplyOperations(t *testing.T) {
+	p := mustParse(t, `{"rules": [
+		{"op": "regex_replace", "path": "$.content..url", "pattern": "www\\.example\\.com", "replace": "www.example.ch"},
+		{"op": "drop_blok", "components": ["internal_note"]},
+		{"op": "set", "path": "$.content.market", "value": "ch"},
+		{"op": "set", "path": "$.content.title", "value": "Welcome"},
+		{"op": "delete", "path": "$.content..['seo-title']"}
+	]}`)
+	raw := story()
+	if n := p.Apply(raw, 1); n != 6 {
+		t.Errorf("changes = %d, want 6", n)
+	}
+	content := raw["content"].(map[string]interface{})
+	if got := content["link"].(map[string]interface{})["url"]; got != "https://www.example.ch/a" {
+		t.Errorf("link url = %v", got)
+	}
+	body := content["body"].([]interface{})
+	if len(body) != 2 || body[0].(map[string]interface{})["url"] != "http://www.example.ch/b" {
+		t.Fatalf("body = %+v", body)
+	}
+	columns := body[1].(map[string]interface{})["columns"].([]interface{})
+	if len(columns) != 1 || !reflect.DeepEqual(columns[0], map[string]interface{}{"component": "text"}) {
+		t.Errorf("columns = %+v", columns)
+	}
+	if content["market"] != "ch" || content["title"] != "Welcome" {
+		t.Errorf("content = %+v", content)
+	}
+}
+
+func TestApplyDeleteCompactsArrays(t *testing.T) {
+	p := mustParse(t, `{"rules": [{"op": "delete", "path": "$.content.body[*]"}, {"op": "delete", "path": "$.name"}]}`)
+	raw := story()
+	if n := p.Apply(raw, 1); n != 4 {
+		t.Errorf("changes = %d, want 4", n)
+	}
+	if _, ok := raw["name"]; ok {
+		t.Error("name not deleted")
+	}
+	if body := raw["content"].(map[string]interface{})["body"].([]interface{}); len(body) != 0 {
+		t.Errorf("body = %+v", body)
+	}
+}
+
+func TestApplyRespectsTarget(t *testing.T) {
+	p := mustParse(t, `{"rules": [{"op": "set", "path": "$.content.market", "value": {"code": "ch"}, "target": 2}]}`)
+	raw := story()
+	if n := p.Apply(raw, 1); n != 0 {
+		t.Fatalf("rule for target 2 applied to target 1 (%d changes)", n)
+	}
+	a, b := story(), story()
+	p.Apply(a, 2)
+	p.Apply(b, 2)
+	a["content"].(map[string]interface{})["market"].(map[string]interface{})["code"] = "de"
+	if got := b["content"].(map[string]interface{})["market"].(map[string]interface{})["code"]; got != "ch" {
+		t.Errorf("set value shared between stories: %v", got)
+	}
+
+	var nilPipeline *Pipeline
+	if nilPipeline.Apply(raw, 1) != 0 || nilPipeline.String() != "" {
+		t.Error("nil pipeline transformed something")
+	}
+}
+
+func TestParseRejectsInvalidRules(t *testing.T) {
+	for _, data := range []string{
+		`{"rules": [{"op": "rename", "path": "$.a"}]}`,
+		`{"rules": [{"op": "set", "path": "$.a"}]}`,
+		`{"rules": [{"op": "regex_replace", "path": "$.a", "pattern": "("}]}`,
+		`{"rules": [{"op": "drop_blok"}]}`,
+		`{"rules": [{"op": "delete", "path": "$"}]}`,
+		`{"rules": [{"op": "delete", "selector": "$.a"}]}`,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Parse(%s): expected error", data)
+		}
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.json")
+	if err := os.WriteFile(path, []byte(`{"rules": [{"op": "drop_blok", "components": ["a"]}, {"op": "set", "path": "$.x", "value": 1}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SB_TRANSFORMS", path)
+	p, err := FromEnv()
+	if err != nil || p.Len() != 2 || p.String() != "1 set, 1 drop_blok" {
+		t.Fatalf("FromEnv = %v, %v", p, err)
+	}
+	t.Setenv("SB_TRANSFORMS", "off")
+	if p, err := FromEnv(); p != nil || err != nil {
+		t.Fatalf("off = %v, %v", p, err)
+	}
+	t.Setenv("SB_TRANSFORMS", filepath.Join(dir, "missing.json"))
+	if _, err := FromEnv(); err == nil {
+		t.Fatal("missing explicit file: expected error")
+	}
+}
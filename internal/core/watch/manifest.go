@@ -0,0 +1,246 @@
+package watch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest describes what a watch keeps in sync:
+//
+//	source: 123456
+//	target: 654321
+//	interval: 5m
+//	include:
+//	  - de/blog
+//	  - en/news/**
+//	exclude: ["**/draft-*"]
+//	components: [teaser, "hero-*"]
+//	datasources: ["*"]
+//
+// Patterns starting with "*" must be quoted, as YAML reads them as aliases
+// otherwise. Patterns match full slugs segment by segment: "*" matches within one
+// segment, "**" any number of segments; a pattern without wildcards matches
+// the slug itself and everything below it. No include means the whole space.
+// Components and datasources (by name and slug) are only synced by
+// `sbsync serve` and only when listed.
+type Manifest struct {
+	Source      int
+	Target      int
+	Interval    time.Duration // 0: use the command line default
+	State       string        // state file; "" uses DefaultStatePath
+	Include     []string
+	Exclude     []string
+	Components  []string
+	Datasources []string
+}
+
+// DefaultStateDir is where state files are stored relative to the working directory.
+const DefaultStateDir = ".sbsync"
+
+// DefaultStatePath returns the state file of a watch from one space to another.
+func DefaultStatePath(fromID, toID int) string {
+	return filepath.Join(DefaultStateDir, fmt.Sprintf("watch-%d-%d.json", fromID, toID))
+}
+
+// LoadManifest reads and validates the manifest at path.
+func LoadManifest(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, err
+	}
+	m, err := ParseManifest(data)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("manifest %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// manifestFile is the YAML layout of a manifest.
+type manifestFile struct {
+	Source      spaceID  `yaml:"source"`
+	Target      spaceID  `yaml:"target"`
+	Interval    duration `yaml:"interval"`
+	State       string   `yaml:"state"`
+	Include     patterns `yaml:"include"`
+	Exclude     patterns `yaml:"exclude"`
+	Components  patterns `yaml:"components"`
+	Datasources patterns `yaml:"datasources"`
+}
+
+// ParseManifest decodes and validates a manifest. Unknown keys, duplicate
+// keys and more than one YAML document are errors.
+func ParseManifest(data []byte) (Manifest, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var f manifestFile
+	if err := dec.Decode(&f); err != nil {
+		if errors.Is(err, io.EOF) {
+			return Manifest{}, fmt.Errorf("source and target are required")
+		}
+		return Manifest{}, err
+	}
+	var extra yaml.Node
+	if err := dec.Decode(&extra); !errors.Is(err, io.EOF) {
+		return Manifest{}, fmt.Errorf("multiple documents are not supported")
+	}
+	m := Manifest{
+		Source:      int(f.Source),
+		Target:      int(f.Target),
+		Interval:    time.Duration(f.Interval),
+		State:       f.State,
+		Include:     f.Include,
+		Exclude:     f.Exclude,
+		Components:  f.Components,
+		Datasources: f.Datasources,
+	}
+	if m.Source == 0 || m.Target == 0 {
+		return Manifest{}, fmt.Errorf("source and target are required")
+	}
+	if m.Source == m.Target {
+		return Manifest{}, fmt.Errorf("source and target must be different spaces")
+	}
+	return m, nil
+}
+
+// spaceID is a space ID given as number or string.
+type spaceID int
+
+func (id *spaceID) UnmarshalYAML(n *yaml.Node) error {
+	v, err := strconv.Atoi(n.Value)
+	if n.Kind != yaml.ScalarNode || err != nil || v <= 0 {
+		return fmt.Errorf("line %d: %q is not a space ID", n.Line, n.Value)
+	}
+	*id = spaceID(v)
+	return nil
+}
+
+// duration is a Go duration such as 5m.
+type duration time.Duration
+
+func (d *duration) UnmarshalYAML(n *yaml.Node) error {
+	v, err := time.ParseDuration(n.Value)
+	if n.Kind != yaml.ScalarNode || err != nil || v <= 0 {
+		return fmt.Errorf("line %d: interval must be a duration like 5m", n.Line)
+	}
+	*d = duration(v)
+	return nil
+}
+
+// patterns is one pattern or a list of them.
+type patterns []string
+
+func (p *patterns) UnmarshalYAML(n *yaml.Node) error {
+	items := []*yaml.Node{n}
+	if n.Kind == yaml.SequenceNode {
+		items = n.Content
+	}
+	out := make([]string, 0, len(items))
+	for _, it := range items {
+		if it.Kind != yaml.ScalarNode {
+			return fmt.Errorf("line %d: expected a pattern or a list of patterns", it.Line)
+		}
+		if err := validPattern(it.Value); err != nil {
+			return fmt.Errorf("line %d: %w", it.Line, err)
+		}
+		out = append(out, it.Value)
+	}
+	*p = out
+	return nil
+}
+
+// StatePath returns the configured or default state file.
+func (m Manifest) StatePath() string {
+	if m.State != "" {
+		return m.State
+	}
+	return DefaultStatePath(m.Source, m.Target)
+}
+
+// InScope reports whether a full slug is covered by the manifest.
+func (m Manifest) InScope(slug string) bool {
+	if len(m.Include) > 0 && !matchAny(m.Include, slug) {
+		return false
+	}
+	return !matchAny(m.Exclude, slug)
+}
+
+// ComponentInScope reports whether a component is listed in the manifest.
+func (m Manifest) ComponentInScope(name string) bool {
+	return matchNames(m.Components, name)
+}
+
+// DatasourceInScope reports whether a datasource slug is listed in the manifest.
+func (m Manifest) DatasourceInScope(slug string) bool {
+	return matchNames(m.Datasources, slug)
+}
+
+func matchNames(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAny(patterns []string, slug string) bool {
+	for _, p := range patterns {
+		if matchSlug(p, slug) {
+			return true
+		}
+	}
+	return false
+}
+
+func validPattern(p string) error {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return fmt.Errorf("empty pattern")
+	}
+	for _, seg := range strings.Split(p, "/") {
+		if _, err := path.Match(seg, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q", p)
+		}
+	}
+	return nil
+}
+
+// matchSlug matches a full slug against a pattern (see Manifest).
+func matchSlug(pattern, slug string) bool {
+	pattern, slug = strings.Trim(pattern, "/"), strings.Trim(slug, "/")
+	if !strings.ContainsAny(pattern, "*?[") {
+		return slug == pattern || strings.HasPrefix(slug, pattern+"/")
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(slug, "/"))
+}
+
+func matchSegments(pat, segs []string) bool {
+	for len(pat) > 0 {
+		if pat[0] == "**" {
+			for i := 0; i <= len(segs); i++ {
+				if matchSegments(pat[1:], segs[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segs) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pat[0], segs[0]); !ok {
+			return false
+		}
+		pat, segs = pat[1:], segs[1:]
+	}
+	return len(segs) == 0
+}
@@ -0,0 +1,100 @@
+package watch
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseManifest(t *testing.T) {
+	m, err := ParseManifest([]byte(`---
+# nightly mirror
+source: 123
+target: "456"
+interval: 10m
+state: .sbsync/mirror.json
+include:
+  - de/blog   # whole subtree
+  - 'en/news/**'
+exclude: ['**/draft-*', de/blog/old]
+components: [teaser, "hero-*"]
+datasources: "*"
+`))
+	if err != nil {
+		t.Fatalf("ParseManifest: %v", err)
+	}
+	want := Manifest{
+		Source: 123, Target: 456, Interval: 10 * time.Minute, State: ".sbsync/mirror.json",
+		Include:     []string{"de/blog", "en/news/**"},
+		Exclude:     []string{"**/draft-*", "de/blog/old"},
+		Components:  []string{"teaser", "hero-*"},
+		Datasources: []string{"*"},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Fatalf("manifest = %+v, want %+v", m, want)
+	}
+	if !m.ComponentInScope("hero-big") || m.ComponentInScope("page") || !m.DatasourceInScope("colors") {
+		t.Fatal("component/datasource scope mismatch")
+	}
+	if (Manifest{}).ComponentInScope("teaser") {
+		t.Fatal("components must be listed to be in scope")
+	}
+	if m.StatePath() != ".sbsync/mirror.json" {
+		t.Fatalf("StatePath = %q", m.StatePath())
+	}
+	m.State = ""
+	if m.StatePath() != filepath.Join(".sbsync", "watch-123-456.json") {
+		t.Fatalf("default StatePath = %q", m.StatePath())
+	}
+}
+
+func TestParseManifestErrors(t *testing.T) {
+	cases := map[string]string{
+		"":                                          "required",
+		"source: 1\n":                               "required",
+		"source: 1\ntarget: 1\n":                    "different",
+		"source: x\ntarget: 2\n":                    `line 1: "x" is not a space ID`,
+		"source: 1\ntarget: 2\nfoo: bar\n":          "line 3: field foo not found",
+		"source: 1\ntarget: 2\ninterval: 5\n":       "line 3: interval",
+		"source: 1\ntarget: 2\nsource: 3\n":         `line 3: mapping key "source" already defined`,
+		"source: 1\ntarget: 2\ninclude: [a/[\n":     "line 2: did not find expected",
+		"- a\n":                                     "cannot unmarshal !!seq",
+		"source: 1\ntarget: 2\ninclude: [a, [b]]\n": "line 3: expected a pattern",
+		"source: 1\ntarget: 2\ninclude: {a: b}\n":   "line 3: expected a pattern",
+		"source: 1\ntarget: 2\ninclude: 'a/[b'\n":   "invalid pattern",
+		"source: 1\ntarget: 2\nstate: [a]\n":        "line 3: cannot unmarshal !!seq",
+		"source: 1\ntarget: 2\nexclude: [**/x]\n":   "line 3",
+		"source: 1\n---\ntarget: 2\n":               "multiple documents",
+	}
+	for in, want := range cases {
+		if _, err := ParseManifest([]byte(in)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseManifest(%q) error = %v, want %q", in, err, want)
+		}
+	}
+}
+
+func TestManifestInScope(t *testing.T) {
+	m := Manifest{Include: []string{"de/blog", "en/*/featured", "shop/**/sale"}, Exclude: []string{"**/draft-*"}}
+	cases := map[string]bool{
+		"de/blog":              true,
+		"de/blog/post":         true,
+		"de/blogger":           false,
+		"en/news/featured":     true,
+		"en/news/x/featured":   false,
+		"shop/sale":            true,
+		"shop/a/b/sale":        true,
+		"de/blog/draft-post":   false,
+		"de/blog/a/draft-post": false,
+		"fr/blog":              false,
+	}
+	for slug, want := range cases {
+		if got := m.InScope(slug); got != want {
+			t.Errorf("InScope(%q) = %v, want %v", slug, got, want)
+		}
+	}
+	if !(Manifest{}).InScope("anything/at/all") {
+		t.Fatal("empty include should cover the whole space")
+	}
+}
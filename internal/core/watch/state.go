@@ -0,0 +1,90 @@
+package watch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// State records the last synced version (updated_at) of every source story,
+// so a cycle only picks up what changed since. It is rewritten atomically
+// after every item.
+type State struct {
+	path string
+	mu   sync.Mutex
+
+	Source  int            `json:"source"`
+	Target  int            `json:"target"`
+	Seen    map[int]string `json:"seen"` // source story ID → updated_at
+	LastRun time.Time      `json:"last_run,omitempty"`
+}
+
+// LoadState reads the state at path or starts an empty one when the file
+// does not exist. A state of another space pair is an error.
+func LoadState(path string, fromID, toID int) (*State, error) {
+	st := &State{path: path, Source: fromID, Target: toID, Seen: make(map[int]string)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("state %s: %w", path, err)
+	}
+	if st.Source != fromID || st.Target != toID {
+		return nil, fmt.Errorf("state %s belongs to watch %d → %d", path, st.Source, st.Target)
+	}
+	if st.Seen == nil {
+		st.Seen = make(map[int]string)
+	}
+	return st, nil
+}
+
+// Changed reports whether the story version differs from the last synced one.
+func (st *State) Changed(id int, updatedAt string) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	v, ok := st.Seen[id]
+	return !ok || v != updatedAt
+}
+
+// MarkSynced stores the synced version of a story and persists the state.
+func (st *State) MarkSynced(id int, updatedAt string) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.Seen[id] = updatedAt
+	return st.save()
+}
+
+// Finish records the end of a cycle and persists the state.
+func (st *State) Finish(at time.Time) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.LastRun = at
+	return st.save()
+}
+
+// save writes the state, replacing the previous file atomically.
+func (st *State) save() error {
+	if st.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(st.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := st.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, st.path)
+}
@@ -0,0 +1,227 @@
+// Package watch keeps a target space in sync with a source space without
+// user interaction: every cycle fetches the stories updated since the last
+// scan, plans the stories in the manifest scope whose updated_at changed
+// since the last synced version and runs them through the sync orchestrator.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	synccore "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/core/tagsync"
+	"storyblok-sync/internal/core/transform"
+	"storyblok-sync/internal/report"
+	"storyblok-sync/internal/sb"
+)
+
+// API is the client subset a watch needs.
+type API interface {
+	synccore.SyncAPI
+	tagsync.StoryTagAPI
+	tagsync.InternalTagAPI
+	ListStories(ctx context.Context, opt sb.ListStoriesOpts) ([]sb.Story, error)
+}
+
+// Runner executes watch cycles for one manifest.
+type Runner struct {
+	api      API
+	manifest Manifest
+	source   sb.Space
+	target   sb.Space
+	state    *State
+	limiter  *synccore.SpaceLimiter
+	progress func(report.Entry)
+
+	transforms *transform.Pipeline
+
+	// stories of both spaces by ID as of the last scan; since is the start
+	// of that scan, zero until the first full scan or after a failure
+	src, tgt map[int]sb.Story
+	since    time.Time
+}
+
+// scanOverlap is subtracted from the last scan start, as updated_at_gt has
+// minute precision and the clocks of sbsync and Storyblok may differ.
+const scanOverlap = 2 * time.Minute
+
+// New creates a runner recording synced versions in st.
+func New(api API, m Manifest, source, target sb.Space, st *State) *Runner {
+	return &Runner{
+		api:      api,
+		manifest: m,
+		source:   source,
+		target:   target,
+		state:    st,
+		limiter:  synccore.NewFanOutLimiter(source, target),
+	}
+}
+
+// OnEntry registers a callback invoked for every synced item.
+func (r *Runner) OnEntry(fn func(report.Entry)) {
+	r.progress = fn
+}
+
+// SetTransforms rewrites story payloads with the pipeline before each write.
+func (r *Runner) SetTransforms(p *transform.Pipeline) {
+	r.transforms = p
+}
+
+// Plan scans both spaces and returns the changed stories in scope, with
+// missing parent folders added, in sync order, and the target index. The
+// first cycle lists both spaces in full; later cycles only fetch the stories
+// updated since the previous scan and merge them into the cached lists.
+func (r *Runner) Plan(ctx context.Context) ([]synccore.PreflightItem, map[string]sb.Story, error) {
+	start := time.Now()
+	opts := func(spaceID int) sb.ListStoriesOpts {
+		o := sb.ListStoriesOpts{SpaceID: spaceID, PerPage: 1000}
+		if !r.since.IsZero() {
+			o.UpdatedAfter = r.since.Add(-scanOverlap)
+		}
+		return o
+	}
+	srcDelta, err := r.api.ListStories(ctx, opts(r.source.ID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("source scan: %w", err)
+	}
+	tgtDelta, err := r.api.ListStories(ctx, opts(r.target.ID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("target scan: %w", err)
+	}
+	if r.since.IsZero() {
+		r.src, r.tgt = map[int]sb.Story{}, map[int]sb.Story{}
+	}
+	for _, st := range srcDelta {
+		r.src[st.ID] = st
+	}
+	for _, st := range tgtDelta {
+		r.tgt[st.ID] = st
+	}
+	r.since = start
+	src, tgt := sortedStories(r.src), sortedStories(r.tgt)
+
+	index := make(map[string]sb.Story, len(tgt))
+	for _, st := range tgt {
+		index[st.FullSlug] = st
+	}
+	var items []synccore.PreflightItem
+	for _, st := range src {
+		if !r.manifest.InScope(st.FullSlug) || !r.state.Changed(st.ID, st.UpdatedAt) {
+			continue
+		}
+		state := synccore.StateCreate
+		if _, ok := index[st.FullSlug]; ok {
+			state = synccore.StateUpdate
+		}
+		items = append(items, synccore.PreflightItem{Story: st, Selected: true, State: state})
+	}
+	if len(items) == 0 {
+		return nil, index, nil
+	}
+	return synccore.NewPreflightPlanner(src, tgt).OptimizePreflight(items), index, nil
+}
+
+// sortedStories returns the cached stories ordered by ID.
+func sortedStories(m map[int]sb.Story) []sb.Story {
+	out := make([]sb.Story, 0, len(m))
+	for _, st := range m {
+		out = append(out, st)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// RunOnce executes one cycle and returns its report (without entries when
+// nothing changed). Failed items keep their old version in the state and
+// are retried by the next cycle, which rescans both spaces in full in case
+// the cached target stories went stale. A cancelled ctx stops after the
+// current item.
+func (r *Runner) RunOnce(ctx context.Context) (*report.Report, error) {
+	rep := report.New(fmt.Sprintf("%s (%d)", r.source.Name, r.source.ID), fmt.Sprintf("%s (%d)", r.target.Name, r.target.ID))
+	rep.SourceSpaceID, rep.TargetSpaceID = r.source.ID, r.target.ID
+	defer rep.Finalize()
+	items, index, err := r.Plan(ctx)
+	if err != nil {
+		return rep, err
+	}
+
+	so := synccore.NewSyncOrchestrator(r.api, nil, &r.source, &r.target, index)
+	so.SetSpaceLimiter(r.limiter)
+	so.SetTagReconciler(tagsync.NewReconciler(r.api, r.api, r.target.ID))
+	so.SetTransforms(r.transforms)
+	for _, it := range items {
+		if err := ctx.Err(); err != nil {
+			return rep, err
+		}
+		story := it.Story
+		start := time.Now()
+		var res *synccore.SyncItemResult
+		if story.IsFolder {
+			res, err = so.SyncFolderDetailed(story)
+		} else {
+			res, err = so.SyncStoryDetailed(story)
+		}
+		e := report.Entry{Slug: story.FullSlug, Status: "success", Operation: it.State, Duration: time.Since(start).Milliseconds(), SourceID: story.ID}
+		if before, ok := index[story.FullSlug]; ok {
+			e.TargetIDBefore = before.ID
+		}
+		switch {
+		case err != nil:
+			e.Status, e.Error = "failure", err.Error()
+			r.since = time.Time{}
+		case res != nil:
+			e.Operation, e.Retries = res.Operation, res.RetryTotal
+			if res.Warning != "" {
+				e.Status, e.Warning = "warning", res.Warning
+			}
+			if res.TargetStory != nil {
+				e.TargetIDAfter = res.TargetStory.ID
+				index[story.FullSlug] = *res.TargetStory
+				r.tgt[res.TargetStory.ID] = *res.TargetStory
+			}
+		}
+		if story.IsFolder {
+			e.Kind = report.KindFolder
+		}
+		rep.Add(e)
+		if r.progress != nil {
+			r.progress(rep.Entries[len(rep.Entries)-1])
+		}
+		if err == nil {
+			if serr := r.state.MarkSynced(story.ID, story.UpdatedAt); serr != nil {
+				return rep, fmt.Errorf("save state: %w", serr)
+			}
+		}
+	}
+	if err := r.state.Finish(time.Now()); err != nil {
+		return rep, fmt.Errorf("save state: %w", err)
+	}
+	return rep, nil
+}
+
+// Run executes a cycle right away and then every interval until ctx is
+// cancelled. onCycle receives the outcome of every cycle; cycle errors do
+// not stop the watch.
+func (r *Runner) Run(ctx context.Context, interval time.Duration, onCycle func(*report.Report, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		rep, err := r.RunOnce(ctx)
+		if ctx.Err() != nil {
+			if onCycle != nil && rep != nil && len(rep.Entries) > 0 {
+				onCycle(rep, ctx.Err())
+			}
+			return ctx.Err()
+		}
+		if onCycle != nil {
+			onCycle(rep, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
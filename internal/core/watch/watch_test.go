@@ -0,0 +1,282 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"storyblok-sync/internal/report"
+	"storyblok-sync/internal/sb"
+)
+
+// fakeAPI implements API over in-memory spaces.
+type fakeAPI struct {
+	stories   map[int][]sb.Story
+	raw       map[int]map[string]interface{}
+	nextID    int
+	failSlugs map[string]bool // writes failing once
+	writes    map[string]int  // creates and updates per full slug
+	lists     []sb.ListStoriesOpts
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{stories: map[int][]sb.Story{}, raw: map[int]map[string]interface{}{}, nextID: 1000, failSlugs: map[string]bool{}, writes: map[string]int{}}
+}
+
+func (f *fakeAPI) addStory(spaceID int, st sb.Story) {
+	f.stories[spaceID] = append(f.stories[spaceID], st)
+	f.raw[st.ID] = map[string]interface{}{
+		"id": st.ID, "uuid": st.UUID, "name": st.Name, "slug": st.Slug, "full_slug": st.FullSlug,
+		"is_folder": st.IsFolder, "content": map[string]interface{}{"component": "page"},
+	}
+}
+
+func (f *fakeAPI) touch(spaceID int, slug, updatedAt string) {
+	for i := range f.stories[spaceID] {
+		if f.stories[spaceID][i].FullSlug == slug {
+			f.stories[spaceID][i].UpdatedAt = updatedAt
+		}
+	}
+}
+
+func (f *fakeAPI) GetStoriesBySlug(_ context.Context, spaceID int, slug string) ([]sb.Story, error) {
+	for _, st := range f.stories[spaceID] {
+		if st.FullSlug == slug {
+			return []sb.Story{st}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeAPI) GetStoryWithContent(_ context.Context, spaceID, storyID int) (sb.Story, error) {
+	for _, st := range f.stories[spaceID] {
+		if st.ID == storyID {
+			return st, nil
+		}
+	}
+	return sb.Story{}, errors.New("not found")
+}
+
+func (f *fakeAPI) UpdateStoryUUID(context.Context, int, int, string) error { return nil }
+
+func (f *fakeAPI) GetStoryRaw(_ context.Context, _ int, storyID int) (map[string]interface{}, error) {
+	raw, ok := f.raw[storyID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	out := make(map[string]interface{}, len(raw))
+	for k, v := range raw {
+		out[k] = v
+	}
+	return out, nil
+}
+
+func (f *fakeAPI) write(slug string) error {
+	f.writes[slug]++
+	if f.failSlugs[slug] {
+		delete(f.failSlugs, slug)
+		return errors.New("boom")
+	}
+	return nil
+}
+
+func (f *fakeAPI) CreateStoryRawWithPublish(_ context.Context, spaceID int, story map[string]interface{}, publish bool) (sb.Story, error) {
+	slug, _ := story["full_slug"].(string)
+	if err := f.write(slug); err != nil {
+		return sb.Story{}, err
+	}
+	f.nextID++
+	st := sb.Story{ID: f.nextID, Slug: story["slug"].(string), FullSlug: slug, Published: publish}
+	st.IsFolder, _ = story["is_folder"].(bool)
+	f.stories[spaceID] = append(f.stories[spaceID], st)
+	return st, nil
+}
+
+func (f *fakeAPI) UpdateStoryRawWithPublish(_ context.Context, spaceID int, storyID int, story map[string]interface{}, publish bool) (sb.Story, error) {
+	for _, st := range f.stories[spaceID] {
+		if st.ID == storyID {
+			if err := f.write(st.FullSlug); err != nil {
+				return sb.Story{}, err
+			}
+			return st, nil
+		}
+	}
+	return sb.Story{}, errors.New("not found")
+}
+
+// ListStories honours UpdatedAfter for RFC 3339 updated_at values; other
+// values (like "t1") are always listed.
+func (f *fakeAPI) ListStories(_ context.Context, opt sb.ListStoriesOpts) ([]sb.Story, error) {
+	f.lists = append(f.lists, opt)
+	var out []sb.Story
+	for _, st := range f.stories[opt.SpaceID] {
+		if ts, err := time.Parse(time.RFC3339, st.UpdatedAt); err == nil && !opt.UpdatedAfter.IsZero() && !ts.After(opt.UpdatedAfter) {
+			continue
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+func (f *fakeAPI) ListTags(context.Context, int) ([]sb.Tag, error) { return nil, nil }
+
+func (f *fakeAPI) CreateTag(_ context.Context, _ int, name string) (sb.Tag, error) {
+	return sb.Tag{Name: name}, nil
+}
+
+func (f *fakeAPI) ListInternalTags(context.Context, int) ([]sb.InternalTag, error) { return nil, nil }
+
+func (f *fakeAPI) CreateInternalTag(_ context.Context, _ int, name, objectType string) (sb.InternalTag, error) {
+	return sb.InternalTag{Name: name, ObjectType: objectType}, nil
+}
+
+func watchFixture(t *testing.T) (*fakeAPI, Manifest, string) {
+	t.Helper()
+	api := newFakeAPI()
+	blog := 1
+	api.addStory(1, sb.Story{ID: 1, Name: "Blog", Slug: "blog", FullSlug: "blog", IsFolder: true, UpdatedAt: "t1"})
+	api.addStory(1, sb.Story{ID: 2, Name: "Post", Slug: "post", FullSlug: "blog/post", FolderID: &blog, UpdatedAt: "t1"})
+	api.addStory(1, sb.Story{ID: 3, Name: "Draft", Slug: "draft-x", FullSlug: "blog/draft-x", FolderID: &blog, UpdatedAt: "t1"})
+	api.addStory(1, sb.Story{ID: 4, Name: "About", Slug: "about", FullSlug: "about", UpdatedAt: "t1"})
+	m := Manifest{Source: 1, Target: 2, Include: []string{"blog/**"}, Exclude: []string{"**/draft-*"}}
+	return api, m, filepath.Join(t.TempDir(), "state.json")
+}
+
+func newRunner(t *testing.T, api *fakeAPI, m Manifest, path string) *Runner {
+	t.Helper()
+	st, err := LoadState(path, 1, 2)
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	return New(api, m, sb.Space{ID: 1, Name: "src"}, sb.Space{ID: 2, Name: "tgt"}, st)
+}
+
+func TestRunOnceSyncsChangedStoriesInScope(t *testing.T) {
+	api, m, path := watchFixture(t)
+	r := newRunner(t, api, m, path)
+
+	rep, err := r.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if len(rep.Entries) != 2 || rep.Entries[0].Slug != "blog" || rep.Entries[1].Slug != "blog/post" {
+		t.Fatalf("entries = %+v", rep.Entries)
+	}
+	if rep.Summary.Success != 2 || api.writes["about"] != 0 || api.writes["blog/draft-x"] != 0 {
+		t.Fatalf("summary = %+v, writes = %v", rep.Summary, api.writes)
+	}
+
+	// nothing changed: no entries, no writes
+	rep, err = r.RunOnce(context.Background())
+	if err != nil || len(rep.Entries) != 0 {
+		t.Fatalf("second cycle: %v, entries = %+v", err, rep.Entries)
+	}
+
+	// a restarted watch resumes from the state file and picks up the edit only
+	api.touch(1, "blog/post", "t2")
+	r = newRunner(t, api, m, path)
+	rep, err = r.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("third cycle: %v", err)
+	}
+	if len(rep.Entries) != 1 || rep.Entries[0].Slug != "blog/post" || rep.Entries[0].Operation != "update" {
+		t.Fatalf("entries = %+v", rep.Entries)
+	}
+	if api.writes["blog/post"] != 2 || api.writes["blog"] != 1 {
+		t.Fatalf("writes = %v", api.writes)
+	}
+}
+
+func TestRunOnceRetriesFailuresNextCycle(t *testing.T) {
+	api, m, path := watchFixture(t)
+	api.failSlugs["blog/post"] = true
+	r := newRunner(t, api, m, path)
+
+	rep, err := r.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if rep.Summary.Failure != 1 {
+		t.Fatalf("summary = %+v", rep.Summary)
+	}
+	if r.state.Changed(1, "t1") || !r.state.Changed(2, "t1") {
+		t.Fatalf("seen = %v", r.state.Seen)
+	}
+
+	rep, err = r.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if len(rep.Entries) != 1 || rep.Entries[0].Slug != "blog/post" || rep.Entries[0].Status != "success" {
+		t.Fatalf("entries = %+v", rep.Entries)
+	}
+	// the cycle after a failure rescans in full
+	if last := api.lists[len(api.lists)-1]; !last.UpdatedAfter.IsZero() {
+		t.Fatalf("retry cycle listed %+v", last)
+	}
+}
+
+func TestRunOnceFetchesOnlyUpdatedStories(t *testing.T) {
+	api, m, path := watchFixture(t)
+	old := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	for _, slug := range []string{"blog", "blog/post", "blog/draft-x", "about"} {
+		api.touch(1, slug, old)
+	}
+	r := newRunner(t, api, m, path)
+
+	if _, err := r.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if len(api.lists) != 2 || !api.lists[0].UpdatedAfter.IsZero() || !api.lists[1].UpdatedAfter.IsZero() {
+		t.Fatalf("first cycle listed %+v", api.lists)
+	}
+
+	api.lists = nil
+	api.touch(1, "blog/post", time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	rep, err := r.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if len(api.lists) != 2 || api.lists[0].UpdatedAfter.IsZero() || api.lists[1].UpdatedAfter.IsZero() {
+		t.Fatalf("second cycle listed %+v", api.lists)
+	}
+	if len(rep.Entries) != 1 || rep.Entries[0].Slug != "blog/post" || rep.Entries[0].Operation != "update" {
+		t.Fatalf("entries = %+v", rep.Entries)
+	}
+	if api.writes["blog/post"] != 2 || api.writes["blog"] != 1 {
+		t.Fatalf("writes = %v", api.writes)
+	}
+}
+
+func TestRunStopsWhenCancelled(t *testing.T) {
+	api, m, path := watchFixture(t)
+	r := newRunner(t, api, m, path)
+	ctx, cancel := context.WithCancel(context.Background())
+	cycles := 0
+	err := r.Run(ctx, 1, func(*report.Report, error) {
+		cycles++
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) || cycles != 1 {
+		t.Fatalf("Run = %v after %d cycles", err, cycles)
+	}
+	if r.state.LastRun.IsZero() {
+		t.Fatal("state not finished")
+	}
+}
+
+func TestLoadStateRejectsOtherSpaces(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	st, err := LoadState(path, 1, 2)
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if err := st.MarkSynced(7, "t1"); err != nil {
+		t.Fatalf("MarkSynced: %v", err)
+	}
+	if _, err := LoadState(path, 1, 3); err == nil {
+		t.Fatal("expected error for another space pair")
+	}
+}
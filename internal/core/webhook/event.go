@@ -0,0 +1,131 @@
+// Package webhook turns Storyblok webhooks into targeted syncs: a story,
+// component or datasource change in the source space is verified, checked
+// against the manifest scope, debounced and synced on its own.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"storyblok-sync/internal/report"
+)
+
+// SignatureHeader carries the hex HMAC-SHA1 of the request body keyed with
+// the webhook secret configured in Storyblok.
+const SignatureHeader = "Webhook-Signature"
+
+// Item kinds a webhook can refer to
+const (
+	KindStory      = report.KindStory
+	KindComponent  = report.KindComponent
+	KindDatasource = report.KindDatasource
+)
+
+// Actions with special handling; other story actions sync the story.
+const (
+	ActionPublished   = "published"
+	ActionUnpublished = "unpublished"
+	ActionDeleted     = "deleted"
+	ActionMoved       = "moved"
+)
+
+// Event is one Storyblok webhook. Story events carry story_id and
+// full_slug, datasource events datasource_slug and component events
+// component_name.
+type Event struct {
+	Kind           string `json:"-"`
+	Action         string `json:"action"`
+	Text           string `json:"text,omitempty"`
+	SpaceID        int    `json:"space_id"`
+	StoryID        int    `json:"story_id,omitempty"`
+	FullSlug       string `json:"full_slug,omitempty"`
+	ComponentName  string `json:"component_name,omitempty"`
+	DatasourceSlug string `json:"datasource_slug,omitempty"`
+}
+
+// Parse decodes a webhook body and determines the item it refers to.
+func Parse(body []byte) (Event, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return Event{}, fmt.Errorf("invalid payload: %w", err)
+	}
+	ev := Event{
+		Action:         str(raw["action"]),
+		Text:           str(raw["text"]),
+		SpaceID:        num(raw["space_id"]),
+		StoryID:        num(raw["story_id"]),
+		FullSlug:       strings.Trim(str(raw["full_slug"]), "/"),
+		ComponentName:  str(raw["component_name"]),
+		DatasourceSlug: str(raw["datasource_slug"]),
+	}
+	switch {
+	case ev.StoryID > 0:
+		ev.Kind = KindStory
+	case ev.ComponentName != "":
+		ev.Kind = KindComponent
+	case ev.DatasourceSlug != "":
+		ev.Kind = KindDatasource
+	default:
+		return Event{}, fmt.Errorf("payload without story_id, component_name or datasource_slug")
+	}
+	if ev.SpaceID <= 0 {
+		return Event{}, fmt.Errorf("payload without space_id")
+	}
+	return ev, nil
+}
+
+// Key identifies the item of an event; events with the same key are debounced.
+func (e Event) Key() string {
+	return e.Kind + ":" + e.Name()
+}
+
+// Name returns the story ID, component name or datasource slug.
+func (e Event) Name() string {
+	switch e.Kind {
+	case KindComponent:
+		return e.ComponentName
+	case KindDatasource:
+		return e.DatasourceSlug
+	}
+	return strconv.Itoa(e.StoryID)
+}
+
+// Sign returns the signature Storyblok sends for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature header value against body.
+func Verify(secret string, body []byte, signature string) bool {
+	want := Sign(secret, body)
+	return hmac.Equal([]byte(want), []byte(strings.ToLower(strings.TrimSpace(signature))))
+}
+
+// str and num read payload values that Storyblok sends as strings or numbers.
+func str(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	}
+	return ""
+}
+
+func num(v interface{}) int {
+	switch t := v.(type) {
+	case float64:
+		return int(t)
+	case string:
+		n, _ := strconv.Atoi(t)
+		return n
+	}
+	return 0
+}
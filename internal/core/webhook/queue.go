@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Queue errors
+var (
+	ErrQueueFull   = errors.New("queue full")
+	ErrQueueClosed = errors.New("queue closed")
+)
+
+// Queue debounces events per item: an event is handed to the worker only
+// after no newer event for the same item arrived for the debounce delay, so
+// a burst of saves results in one sync of the latest state.
+type Queue struct {
+	delay time.Duration
+	max   int
+
+	mu      sync.Mutex
+	pending map[string]*pendingEvent
+	ready   chan Event
+	closed  bool
+}
+
+type pendingEvent struct {
+	ev    Event
+	timer *time.Timer
+}
+
+// NewQueue creates a queue holding at most max items (pending or ready).
+func NewQueue(delay time.Duration, max int) *Queue {
+	if max <= 0 {
+		max = 1
+	}
+	return &Queue{delay: delay, max: max, pending: make(map[string]*pendingEvent), ready: make(chan Event, max)}
+}
+
+// Add schedules an event, replacing a pending event of the same item.
+func (q *Queue) Add(ev Event) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	key := ev.Key()
+	if p, ok := q.pending[key]; ok {
+		p.ev = ev
+		p.timer.Reset(q.delay)
+		return nil
+	}
+	if len(q.pending)+len(q.ready) >= q.max {
+		return ErrQueueFull
+	}
+	p := &pendingEvent{ev: ev}
+	p.timer = time.AfterFunc(q.delay, func() { q.fire(key, p) })
+	q.pending[key] = p
+	return nil
+}
+
+// fire moves a pending event whose delay elapsed to the worker. The size
+// check in Add guarantees the send does not block.
+func (q *Queue) fire(key string, p *pendingEvent) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || q.pending[key] != p {
+		return
+	}
+	delete(q.pending, key)
+	q.ready <- p.ev
+}
+
+// Events returns the debounced events; the channel is closed by Close.
+func (q *Queue) Events() <-chan Event {
+	return q.ready
+}
+
+// Len returns the number of pending and ready events.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending) + len(q.ready)
+}
+
+// Close stops accepting events and hands all pending events to the worker
+// without waiting for their delay, so a shutdown drains the queue.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	for key, p := range q.pending {
+		// a timer that already fired waits for the lock and then sees closed
+		p.timer.Stop()
+		q.ready <- p.ev
+		delete(q.pending, key)
+	}
+	close(q.ready)
+}
@@ -0,0 +1,218 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"storyblok-sync/internal/report"
+)
+
+// maxBody limits webhook payloads; Storyblok sends a few hundred bytes.
+const maxBody = 1 << 20
+
+// DefaultDrainTimeout bounds how long a shutdown waits for the queue to drain.
+const DefaultDrainTimeout = time.Minute
+
+// Health is the /healthz response.
+type Health struct {
+	Status    string     `json:"status"`
+	Source    int        `json:"source"`
+	Target    int        `json:"target"`
+	Queued    int        `json:"queued"`
+	Received  int        `json:"received"`
+	Ignored   int        `json:"ignored"`
+	Synced    int        `json:"synced"`
+	Failed    int        `json:"failed"`
+	LastSync  *time.Time `json:"last_sync,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+// Server accepts webhooks on /webhook, queues the events in scope and runs
+// them one at a time in Work.
+type Server struct {
+	secret   string
+	queue    *Queue
+	syncer   *Syncer
+	progress func(Event, report.Entry)
+	drain    time.Duration
+
+	mu     sync.Mutex
+	health Health
+}
+
+// NewServer creates a server verifying signatures with secret ("" accepts
+// unsigned webhooks).
+func NewServer(secret string, q *Queue, s *Syncer) *Server {
+	return &Server{
+		secret: secret,
+		queue:  q,
+		syncer: s,
+		drain:  DefaultDrainTimeout,
+		health: Health{Status: "ok", Source: s.source.ID, Target: s.target.ID},
+	}
+}
+
+// OnEntry registers a callback invoked for every synced item.
+func (s *Server) OnEntry(fn func(Event, report.Entry)) {
+	s.progress = fn
+}
+
+// SetDrainTimeout bounds how long Serve waits for queued items after ctx is
+// cancelled; 0 waits until the queue is empty.
+func (s *Server) SetDrainTimeout(d time.Duration) {
+	s.drain = d
+}
+
+// Handler returns the HTTP handler with /webhook and /healthz.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/webhook", s.handleWebhook)
+	mux.HandleFunc("/healthz", s.handleHealth)
+	return mux
+}
+
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		reply(w, http.StatusMethodNotAllowed, "error", "method not allowed")
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+	if err != nil {
+		reply(w, http.StatusRequestEntityTooLarge, "error", err.Error())
+		return
+	}
+	if s.secret != "" && !Verify(s.secret, body, r.Header.Get(SignatureHeader)) {
+		reply(w, http.StatusUnauthorized, "error", "invalid signature")
+		return
+	}
+	ev, err := Parse(body)
+	if err != nil {
+		reply(w, http.StatusBadRequest, "error", err.Error())
+		return
+	}
+	s.count(func(h *Health) { h.Received++ })
+	if ev.SpaceID != s.syncer.source.ID {
+		s.count(func(h *Health) { h.Ignored++ })
+		reply(w, http.StatusForbidden, "error", fmt.Sprintf("space %d is not the manifest source", ev.SpaceID))
+		return
+	}
+	if !s.syncer.InScope(ev) {
+		s.count(func(h *Health) { h.Ignored++ })
+		reply(w, http.StatusOK, "ignored", ev.Key()+" is outside the manifest scope")
+		return
+	}
+	if err := s.queue.Add(ev); err != nil {
+		reply(w, http.StatusServiceUnavailable, "error", err.Error())
+		return
+	}
+	reply(w, http.StatusAccepted, "queued", ev.Key())
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	h := s.Health()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(h)
+}
+
+// Health returns the current counters.
+func (s *Server) Health() Health {
+	s.mu.Lock()
+	h := s.health
+	s.mu.Unlock()
+	h.Queued = s.queue.Len()
+	return h
+}
+
+// Work syncs queued events until the queue is closed and drained or ctx is
+// cancelled; a cancelled ctx also stops the running sync.
+func (s *Server) Work(ctx context.Context) {
+	for ctx.Err() == nil {
+		ev, ok := <-s.queue.Events()
+		if !ok {
+			return
+		}
+		for _, e := range s.syncer.Sync(ctx, ev) {
+			now := time.Now()
+			s.count(func(h *Health) {
+				h.LastSync = &now
+				if e.Status == "failure" {
+					h.Failed++
+					h.LastError = e.Slug + ": " + e.Error
+				} else {
+					h.Synced++
+				}
+			})
+			if s.progress != nil {
+				s.progress(ev, e)
+			}
+		}
+	}
+}
+
+func (s *Server) count(fn func(*Health)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.health)
+}
+
+func reply(w http.ResponseWriter, code int, status, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	key := "message"
+	if status == "error" {
+		key = "error"
+	}
+	_ = json.NewEncoder(w).Encode(map[string]string{"status": status, key: msg})
+}
+
+// Serve listens on addr until ctx is cancelled, then stops accepting
+// webhooks and waits for the queue to drain. After the drain timeout the
+// running sync is cancelled and the remaining items are dropped.
+func (s *Server) Serve(ctx context.Context, addr string) error {
+	srv := &http.Server{Addr: addr, Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
+	work, stop := context.WithCancel(context.Background())
+	defer stop()
+	done := make(chan struct{})
+	go func() {
+		s.Work(work)
+		close(done)
+	}()
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err = srv.Shutdown(shutdown)
+		cancel()
+	}
+	s.queue.Close()
+	if errors.Is(err, http.ErrServerClosed) {
+		err = nil
+	}
+	var timeout <-chan time.Time
+	if s.drain > 0 {
+		t := time.NewTimer(s.drain)
+		defer t.Stop()
+		timeout = t.C
+	}
+	select {
+	case <-done:
+	case <-timeout:
+		stop()
+		<-done
+		if err == nil {
+			err = fmt.Errorf("drain timeout after %s, %d queued items dropped", s.drain, s.queue.Len())
+		}
+	}
+	return err
+}
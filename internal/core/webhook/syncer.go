@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"storyblok-sync/internal/core/clone"
+	synccore "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/core/tagsync"
+	"storyblok-sync/internal/core/transform"
+	"storyblok-sync/internal/core/watch"
+	"storyblok-sync/internal/report"
+	"storyblok-sync/internal/sb"
+)
+
+// Syncer runs the sync of a single item named by a webhook event.
+type Syncer struct {
+	api      clone.API
+	manifest watch.Manifest
+	source   sb.Space
+	target   sb.Space
+	limiter  *synccore.SpaceLimiter
+
+	transforms *transform.Pipeline
+}
+
+// NewSyncer creates a syncer from source to target restricted to the manifest scope.
+func NewSyncer(api clone.API, m watch.Manifest, source, target sb.Space) *Syncer {
+	return &Syncer{
+		api:      api,
+		manifest: m,
+		source:   source,
+		target:   target,
+		limiter:  synccore.NewFanOutLimiter(source, target),
+	}
+}
+
+// SetTransforms rewrites story payloads with the pipeline before each write.
+func (s *Syncer) SetTransforms(p *transform.Pipeline) {
+	s.transforms = p
+}
+
+// InScope reports whether the manifest covers the item of an event. Story
+// events without a full slug are checked again once the story is loaded.
+func (s *Syncer) InScope(ev Event) bool {
+	switch ev.Kind {
+	case KindComponent:
+		return s.manifest.ComponentInScope(ev.ComponentName)
+	case KindDatasource:
+		return s.manifest.DatasourceInScope(ev.DatasourceSlug)
+	}
+	return ev.FullSlug == "" || s.manifest.InScope(ev.FullSlug)
+}
+
+// Sync runs the event's item and returns one report entry per written item.
+func (s *Syncer) Sync(ctx context.Context, ev Event) []report.Entry {
+	switch ev.Kind {
+	case KindComponent, KindDatasource:
+		return s.syncSchema(ctx, ev)
+	}
+	if ev.Action == ActionDeleted {
+		return []report.Entry{s.unpublishDeleted(ctx, ev)}
+	}
+	return s.syncStory(ctx, ev)
+}
+
+// syncSchema syncs one component or datasource with the clone steps.
+func (s *Syncer) syncSchema(ctx context.Context, ev Event) []report.Entry {
+	start := time.Now()
+	cp, err := clone.LoadCheckpoint("", s.source.ID, s.target.ID)
+	if err != nil {
+		return []report.Entry{failure(ev.Kind, ev.Name(), "", start, err)}
+	}
+	var entries []report.Entry
+	cl := clone.New(s.api, s.source, s.target, cp)
+	cl.OnEntry(func(e clone.Entry) {
+		entries = append(entries, report.Entry{Kind: e.Kind, Slug: e.Name, Status: e.Status, Operation: e.Operation, Error: e.Error, Warning: e.Warning, Duration: e.DurationMs})
+	})
+	if ev.Kind == KindComponent {
+		err = cl.SyncComponent(ctx, ev.ComponentName)
+	} else {
+		err = cl.SyncDatasource(ctx, ev.DatasourceSlug)
+	}
+	if err != nil && len(entries) == 0 {
+		entries = append(entries, failure(ev.Kind, ev.Name(), "", start, err))
+	}
+	return entries
+}
+
+// syncStory loads the story, creates missing parent folders and syncs it
+// with its publish state.
+func (s *Syncer) syncStory(ctx context.Context, ev Event) []report.Entry {
+	start := time.Now()
+	story, err := s.api.GetStoryWithContent(ctx, s.source.ID, ev.StoryID)
+	if err != nil {
+		return []report.Entry{failure(KindStory, slugOrID(ev), "", start, fmt.Errorf("load story %d: %w", ev.StoryID, err))}
+	}
+	kind := KindStory
+	if story.IsFolder {
+		kind = report.KindFolder
+	}
+	if !s.manifest.InScope(story.FullSlug) {
+		return []report.Entry{{Kind: kind, Slug: story.FullSlug, Status: "success", Operation: synccore.OperationSkip, Warning: "outside manifest scope", SourceID: story.ID}}
+	}
+
+	// EnsureFolderPath creates the missing folders above the last segment
+	fb := synccore.NewFolderPathBuilder(s.api, nil, nil, s.source.ID, s.target.ID, false)
+	fb.SetLimiter(s.limiter)
+	folders, err := fb.EnsureFolderPathContext(ctx, story.FullSlug)
+	var entries []report.Entry
+	for _, f := range folders {
+		entries = append(entries, report.Entry{Kind: report.KindFolder, Slug: f.FullSlug, Status: "success", Operation: synccore.OperationCreate, TargetIDAfter: f.ID})
+	}
+	if err != nil {
+		return append(entries, failure(kind, story.FullSlug, "", start, fmt.Errorf("parent folders: %w", err)))
+	}
+
+	index := make(map[string]sb.Story, 1)
+	before, existed := sb.Story{}, false
+	if found, err := s.api.GetStoriesBySlug(ctx, s.target.ID, story.FullSlug); err == nil && len(found) > 0 {
+		before, existed = found[0], true
+		index[story.FullSlug] = before
+	}
+	so := synccore.NewSyncOrchestrator(s.api, nil, &s.source, &s.target, index)
+	so.SetSpaceLimiter(s.limiter)
+	so.SetTagReconciler(tagsync.NewReconciler(s.api, s.api, s.target.ID))
+	so.SetTransforms(s.transforms)
+	var res *synccore.SyncItemResult
+	if story.IsFolder {
+		res, err = so.SyncFolderDetailed(story)
+	} else {
+		res, err = so.SyncStoryDetailed(story)
+	}
+	op := synccore.OperationCreate
+	if existed {
+		op = synccore.OperationUpdate
+	}
+	e := report.Entry{Kind: kind, Slug: story.FullSlug, Status: "success", Operation: op, Duration: time.Since(start).Milliseconds(), SourceID: story.ID, TargetIDBefore: before.ID}
+	switch {
+	case err != nil:
+		e.Status, e.Error = "failure", err.Error()
+	case res != nil:
+		e.Operation, e.Retries = res.Operation, res.RetryTotal
+		if res.Warning != "" {
+			e.Status, e.Warning = "warning", res.Warning
+		}
+		if res.TargetStory != nil {
+			e.TargetIDAfter = res.TargetStory.ID
+			// An unpublished source story takes back the target publication
+			if !story.IsFolder && !story.Published && existed && before.Published {
+				if uerr := s.api.UnpublishStory(ctx, s.target.ID, res.TargetStory.ID); uerr != nil {
+					e.Status, e.Warning = "warning", "unpublish: "+uerr.Error()
+				}
+			}
+		}
+	}
+	if err == nil && ev.Action == ActionMoved && e.Warning == "" {
+		e.Status, e.Warning = "warning", "moved: the copy at the old slug is left in the target"
+	}
+	return append(entries, e)
+}
+
+// unpublishDeleted unpublishes the target copy of a story deleted in the
+// source. Content is never deleted from the target.
+func (s *Syncer) unpublishDeleted(ctx context.Context, ev Event) report.Entry {
+	start := time.Now()
+	if ev.FullSlug == "" {
+		return failure(KindStory, slugOrID(ev), "unpublish", start, fmt.Errorf("deleted story %d without full_slug", ev.StoryID))
+	}
+	found, err := s.api.GetStoriesBySlug(ctx, s.target.ID, ev.FullSlug)
+	if err != nil {
+		return failure(KindStory, ev.FullSlug, "unpublish", start, err)
+	}
+	if len(found) == 0 {
+		return report.Entry{Kind: KindStory, Slug: ev.FullSlug, Status: "success", Operation: synccore.OperationSkip, Warning: "not in target", SourceID: ev.StoryID}
+	}
+	tgt := found[0]
+	e := report.Entry{Kind: KindStory, Slug: ev.FullSlug, Status: "success", Operation: "unpublish", SourceID: ev.StoryID, TargetIDBefore: tgt.ID, TargetIDAfter: tgt.ID}
+	_ = s.limiter.WaitWrite(ctx, s.target.ID)
+	if err := s.api.UnpublishStory(ctx, s.target.ID, tgt.ID); err != nil {
+		e.Status, e.Error = "failure", err.Error()
+	}
+	e.Duration = time.Since(start).Milliseconds()
+	return e
+}
+
+func failure(kind, name, op string, start time.Time, err error) report.Entry {
+	return report.Entry{Kind: kind, Slug: name, Status: "failure", Operation: op, Error: err.Error(), Duration: time.Since(start).Milliseconds()}
+}
+
+func slugOrID(ev Event) string {
+	if ev.FullSlug != "" {
+		return ev.FullSlug
+	}
+	return fmt.Sprintf("story %d", ev.StoryID)
+}
@@ -0,0 +1,305 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"storyblok-sync/internal/core/clone"
+	"storyblok-sync/internal/core/watch"
+	"storyblok-sync/internal/sb"
+)
+
+// fakeAPI implements the story part of clone.API over in-memory spaces;
+// other methods are not used by story events.
+type fakeAPI struct {
+	clone.API
+	stories     map[int][]sb.Story
+	raw         map[int]map[string]interface{}
+	nextID      int
+	unpublished []int
+	block       bool // story loads wait for the ctx to end
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{stories: map[int][]sb.Story{}, raw: map[int]map[string]interface{}{}, nextID: 1000}
+}
+
+func (f *fakeAPI) addStory(spaceID int, st sb.Story) {
+	f.stories[spaceID] = append(f.stories[spaceID], st)
+	f.raw[st.ID] = map[string]interface{}{
+		"id": st.ID, "uuid": st.UUID, "name": st.Name, "slug": st.Slug, "full_slug": st.FullSlug,
+		"is_folder": st.IsFolder, "content": map[string]interface{}{"component": "page"},
+	}
+}
+
+func (f *fakeAPI) GetStoriesBySlug(_ context.Context, spaceID int, slug string) ([]sb.Story, error) {
+	for _, st := range f.stories[spaceID] {
+		if st.FullSlug == slug {
+			return []sb.Story{st}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeAPI) GetStoryWithContent(ctx context.Context, spaceID, storyID int) (sb.Story, error) {
+	if f.block {
+		<-ctx.Done()
+		return sb.Story{}, ctx.Err()
+	}
+	for _, st := range f.stories[spaceID] {
+		if st.ID == storyID {
+			return st, nil
+		}
+	}
+	return sb.Story{}, errors.New("not found")
+}
+
+func (f *fakeAPI) UpdateStoryUUID(context.Context, int, int, string) error { return nil }
+
+func (f *fakeAPI) GetStoryRaw(_ context.Context, _ int, storyID int) (map[string]interface{}, error) {
+	raw, ok := f.raw[storyID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	out := make(map[string]interface{}, len(raw))
+	for k, v := range raw {
+		out[k] = v
+	}
+	return out, nil
+}
+
+func (f *fakeAPI) CreateStoryRawWithPublish(_ context.Context, spaceID int, story map[string]interface{}, publish bool) (sb.Story, error) {
+	f.nextID++
+	st := sb.Story{ID: f.nextID, Slug: story["slug"].(string), Published: publish}
+	st.FullSlug, _ = story["full_slug"].(string)
+	st.IsFolder, _ = story["is_folder"].(bool)
+	f.stories[spaceID] = append(f.stories[spaceID], st)
+	return st, nil
+}
+
+func (f *fakeAPI) UpdateStoryRawWithPublish(_ context.Context, spaceID int, storyID int, _ map[string]interface{}, publish bool) (sb.Story, error) {
+	for i, st := range f.stories[spaceID] {
+		if st.ID == storyID {
+			f.stories[spaceID][i].Published = publish
+			return f.stories[spaceID][i], nil
+		}
+	}
+	return sb.Story{}, errors.New("not found")
+}
+
+func (f *fakeAPI) UnpublishStory(_ context.Context, _ int, storyID int) error {
+	f.unpublished = append(f.unpublished, storyID)
+	return nil
+}
+
+func (f *fakeAPI) ListTags(context.Context, int) ([]sb.Tag, error) { return nil, nil }
+
+func (f *fakeAPI) ListInternalTags(context.Context, int) ([]sb.InternalTag, error) { return nil, nil }
+
+func testSyncer(api *fakeAPI) *Syncer {
+	m := watch.Manifest{Source: 1, Target: 2, Include: []string{"blog"}, Components: []string{"hero-*"}}
+	return NewSyncer(api, m, sb.Space{ID: 1, Name: "src"}, sb.Space{ID: 2, Name: "tgt"})
+}
+
+func TestParse(t *testing.T) {
+	ev, err := Parse([]byte(`{"text":"published","action":"published","space_id":1,"story_id":"42","full_slug":"blog/post"}`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if ev.Kind != KindStory || ev.StoryID != 42 || ev.FullSlug != "blog/post" || ev.Key() != "story:42" {
+		t.Fatalf("event = %+v", ev)
+	}
+	ev, err = Parse([]byte(`{"action":"entries_updated","space_id":1,"datasource_slug":"colors"}`))
+	if err != nil || ev.Kind != KindDatasource || ev.Key() != "datasource:colors" {
+		t.Fatalf("datasource event = %+v, %v", ev, err)
+	}
+	for _, body := range []string{`{"action":"published","space_id":1}`, `{"story_id":1}`, `not json`} {
+		if _, err := Parse([]byte(body)); err == nil {
+			t.Errorf("Parse(%s): expected error", body)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"story_id":1}`)
+	sig := Sign("s3cret", body)
+	if !Verify("s3cret", body, sig) || !Verify("s3cret", body, " "+sig+" ") {
+		t.Fatal("valid signature rejected")
+	}
+	if Verify("other", body, sig) || Verify("s3cret", []byte(`{"story_id":2}`), sig) || Verify("s3cret", body, "") {
+		t.Fatal("invalid signature accepted")
+	}
+}
+
+func TestQueueDebouncesPerItem(t *testing.T) {
+	q := NewQueue(20*time.Millisecond, 2)
+	a := Event{Kind: KindStory, StoryID: 1, Action: ActionPublished}
+	for i := 0; i < 3; i++ {
+		if err := q.Add(a); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	a.Action = ActionUnpublished
+	_ = q.Add(a)
+	_ = q.Add(Event{Kind: KindStory, StoryID: 2})
+	if err := q.Add(Event{Kind: KindStory, StoryID: 3}); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("Add over capacity = %v", err)
+	}
+	got := map[int]Event{}
+	for i := 0; i < 2; i++ {
+		ev := <-q.Events()
+		got[ev.StoryID] = ev
+	}
+	if len(got) != 2 || got[1].Action != ActionUnpublished {
+		t.Fatalf("events = %+v, want one per story with the latest of story 1", got)
+	}
+	if q.Len() != 0 {
+		t.Fatalf("Len = %d after delivery", q.Len())
+	}
+}
+
+func TestQueueCloseDrainsPending(t *testing.T) {
+	q := NewQueue(time.Hour, 10)
+	_ = q.Add(Event{Kind: KindStory, StoryID: 1})
+	q.Close()
+	if err := q.Add(Event{Kind: KindStory, StoryID: 2}); !errors.Is(err, ErrQueueClosed) {
+		t.Fatalf("Add after Close = %v", err)
+	}
+	var ids []int
+	for ev := range q.Events() {
+		ids = append(ids, ev.StoryID)
+	}
+	if len(ids) != 1 || ids[0] != 1 {
+		t.Fatalf("drained %v", ids)
+	}
+}
+
+func TestServerWebhook(t *testing.T) {
+	q := NewQueue(time.Hour, 10)
+	srv := NewServer("s3cret", q, testSyncer(newFakeAPI()))
+	h := srv.Handler()
+	post := func(body, sig string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(body))
+		req.Header.Set(SignatureHeader, sig)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	signed := func(body string) *httptest.ResponseRecorder { return post(body, Sign("s3cret", []byte(body))) }
+
+	cases := []struct {
+		name string
+		rec  *httptest.ResponseRecorder
+		code int
+	}{
+		{"unsigned", post(`{"space_id":1,"story_id":5,"full_slug":"blog/a"}`, ""), http.StatusUnauthorized},
+		{"invalid", signed(`{"space_id":1}`), http.StatusBadRequest},
+		{"other space", signed(`{"space_id":9,"story_id":5,"full_slug":"blog/a"}`), http.StatusForbidden},
+		{"story out of scope", signed(`{"space_id":1,"story_id":6,"full_slug":"shop/a"}`), http.StatusOK},
+		{"component out of scope", signed(`{"space_id":1,"component_name":"teaser"}`), http.StatusOK},
+		{"story", signed(`{"action":"published","space_id":1,"story_id":5,"full_slug":"blog/a"}`), http.StatusAccepted},
+		{"component", signed(`{"space_id":1,"component_name":"hero-big"}`), http.StatusAccepted},
+	}
+	for _, c := range cases {
+		if c.rec.Code != c.code {
+			t.Errorf("%s: status %d, want %d (%s)", c.name, c.rec.Code, c.code, c.rec.Body.String())
+		}
+	}
+	if q.Len() != 2 {
+		t.Fatalf("queued %d events, want 2", q.Len())
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	var health Health
+	if err := json.Unmarshal(rec.Body.Bytes(), &health); err != nil {
+		t.Fatalf("healthz: %v", err)
+	}
+	if rec.Code != http.StatusOK || health.Status != "ok" || health.Received != 5 || health.Ignored != 3 || health.Queued != 2 {
+		t.Fatalf("health = %d %+v", rec.Code, health)
+	}
+}
+
+func TestSyncerSyncsStoryWithParents(t *testing.T) {
+	api := newFakeAPI()
+	blog := 1
+	api.addStory(1, sb.Story{ID: 1, Name: "Blog", Slug: "blog", FullSlug: "blog", IsFolder: true})
+	api.addStory(1, sb.Story{ID: 2, Name: "Post", Slug: "post", FullSlug: "blog/post", FolderID: &blog, Published: true})
+	api.addStory(1, sb.Story{ID: 3, Name: "Shop", Slug: "shop", FullSlug: "shop"})
+	s := testSyncer(api)
+
+	entries := s.Sync(context.Background(), Event{Kind: KindStory, Action: ActionPublished, StoryID: 2})
+	if len(entries) != 2 || entries[0].Slug != "blog" || entries[0].Kind != "folder" || entries[1].Slug != "blog/post" {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if e := entries[1]; e.Status != "success" || e.Operation != "create" || e.TargetIDAfter == 0 {
+		t.Fatalf("story entry = %+v", e)
+	}
+	if len(api.stories[2]) != 2 {
+		t.Fatalf("target = %+v", api.stories[2])
+	}
+
+	// a story moved out of scope is skipped after loading it
+	entries = s.Sync(context.Background(), Event{Kind: KindStory, Action: ActionMoved, StoryID: 3})
+	if len(entries) != 1 || entries[0].Operation != "skip" || len(api.stories[2]) != 2 {
+		t.Fatalf("out of scope entries = %+v", entries)
+	}
+
+	// deletions unpublish the target copy
+	target := api.stories[2][1]
+	entries = s.Sync(context.Background(), Event{Kind: KindStory, Action: ActionDeleted, StoryID: 2, FullSlug: "blog/post"})
+	if len(entries) != 1 || entries[0].Operation != "unpublish" || len(api.unpublished) != 1 || api.unpublished[0] != target.ID {
+		t.Fatalf("delete entries = %+v, unpublished = %v", entries, api.unpublished)
+	}
+}
+
+func TestSyncerFolderCreationHonoursContext(t *testing.T) {
+	api := newFakeAPI()
+	blog := 1
+	api.addStory(1, sb.Story{ID: 1, Name: "Blog", Slug: "blog", FullSlug: "blog", IsFolder: true})
+	api.addStory(1, sb.Story{ID: 2, Name: "Post", Slug: "post", FullSlug: "blog/post", FolderID: &blog})
+	s := testSyncer(api)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	entries := s.Sync(ctx, Event{Kind: KindStory, Action: ActionPublished, StoryID: 2})
+	if len(entries) != 1 || entries[0].Status != "failure" || len(api.stories[2]) != 0 {
+		t.Fatalf("entries = %+v, target = %+v", entries, api.stories[2])
+	}
+}
+
+func TestServeStopsDrainingAfterTimeout(t *testing.T) {
+	api := newFakeAPI()
+	api.block = true
+	q := NewQueue(0, 10)
+	srv := NewServer("", q, testSyncer(api))
+	srv.SetDrainTimeout(50 * time.Millisecond)
+	for id := 1; id <= 2; id++ {
+		if err := q.Add(Event{Kind: KindStory, Action: ActionPublished, StoryID: id}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, "127.0.0.1:0") }()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "drain timeout") {
+			t.Fatalf("Serve = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not stop after the drain timeout")
+	}
+	if h := srv.Health(); h.Failed != 1 || h.Queued != 1 {
+		t.Fatalf("health = %+v", h)
+	}
+}
@@ -0,0 +1,115 @@
+// Package audit keeps an append-only record of every write sbsync sends to
+// Storyblok. The sb client feeds one Record per POST/PUT/DELETE into the
+// process-wide sink configured at startup; unlike the debug log it is written
+// regardless of DEBUG.
+package audit
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"storyblok-sync/internal/infra/logx"
+)
+
+// Record describes one write request and its outcome.
+type Record struct {
+	Time time.Time `json:"time"`
+	// Token owner as reported by /users/me, plus a fingerprint of the token
+	OwnerID    int    `json:"owner_id,omitempty"`
+	Owner      string `json:"owner,omitempty"`
+	TokenHash  string `json:"token_sha256,omitempty"`
+	Method     string `json:"method"`
+	SpaceID    int    `json:"space_id,omitempty"`
+	Endpoint   string `json:"endpoint"` // URL path below the API version, e.g. /spaces/1/stories/2
+	Resource   string `json:"resource,omitempty"`
+	ObjectID   int    `json:"object_id,omitempty"`
+	UUID       string `json:"uuid,omitempty"`
+	FullSlug   string `json:"full_slug,omitempty"`
+	Name       string `json:"name,omitempty"`
+	PayloadSHA string `json:"payload_sha256,omitempty"`
+	// Source space and story the write was synced from, if any
+	SourceSpaceID  int    `json:"source_space_id,omitempty"`
+	SourceStoryID  int    `json:"source_story_id,omitempty"`
+	SourceFullSlug string `json:"source_full_slug,omitempty"`
+	Status         int    `json:"status,omitempty"`
+	Error          string `json:"error,omitempty"`
+	DurationMs     int64  `json:"duration_ms"`
+}
+
+// Sink persists audit records.
+type Sink interface {
+	Write(rec Record) error
+	Close() error
+}
+
+var (
+	mu   sync.RWMutex
+	sink Sink
+)
+
+// SetSink installs the process-wide sink; nil disables auditing.
+func SetSink(s Sink) { mu.Lock(); sink = s; mu.Unlock() }
+
+// Enabled reports whether a sink is installed.
+func Enabled() bool { mu.RLock(); defer mu.RUnlock(); return sink != nil }
+
+// Write records rec in the installed sink. Failures are logged, never
+// returned: auditing must not break the write it describes.
+func Write(rec Record) {
+	mu.RLock()
+	s := sink
+	mu.RUnlock()
+	if s == nil {
+		return
+	}
+	if rec.Time.IsZero() {
+		rec.Time = time.Now().UTC()
+	}
+	if err := s.Write(rec); err != nil {
+		logx.Errorf("AUDIT write failed method=%s endpoint=%s err=%v", rec.Method, rec.Endpoint, err)
+	}
+}
+
+// Close closes the installed sink and uninstalls it.
+func Close() error {
+	mu.Lock()
+	s := sink
+	sink = nil
+	mu.Unlock()
+	if s == nil {
+		return nil
+	}
+	return s.Close()
+}
+
+// multiSink fans records out to several sinks.
+type multiSink []Sink
+
+// Multi combines sinks; a record is written to all of them.
+func Multi(sinks ...Sink) Sink {
+	if len(sinks) == 1 {
+		return sinks[0]
+	}
+	return multiSink(sinks)
+}
+
+func (m multiSink) Write(rec Record) error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Write(rec); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m multiSink) Close() error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
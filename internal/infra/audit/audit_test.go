@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readRecords(t *testing.T, path string) []Record {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var out []Record
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var r Record
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			t.Fatalf("invalid line %q: %v", sc.Text(), err)
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func TestFileSinkAppendsAndRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "audit.jsonl")
+	s, err := NewFileSink(path, 300, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 6; i++ {
+		if err := s.Write(Record{Method: "PUT", Endpoint: "/spaces/1/stories/2", ObjectID: i}); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	current := readRecords(t, path)
+	if len(current) == 0 || current[len(current)-1].ObjectID != 6 {
+		t.Fatalf("expected the latest record in the current file, got %+v", current)
+	}
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Fatalf("expected a rotated file: %v", err)
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("expected at most 2 rotated files, got err=%v", err)
+	}
+	if st, _ := os.Stat(path); st.Size() > 300 {
+		t.Fatalf("current file exceeds the limit: %d bytes", st.Size())
+	}
+
+	// reopening appends instead of truncating
+	s, err = NewFileSink(path, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := len(readRecords(t, path))
+	_ = s.Write(Record{Method: "DELETE", Endpoint: "/spaces/1/stories/3"})
+	s.Close()
+	if got := len(readRecords(t, path)); got != before+1 {
+		t.Fatalf("expected %d records after reopen, got %d", before+1, got)
+	}
+}
+
+func TestWriteUsesInstalledSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	s, err := NewFileSink(path, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Write(Record{Method: "POST"}) // no sink: dropped
+	SetSink(Multi(s))
+	if !Enabled() {
+		t.Fatal("expected auditing to be enabled")
+	}
+	Write(Record{Method: "POST", Endpoint: "/spaces/1/components"})
+	if err := Close(); err != nil {
+		t.Fatal(err)
+	}
+	if Enabled() {
+		t.Fatal("expected Close to uninstall the sink")
+	}
+	recs := readRecords(t, path)
+	if len(recs) != 1 || recs[0].Endpoint != "/spaces/1/components" || recs[0].Time.IsZero() {
+		t.Fatalf("unexpected records %+v", recs)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("SB_AUDIT_SYSLOG", "")
+	t.Setenv("SB_AUDIT_LOG", "off")
+	if s, err := FromEnv(); err != nil || s != nil {
+		t.Fatalf("expected auditing off, got %v, %v", s, err)
+	}
+
+	t.Setenv("SB_AUDIT_LOG", "")
+	s, err := FromEnv()
+	if err != nil || s == nil {
+		t.Fatalf("expected default file sink, got %v, %v", s, err)
+	}
+	s.Close()
+	if _, err := os.Stat(DefaultPath); err != nil {
+		t.Fatalf("expected %s to be created: %v", DefaultPath, err)
+	}
+
+	t.Setenv("SB_AUDIT_MAX_MB", "lots")
+	if _, err := FromEnv(); err == nil {
+		t.Fatal("expected error for invalid SB_AUDIT_MAX_MB")
+	}
+}
@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultPath is where the audit log is kept relative to the working directory.
+const DefaultPath = ".sbsync/audit.jsonl"
+
+// Rotation defaults: files are rotated at DefaultMaxMB and DefaultKeep
+// rotated files are kept next to the current one.
+const (
+	DefaultMaxMB = 10
+	DefaultKeep  = 5
+)
+
+// FromEnv builds the sink configured by SB_AUDIT_LOG (file path, "off" to
+// disable), SB_AUDIT_MAX_MB (rotation size) and SB_AUDIT_SYSLOG (syslog tag,
+// or 1/true for "sbsync"). It returns nil when auditing is disabled.
+func FromEnv() (Sink, error) {
+	var sinks []Sink
+	if path := fileFromEnv(os.Getenv("SB_AUDIT_LOG")); path != "" {
+		maxMB := DefaultMaxMB
+		if v := strings.TrimSpace(os.Getenv("SB_AUDIT_MAX_MB")); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid SB_AUDIT_MAX_MB %q", v)
+			}
+			maxMB = n
+		}
+		fs, err := NewFileSink(path, int64(maxMB)*1024*1024, DefaultKeep)
+		if err != nil {
+			return nil, fmt.Errorf("open audit log: %w", err)
+		}
+		sinks = append(sinks, fs)
+	}
+	if tag := syslogTagFromEnv(os.Getenv("SB_AUDIT_SYSLOG")); tag != "" {
+		ss, err := NewSyslogSink(tag)
+		if err != nil {
+			for _, s := range sinks {
+				s.Close()
+			}
+			return nil, fmt.Errorf("open audit syslog: %w", err)
+		}
+		sinks = append(sinks, ss)
+	}
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+	return Multi(sinks...), nil
+}
+
+func fileFromEnv(v string) string {
+	v = strings.TrimSpace(v)
+	switch strings.ToLower(v) {
+	case "":
+		return DefaultPath
+	case "off", "0", "false", "no":
+		return ""
+	}
+	return v
+}
+
+func syslogTagFromEnv(v string) string {
+	v = strings.TrimSpace(v)
+	switch strings.ToLower(v) {
+	case "", "off", "0", "false", "no":
+		return ""
+	case "1", "true", "yes", "on":
+		return "sbsync"
+	}
+	return v
+}
@@ -0,0 +1,10 @@
+//go:build windows || plan9
+
+package audit
+
+import "errors"
+
+// NewSyslogSink is not available on this platform.
+func NewSyslogSink(tag string) (Sink, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
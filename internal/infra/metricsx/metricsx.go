@@ -0,0 +1,17 @@
+// Package metricsx exposes process metrics (transport counters, limiter
+// rates, item durations) through the Prometheus client. Packages register
+// their collectors with Registry at init; nothing is served until Serve is
+// called.
+package metricsx
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Registry holds the sbsync metrics. It is separate from the Prometheus
+// default registry, so /metrics only lists what sbsync itself records.
+var Registry = prometheus.NewRegistry()
+
+// Factory creates metrics registered with Registry.
+var Factory = promauto.With(Registry)
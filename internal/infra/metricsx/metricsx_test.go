@@ -0,0 +1,50 @@
+package metricsx
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestServeExposesOpenMetrics(t *testing.T) {
+	g := Factory.NewGaugeVec(prometheus.GaugeOpts{Name: "test_limiter_rps", Help: "Limiter rate."}, []string{"space_id", "kind"})
+	g.WithLabelValues("1", "read").Set(7)
+	h := Factory.NewHistogramVec(prometheus.HistogramOpts{Name: "test_item_seconds", Help: "Item duration.", Buckets: []float64{0.5, 1}}, []string{"kind"})
+	for _, v := range []float64{0.2, 0.7, 3} {
+		h.WithLabelValues("story").Observe(v)
+	}
+	addr, stop, err := Serve("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	req, _ := http.NewRequest(http.MethodGet, "http://"+addr+"/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	out := string(body)
+	if !strings.HasPrefix(res.Header.Get("Content-Type"), "application/openmetrics-text") {
+		t.Fatalf("unexpected content type %q", res.Header.Get("Content-Type"))
+	}
+	for _, want := range []string{
+		`test_limiter_rps{kind="read",space_id="1"} 7.0` + "\n",
+		`test_item_seconds_bucket{kind="story",le="0.5"} 1` + "\n",
+		`test_item_seconds_bucket{kind="story",le="+Inf"} 3` + "\n",
+		`test_item_seconds_count{kind="story"} 3` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(out, "# EOF\n") {
+		t.Fatalf("output must end with # EOF:\n%s", out)
+	}
+}
@@ -0,0 +1,43 @@
+package metricsx
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"storyblok-sync/internal/infra/logx"
+)
+
+// Handler serves the registered metrics, in the OpenMetrics text format when
+// the scraper asks for it.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		EnableOpenMetrics: true,
+		ErrorLog:          promLogger{},
+	})
+}
+
+// promLogger forwards handler errors to the warning log.
+type promLogger struct{}
+
+func (promLogger) Println(v ...interface{}) { logx.Warnf("METRICS %v", v) }
+
+// Serve listens on addr (e.g. ":9090") and serves /metrics in the
+// background. It returns the bound address and a func stopping the server.
+func Serve(addr string) (string, func(), error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+			logx.Warnf("METRICS server stopped: %v", err)
+		}
+	}()
+	return ln.Addr().String(), func() { _ = srv.Close() }, nil
+}
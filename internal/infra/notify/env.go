@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FromEnv builds the notifier from SB_WEBHOOK_URLS, a comma-separated list of
+// URLs, each optionally prefixed with its template ("slack=https://…",
+// default generic). SB_WEBHOOK_SECRET signs the payloads, SB_WEBHOOK_EVENTS
+// (started, completed, failure_threshold) filters events,
+// SB_WEBHOOK_FAIL_THRESHOLD (default 1, 0 disables) sets the failure count
+// of the threshold event and SB_WEBHOOK_RETRIES (default 3) the retries.
+// It returns nil when no URL is configured.
+func FromEnv() (*Notifier, error) {
+	var hooks []Hook
+	for _, item := range strings.Split(os.Getenv("SB_WEBHOOK_URLS"), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		h := Hook{URL: item, Template: TemplateGeneric}
+		if tmpl, u, ok := strings.Cut(item, "="); ok && !strings.Contains(tmpl, "/") {
+			h = Hook{URL: strings.TrimSpace(u), Template: strings.ToLower(strings.TrimSpace(tmpl))}
+		}
+		if h.Template != TemplateGeneric && h.Template != TemplateSlack {
+			return nil, fmt.Errorf("unknown webhook template %q (generic, slack)", h.Template)
+		}
+		if !strings.HasPrefix(h.URL, "http://") && !strings.HasPrefix(h.URL, "https://") {
+			return nil, fmt.Errorf("invalid webhook url %q", h.URL)
+		}
+		hooks = append(hooks, h)
+	}
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+	n := &Notifier{
+		Hooks:         hooks,
+		Secret:        os.Getenv("SB_WEBHOOK_SECRET"),
+		FailThreshold: 1,
+		Retries:       3,
+		Backoff:       time.Second,
+	}
+	if v := strings.TrimSpace(os.Getenv("SB_WEBHOOK_EVENTS")); v != "" {
+		n.Events = make(map[string]bool)
+		for _, e := range strings.Split(v, ",") {
+			switch e = strings.ToLower(strings.TrimSpace(e)); e {
+			case "started", "completed", "failure_threshold":
+				n.Events["sync."+e] = true
+			case "":
+			default:
+				return nil, fmt.Errorf("unknown webhook event %q (started, completed, failure_threshold)", e)
+			}
+		}
+	}
+	var err error
+	if n.FailThreshold, err = intEnv("SB_WEBHOOK_FAIL_THRESHOLD", n.FailThreshold); err != nil {
+		return nil, err
+	}
+	if n.Retries, err = intEnv("SB_WEBHOOK_RETRIES", n.Retries); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func intEnv(key string, def int) (int, error) {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q", key, v)
+	}
+	return n, nil
+}
@@ -0,0 +1,250 @@
+// Package notify posts outgoing webhooks when a sync run starts, completes
+// or reaches its failure threshold. Payloads are signed with HMAC-SHA256
+// when a secret is configured and delivered in the background with retries.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"storyblok-sync/internal/infra/logx"
+	"storyblok-sync/internal/report"
+)
+
+// Events a hook can subscribe to
+const (
+	EventStarted          = "sync.started"
+	EventCompleted        = "sync.completed"
+	EventFailureThreshold = "sync.failure_threshold"
+)
+
+// Payload templates
+const (
+	TemplateGeneric = "generic"
+	TemplateSlack   = "slack"
+)
+
+// SignatureHeader carries "sha256=<hex HMAC of the body>".
+const SignatureHeader = "X-Sbsync-Signature"
+
+// maxFailedSlugs caps the failed slugs sent per event.
+const maxFailedSlugs = 100
+
+// Event is the summary of a run sent to the hooks; it is the body of the
+// generic template.
+type Event struct {
+	Event         string         `json:"event"`
+	Time          time.Time      `json:"time"`
+	SourceSpace   string         `json:"source_space,omitempty"`
+	SourceSpaceID int            `json:"source_space_id,omitempty"`
+	TargetSpace   string         `json:"target_space,omitempty"`
+	TargetSpaceID int            `json:"target_space_id,omitempty"`
+	DurationMs    int64          `json:"duration_ms"`
+	Summary       report.Summary `json:"summary"`
+	FailedSlugs   []string       `json:"failed_slugs,omitempty"`
+	// Threshold is the configured failure threshold of a threshold event
+	Threshold int `json:"failure_threshold,omitempty"`
+}
+
+// FromReport builds an event from the current state of a (running) report.
+func FromReport(event string, r *report.Report) Event {
+	snap := *r
+	snap.Finalize()
+	ev := Event{
+		Event:         event,
+		Time:          time.Now(),
+		SourceSpace:   r.SourceSpace,
+		SourceSpaceID: r.SourceSpaceID,
+		TargetSpace:   r.TargetSpace,
+		TargetSpaceID: r.TargetSpaceID,
+		DurationMs:    snap.Duration,
+		Summary:       snap.Summary,
+	}
+	for _, e := range r.Entries {
+		if e.Status == "failure" {
+			ev.AddFailed(e.Slug)
+		}
+	}
+	return ev
+}
+
+// AddFailed appends a failed slug, keeping at most maxFailedSlugs.
+func (ev *Event) AddFailed(slug string) {
+	if len(ev.FailedSlugs) < maxFailedSlugs {
+		ev.FailedSlugs = append(ev.FailedSlugs, slug)
+	}
+}
+
+// Hook is one webhook target.
+type Hook struct {
+	URL      string
+	Template string // TemplateGeneric or TemplateSlack
+}
+
+// Notifier delivers events to its hooks.
+type Notifier struct {
+	Hooks  []Hook
+	Secret string
+	// Events limits the delivered events; empty delivers all
+	Events map[string]bool
+	// FailThreshold is the failure count firing EventFailureThreshold (0: never)
+	FailThreshold int
+	// Retries after the first attempt on network errors, 429 and 5xx
+	Retries int
+	// Backoff before the first retry, doubled per retry
+	Backoff time.Duration
+	Client  *http.Client
+
+	wg sync.WaitGroup
+}
+
+// Wants reports whether event is delivered.
+func (n *Notifier) Wants(event string) bool {
+	return len(n.Events) == 0 || n.Events[event]
+}
+
+// Send delivers ev to all hooks in the background.
+func (n *Notifier) Send(ev Event) {
+	if !n.Wants(ev.Event) {
+		return
+	}
+	for _, h := range n.Hooks {
+		n.wg.Add(1)
+		go func(h Hook) {
+			defer n.wg.Done()
+			if err := n.Deliver(context.Background(), h, ev); err != nil {
+				logx.Warnf("WEBHOOK %s to %s failed: %v", ev.Event, redactURL(h.URL), err)
+			}
+		}(h)
+	}
+}
+
+// Wait blocks until all background deliveries are done.
+func (n *Notifier) Wait() { n.wg.Wait() }
+
+// Deliver posts ev to h, retrying transient failures.
+func (n *Notifier) Deliver(ctx context.Context, h Hook, ev Event) error {
+	body, err := Render(h.Template, ev)
+	if err != nil {
+		return err
+	}
+	client := n.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	backoff := n.Backoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	var lastErr error
+	for attempt := 0; attempt <= n.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		retry, err := n.post(ctx, client, h.URL, ev.Event, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return lastErr
+}
+
+// post sends one attempt and reports whether a failure is worth retrying.
+func (n *Notifier) post(ctx context.Context, client *http.Client, url, event string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "sbsync")
+	req.Header.Set("X-Sbsync-Event", event)
+	if n.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(n.Secret, body))
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+	retry := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+	return retry, fmt.Errorf("webhook status %s", res.Status)
+}
+
+// Sign returns the signature header value of body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature header value in constant time.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+var (
+	mu  sync.RWMutex
+	cur *Notifier
+)
+
+// Install makes n the process notifier; nil disables webhooks.
+func Install(n *Notifier) {
+	mu.Lock()
+	cur = n
+	mu.Unlock()
+}
+
+// Enabled reports whether a notifier is installed.
+func Enabled() bool { mu.RLock(); defer mu.RUnlock(); return cur != nil }
+
+// Send delivers ev with the installed notifier, if any.
+func Send(ev Event) {
+	mu.RLock()
+	n := cur
+	mu.RUnlock()
+	if n != nil {
+		n.Send(ev)
+	}
+}
+
+// Threshold returns the failure threshold of the installed notifier (0: none).
+func Threshold() int {
+	mu.RLock()
+	defer mu.RUnlock()
+	if cur == nil {
+		return 0
+	}
+	return cur.FailThreshold
+}
+
+// Close waits for pending deliveries and uninstalls the notifier.
+func Close() {
+	mu.Lock()
+	n := cur
+	cur = nil
+	mu.Unlock()
+	if n != nil {
+		n.Wait()
+	}
+}
@@ -0,0 +1,171 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"storyblok-sync/internal/report"
+)
+
+// receiver records webhook requests and answers with queued statuses.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.bodies = append(rc.bodies, body)
+	rc.headers = append(rc.headers, r.Header.Clone())
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (rc *receiver) calls() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return len(rc.bodies)
+}
+
+func sampleReport() *report.Report {
+	r := report.New("Source (1)", "Target (2)")
+	r.SourceSpaceID, r.TargetSpaceID = 1, 2
+	r.StartTime = time.Now().Add(-2 * time.Second)
+	r.Add(report.Entry{Slug: "de/home", Status: "success", Operation: "update"})
+	r.Add(report.Entry{Slug: "de/about", Status: "failure", Operation: "sync", Error: "boom"})
+	return r
+}
+
+func TestDeliverSignsAndRetries(t *testing.T) {
+	rc := &receiver{statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests}}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	n := &Notifier{Secret: "s3cret", Retries: 3, Backoff: time.Millisecond}
+	ev := FromReport(EventCompleted, sampleReport())
+	if err := n.Deliver(context.Background(), Hook{URL: srv.URL}, ev); err != nil {
+		t.Fatal(err)
+	}
+	if rc.calls() != 3 {
+		t.Fatalf("expected 2 retries before success, got %d calls", rc.calls())
+	}
+	body, h := rc.bodies[2], rc.headers[2]
+	if !Verify("s3cret", body, h.Get(SignatureHeader)) || Verify("other", body, h.Get(SignatureHeader)) {
+		t.Fatalf("invalid signature %q", h.Get(SignatureHeader))
+	}
+	if h.Get("X-Sbsync-Event") != EventCompleted || h.Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected headers %v", h)
+	}
+	var got Event
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Summary.Total != 2 || got.Summary.Failure != 1 || got.TargetSpaceID != 2 || got.DurationMs < 2000 {
+		t.Fatalf("unexpected summary %+v", got)
+	}
+	if len(got.FailedSlugs) != 1 || got.FailedSlugs[0] != "de/about" {
+		t.Fatalf("unexpected failed slugs %v", got.FailedSlugs)
+	}
+}
+
+func TestDeliverDoesNotRetryClientErrors(t *testing.T) {
+	rc := &receiver{statuses: []int{http.StatusBadRequest}}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	n := &Notifier{Retries: 3, Backoff: time.Millisecond}
+	if err := n.Deliver(context.Background(), Hook{URL: srv.URL}, Event{Event: EventStarted}); err == nil {
+		t.Fatal("expected an error for a 400 response")
+	}
+	if rc.calls() != 1 {
+		t.Fatalf("expected a single attempt, got %d", rc.calls())
+	}
+	if rc.headers[0].Get(SignatureHeader) != "" {
+		t.Fatal("unsigned notifier must not send a signature")
+	}
+}
+
+func TestSlackTemplate(t *testing.T) {
+	body, err := Render(TemplateSlack, FromReport(EventCompleted, sampleReport()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg map[string]string
+	if err := json.Unmarshal(body, &msg); err != nil {
+		t.Fatal(err)
+	}
+	text := msg["text"]
+	for _, want := range []string{":x:", "Source (1) → Target (2)", "1 succeeded, 0 warnings, 1 failed", "Failed: `de/about`"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in %q", want, text)
+		}
+	}
+	if _, err := Render("teams", Event{}); err == nil {
+		t.Fatal("expected an error for an unknown template")
+	}
+}
+
+func TestSendFiltersEventsAndCloseWaits(t *testing.T) {
+	rc := &receiver{}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	Install(&Notifier{
+		Hooks:  []Hook{{URL: srv.URL}, {URL: srv.URL, Template: TemplateSlack}},
+		Events: map[string]bool{EventCompleted: true},
+	})
+	Send(Event{Event: EventStarted})
+	Send(FromReport(EventCompleted, sampleReport()))
+	Close()
+	if Enabled() {
+		t.Fatal("expected Close to uninstall the notifier")
+	}
+	if rc.calls() != 2 {
+		t.Fatalf("expected the completed event on both hooks only, got %d calls", rc.calls())
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("SB_WEBHOOK_URLS", "")
+	if n, err := FromEnv(); err != nil || n != nil {
+		t.Fatalf("expected webhooks off, got %v, %v", n, err)
+	}
+
+	t.Setenv("SB_WEBHOOK_URLS", "https://deploy.example/hook?a=b, slack=https://hooks.slack.com/services/T/B/X")
+	t.Setenv("SB_WEBHOOK_EVENTS", "completed,failure_threshold")
+	t.Setenv("SB_WEBHOOK_FAIL_THRESHOLD", "5")
+	n, err := FromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(n.Hooks) != 2 || n.Hooks[0].Template != TemplateGeneric || n.Hooks[0].URL != "https://deploy.example/hook?a=b" || n.Hooks[1].Template != TemplateSlack {
+		t.Fatalf("unexpected hooks %+v", n.Hooks)
+	}
+	if n.Wants(EventStarted) || !n.Wants(EventFailureThreshold) || n.FailThreshold != 5 || n.Retries != 3 {
+		t.Fatalf("unexpected notifier %+v", n)
+	}
+
+	t.Setenv("SB_WEBHOOK_EVENTS", "finished")
+	if _, err := FromEnv(); err == nil {
+		t.Fatal("expected error for an unknown event")
+	}
+	t.Setenv("SB_WEBHOOK_EVENTS", "")
+	t.Setenv("SB_WEBHOOK_URLS", "teams=https://example.com")
+	if _, err := FromEnv(); err == nil {
+		t.Fatal("expected error for an unknown template")
+	}
+}
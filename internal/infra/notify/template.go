@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// slackFailedSlugs caps the failed slugs listed in a Slack message.
+const slackFailedSlugs = 10
+
+// Render builds the request body of ev for template.
+func Render(template string, ev Event) ([]byte, error) {
+	switch template {
+	case "", TemplateGeneric:
+		return json.Marshal(ev)
+	case TemplateSlack:
+		return json.Marshal(map[string]string{"text": slackText(ev)})
+	default:
+		return nil, fmt.Errorf("unknown webhook template %q", template)
+	}
+}
+
+// slackText renders ev as a Slack mrkdwn message (also understood by
+// Mattermost and Rocket.Chat incoming webhooks).
+func slackText(ev Event) string {
+	route := fmt.Sprintf("%s → %s", orID(ev.SourceSpace, ev.SourceSpaceID), orID(ev.TargetSpace, ev.TargetSpaceID))
+	s := ev.Summary
+	counts := fmt.Sprintf("%d succeeded, %d warnings, %d failed", s.Success, s.Warning, s.Failure)
+	var b strings.Builder
+	switch ev.Event {
+	case EventStarted:
+		fmt.Fprintf(&b, ":arrows_counterclockwise: *sbsync* started: %s", route)
+	case EventCompleted:
+		icon := ":white_check_mark:"
+		if s.Failure > 0 {
+			icon = ":x:"
+		}
+		fmt.Fprintf(&b, "%s *sbsync* finished: %s\n%s in %s", icon, route, counts, time.Duration(ev.DurationMs)*time.Millisecond)
+	case EventFailureThreshold:
+		fmt.Fprintf(&b, ":warning: *sbsync* reached %d failures: %s\n%s so far", ev.Threshold, route, counts)
+	default:
+		fmt.Fprintf(&b, "*sbsync* %s: %s", ev.Event, route)
+	}
+	if len(ev.FailedSlugs) > 0 {
+		shown := ev.FailedSlugs
+		if len(shown) > slackFailedSlugs {
+			shown = shown[:slackFailedSlugs]
+		}
+		b.WriteString("\nFailed: `" + strings.Join(shown, "`, `") + "`")
+		if more := s.Failure - len(shown); more > 0 {
+			fmt.Fprintf(&b, " (+%d more)", more)
+		}
+	}
+	return b.String()
+}
+
+func orID(name string, id int) string {
+	if name != "" {
+		return name
+	}
+	return fmt.Sprintf("space %d", id)
+}
+
+// redactURL keeps scheme and host for logs; hook paths often embed secrets.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "webhook"
+	}
+	return u.Scheme + "://" + u.Host + "/…"
+}
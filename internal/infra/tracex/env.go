@@ -0,0 +1,58 @@
+package tracex
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+)
+
+// DefaultFile is where the file exporter writes relative to the working directory.
+const DefaultFile = ".sbsync/traces.jsonl"
+
+// FromEnv builds the exporter selected by SB_TRACE, or by
+// OTEL_TRACES_EXPORTER when SB_TRACE is unset: "otlp" sends to an OTLP/HTTP
+// collector configured by the standard OTEL_EXPORTER_OTLP_* variables
+// (endpoint, headers, timeout, compression, TLS), "file" appends to
+// SB_TRACE_FILE. It returns nil when tracing is off (the default).
+func FromEnv() (Exporter, error) {
+	key, mode := "SB_TRACE", strings.ToLower(strings.TrimSpace(os.Getenv("SB_TRACE")))
+	if mode == "" {
+		key, mode = "OTEL_TRACES_EXPORTER", strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER")))
+	}
+	switch mode {
+	case "", "off", "0", "false", "no", "none":
+		return nil, nil
+	case "otlp":
+		if p := otlpProtocol(); p != "" && p != "http/protobuf" {
+			return nil, fmt.Errorf("unsupported OTLP protocol %q (http/protobuf)", p)
+		}
+		exp, err := otlptracehttp.New(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("otlp exporter: %w", err)
+		}
+		return exp, nil
+	case "file":
+		path := strings.TrimSpace(os.Getenv("SB_TRACE_FILE"))
+		if path == "" {
+			path = DefaultFile
+		}
+		exp, err := NewFileExporter(path)
+		if err != nil {
+			return nil, fmt.Errorf("open trace file: %w", err)
+		}
+		return exp, nil
+	default:
+		return nil, fmt.Errorf("unknown %s %q (otlp, file, off)", key, mode)
+	}
+}
+
+// otlpProtocol returns the configured OTLP protocol for traces, if any.
+func otlpProtocol() string {
+	if v := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")); v != "" {
+		return v
+	}
+	return strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"))
+}
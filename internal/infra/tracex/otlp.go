@@ -0,0 +1,174 @@
+package tracex
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// OTLP/JSON trace payload (ExportTraceServiceRequest), as accepted by the
+// otlpjsonfile receiver of the collector.
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"` // 1 ok, 2 error
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"` // int64 as string per the JSON mapping
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+func otlpAttr(a attribute.KeyValue) otlpKeyValue {
+	kv := otlpKeyValue{Key: string(a.Key)}
+	switch a.Value.Type() {
+	case attribute.STRING:
+		v := a.Value.AsString()
+		kv.Value.StringValue = &v
+	case attribute.INT64:
+		s := strconv.FormatInt(a.Value.AsInt64(), 10)
+		kv.Value.IntValue = &s
+	case attribute.FLOAT64:
+		v := a.Value.AsFloat64()
+		kv.Value.DoubleValue = &v
+	case attribute.BOOL:
+		v := a.Value.AsBool()
+		kv.Value.BoolValue = &v
+	default:
+		s := a.Value.Emit()
+		kv.Value.StringValue = &s
+	}
+	return kv
+}
+
+// otlpStatusOf maps the SDK status; the SDK and OTLP number ok and error
+// differently.
+func otlpStatusOf(st sdktrace.Status) otlpStatus {
+	switch st.Code {
+	case codes.Ok:
+		return otlpStatus{Code: 1}
+	case codes.Error:
+		return otlpStatus{Code: 2, Message: st.Description}
+	}
+	return otlpStatus{}
+}
+
+// encodeOTLP renders spans as one OTLP/JSON request. All spans of a batch
+// come from the same provider and share its resource and scope.
+func encodeOTLP(spans []sdktrace.ReadOnlySpan) ([]byte, error) {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		sp := otlpSpan{
+			TraceID:           s.SpanContext().TraceID().String(),
+			SpanID:            s.SpanContext().SpanID().String(),
+			Name:              s.Name(),
+			Kind:              int(s.SpanKind()),
+			StartTimeUnixNano: strconv.FormatInt(s.StartTime().UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.EndTime().UnixNano(), 10),
+			Status:            otlpStatusOf(s.Status()),
+		}
+		if s.Parent().HasSpanID() {
+			sp.ParentSpanID = s.Parent().SpanID().String()
+		}
+		for _, a := range s.Attributes() {
+			sp.Attributes = append(sp.Attributes, otlpAttr(a))
+		}
+		out = append(out, sp)
+	}
+	rs := otlpResourceSpans{ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: scope}, Spans: out}}}
+	if len(spans) > 0 {
+		for _, a := range spans[0].Resource().Attributes() {
+			rs.Resource.Attributes = append(rs.Resource.Attributes, otlpAttr(a))
+		}
+	}
+	return json.Marshal(otlpRequest{ResourceSpans: []otlpResourceSpans{rs}})
+}
+
+// FileExporter appends one OTLP/JSON request per batch as a line to a file.
+type FileExporter struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewFileExporter opens (or creates) the trace file at path for appending.
+func NewFileExporter(path string) (*FileExporter, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileExporter{f: f}, nil
+}
+
+// ExportSpans writes the batch as one line.
+func (e *FileExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	line, err := encodeOTLP(spans)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err = e.f.Write(append(line, '\n'))
+	return err
+}
+
+// Shutdown closes the file.
+func (e *FileExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.f.Close()
+}
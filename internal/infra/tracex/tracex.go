@@ -0,0 +1,156 @@
+// Package tracex records spans of sync runs (scan, preflight, sync items,
+// HTTP attempts, limiter and backoff waits) with the OpenTelemetry SDK and
+// exports them over OTLP/HTTP or as OTLP/JSON to a local file. Sampling and
+// the resource follow the standard OTEL_* variables, and outgoing API
+// requests carry a W3C traceparent header. Tracing is off until an exporter
+// is installed; spans are then nil and every method on them is a no-op.
+package tracex
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"storyblok-sync/internal/infra/logx"
+)
+
+// scope is the instrumentation scope of all spans.
+const scope = "sbsync"
+
+// Attr is a span attribute.
+type Attr = attribute.KeyValue
+
+// String, Int and Bool build attributes.
+func String(k, v string) Attr    { return attribute.String(k, v) }
+func Int(k string, v int) Attr   { return attribute.Int(k, v) }
+func Bool(k string, v bool) Attr { return attribute.Bool(k, v) }
+
+// Exporter ships finished spans.
+type Exporter = sdktrace.SpanExporter
+
+// Span is one timed operation of a trace.
+type Span struct {
+	span trace.Span
+}
+
+var (
+	mu       sync.RWMutex
+	provider *sdktrace.TracerProvider
+
+	// propagator writes W3C traceparent/tracestate headers
+	propagator propagation.TextMapPropagator = propagation.TraceContext{}
+)
+
+// Enabled reports whether an exporter is installed.
+func Enabled() bool { mu.RLock(); defer mu.RUnlock(); return provider != nil }
+
+// Install starts exporting finished spans in batches; nil disables tracing.
+// The sampler comes from OTEL_TRACES_SAMPLER/OTEL_TRACES_SAMPLER_ARG
+// (default: parent-based always on), the resource from OTEL_SERVICE_NAME and
+// OTEL_RESOURCE_ATTRIBUTES. A previously installed exporter is flushed and
+// closed.
+func Install(exp Exporter) {
+	var tp *sdktrace.TracerProvider
+	if exp != nil {
+		tp = sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp), sdktrace.WithResource(newResource()))
+	}
+	mu.Lock()
+	old := provider
+	provider = tp
+	mu.Unlock()
+	if old != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := old.Shutdown(ctx); err != nil {
+			logx.Warnf("TRACE shutdown failed: %v", err)
+		}
+	}
+}
+
+// Shutdown flushes pending spans and closes the exporter.
+func Shutdown() { Install(nil) }
+
+// newResource describes this process; OTEL_SERVICE_NAME overrides the
+// default service name "sbsync".
+func newResource() *resource.Resource {
+	res, err := resource.New(context.Background(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(attribute.String("service.name", scope)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		logx.Warnf("TRACE resource: %v", err)
+	}
+	return res
+}
+
+// Start begins a span as a child of the span in ctx (or a new trace) and
+// returns a context carrying it. Spans with an HTTP method are client spans.
+// Without an exporter it returns ctx and nil.
+func Start(ctx context.Context, name string, attrs ...Attr) (context.Context, *Span) {
+	mu.RLock()
+	tp := provider
+	mu.RUnlock()
+	if tp == nil {
+		return ctx, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	kind := trace.SpanKindInternal
+	for _, a := range attrs {
+		if a.Key == "http.request.method" {
+			kind = trace.SpanKindClient
+		}
+	}
+	ctx, s := tp.Tracer(scope).Start(ctx, name, trace.WithAttributes(attrs...), trace.WithSpanKind(kind))
+	return ctx, &Span{span: s}
+}
+
+// WithSpan returns ctx carrying s, so spans started from it become its
+// children; used where work continues on a fresh context.
+func WithSpan(ctx context.Context, s *Span) context.Context {
+	if s == nil {
+		return ctx
+	}
+	return trace.ContextWithSpan(ctx, s.span)
+}
+
+// Inject writes the W3C traceparent of the span in ctx to h; without a span
+// in ctx it writes nothing.
+func Inject(ctx context.Context, h http.Header) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(h))
+}
+
+// SetAttrs adds attributes to the span.
+func (s *Span) SetAttrs(attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	s.span.SetAttributes(attrs...)
+}
+
+// SetError marks the span as failed with err (nil is ignored).
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End finishes the span and queues it for export. Only the first call counts.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.span.End()
+}
@@ -0,0 +1,191 @@
+package tracex
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestStartIsNoopWhenDisabled(t *testing.T) {
+	ctx, s := Start(context.Background(), "scan")
+	if s != nil || trace.SpanContextFromContext(ctx).IsValid() {
+		t.Fatal("expected no span without an exporter")
+	}
+	// nil spans must be safe to use
+	s.SetAttrs(Int("n", 1))
+	s.SetError(errors.New("boom"))
+	s.End()
+	h := http.Header{}
+	Inject(ctx, h)
+	if len(h) != 0 {
+		t.Fatalf("unexpected headers %v", h)
+	}
+}
+
+func TestFileExporterWritesChildSpans(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "sbsync-test")
+	path := filepath.Join(t.TempDir(), "traces", "traces.jsonl")
+	exp, err := NewFileExporter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Install(exp)
+	ctx, root := Start(context.Background(), "sync.run", Int("items", 2))
+	_, child := Start(ctx, "http.attempt", String("http.request.method", "PUT"))
+	child.SetError(errors.New("write failed"))
+	child.End()
+	child.End() // second End is ignored
+	root.End()
+	Shutdown()
+	if Enabled() {
+		t.Fatal("expected Shutdown to disable tracing")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var spans []otlpSpan
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var req otlpRequest
+		if err := json.Unmarshal(sc.Bytes(), &req); err != nil {
+			t.Fatalf("invalid line %q: %v", sc.Text(), err)
+		}
+		service := ""
+		for _, a := range req.ResourceSpans[0].Resource.Attributes {
+			if a.Key == "service.name" && a.Value.StringValue != nil {
+				service = *a.Value.StringValue
+			}
+		}
+		if service != "sbsync-test" {
+			t.Fatalf("unexpected resource %+v", req.ResourceSpans[0].Resource)
+		}
+		spans = append(spans, req.ResourceSpans[0].ScopeSpans[0].Spans...)
+	}
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %+v", spans)
+	}
+	byName := map[string]otlpSpan{}
+	for _, s := range spans {
+		byName[s.Name] = s
+	}
+	run, item := byName["sync.run"], byName["http.attempt"]
+	if item.TraceID != run.TraceID || item.ParentSpanID != run.SpanID || run.ParentSpanID != "" {
+		t.Fatalf("child not linked to root: run=%+v item=%+v", run, item)
+	}
+	if len(run.TraceID) != 32 || len(run.SpanID) != 16 {
+		t.Fatalf("unexpected id lengths %q %q", run.TraceID, run.SpanID)
+	}
+	if item.Status.Code != 2 || item.Status.Message != "write failed" || run.Status.Code != 0 {
+		t.Fatalf("unexpected status run=%+v item=%+v", run.Status, item.Status)
+	}
+	if item.Kind != int(trace.SpanKindClient) || run.Kind != int(trace.SpanKindInternal) {
+		t.Fatalf("unexpected kinds run=%d item=%d", run.Kind, item.Kind)
+	}
+	if v := run.Attributes[0].Value.IntValue; v == nil || *v != "2" {
+		t.Fatalf("unexpected int attribute %+v", run.Attributes)
+	}
+}
+
+func TestInjectWritesTraceparent(t *testing.T) {
+	exp, err := NewFileExporter(filepath.Join(t.TempDir(), "traces.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	Install(exp)
+	defer Shutdown()
+	ctx, s := Start(context.Background(), "http.attempt")
+	defer s.End()
+	h := http.Header{}
+	Inject(ctx, h)
+	sc := trace.SpanContextFromContext(ctx)
+	want := "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01"
+	if got := h.Get("traceparent"); got != want {
+		t.Fatalf("traceparent = %q, want %q", got, want)
+	}
+}
+
+func TestSamplerFromEnv(t *testing.T) {
+	t.Setenv("OTEL_TRACES_SAMPLER", "always_off")
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	exp, err := NewFileExporter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Install(exp)
+	_, s := Start(context.Background(), "scan")
+	s.End()
+	Shutdown()
+	if b, _ := os.ReadFile(path); len(b) != 0 {
+		t.Fatalf("expected no exported spans, got %s", b)
+	}
+}
+
+func TestOTLPExporterFromEnv(t *testing.T) {
+	var path, auth, ctype string
+	var n int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth, ctype = r.URL.Path, r.Header.Get("Authorization"), r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		n = len(body)
+	}))
+	defer srv.Close()
+
+	t.Setenv("SB_TRACE", "otlp")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", srv.URL)
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization=Bearer x")
+	exp, err := FromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	Install(exp)
+	_, s := Start(context.Background(), "http.attempt", String("http.request.method", "PUT"))
+	s.End()
+	Shutdown()
+
+	if path != "/v1/traces" || auth != "Bearer x" || ctype != "application/x-protobuf" || n == 0 {
+		t.Fatalf("unexpected export path=%q auth=%q content-type=%q bytes=%d", path, auth, ctype, n)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("SB_TRACE", "")
+	t.Setenv("OTEL_TRACES_EXPORTER", "")
+	if exp, err := FromEnv(); err != nil || exp != nil {
+		t.Fatalf("expected tracing off by default, got %v, %v", exp, err)
+	}
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "otlp")
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "grpc")
+	if _, err := FromEnv(); err == nil {
+		t.Fatal("expected error for the grpc protocol")
+	}
+
+	t.Setenv("SB_TRACE", "file")
+	t.Setenv("SB_TRACE_FILE", "")
+	exp, err := FromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp.Shutdown(context.Background())
+	if _, err := os.Stat(DefaultFile); err != nil {
+		t.Fatalf("expected %s to be created: %v", DefaultFile, err)
+	}
+
+	t.Setenv("SB_TRACE", "jaeger")
+	if _, err := FromEnv(); err == nil {
+		t.Fatal("expected error for unknown SB_TRACE")
+	}
+}
@@ -0,0 +1,117 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// JUnit XML document (the subset CI test UIs read).
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// junitSeconds formats a millisecond duration as JUnit seconds.
+func junitSeconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
+
+// RenderJUnit writes the report as JUnit XML: one test case per story,
+// folder or component, one suite per kind (and target space in fan-out
+// runs). Failures become <failure>, warnings <system-out>, skips <skipped>.
+func (r *Report) RenderJUnit(w io.Writer) error {
+	r.calculateSummary()
+	targetNames := make(map[int]string, len(r.Targets))
+	for _, t := range r.Targets {
+		targetNames[t.ID] = t.Name
+	}
+	doc := junitTestSuites{Name: "sbsync", Time: junitSeconds(r.Duration)}
+	suiteIdx := make(map[string]int)
+	var suiteMs []int64
+	for _, e := range r.Entries {
+		kind := e.kind()
+		suiteName := "sbsync." + kind
+		if name, ok := targetNames[e.TargetSpaceID]; ok && len(r.Targets) > 1 {
+			suiteName = "sbsync." + name + "." + kind
+		}
+		i, ok := suiteIdx[suiteName]
+		if !ok {
+			i = len(doc.Suites)
+			suiteIdx[suiteName] = i
+			doc.Suites = append(doc.Suites, junitTestSuite{Name: suiteName, Timestamp: r.StartTime.UTC().Format("2006-01-02T15:04:05")})
+			suiteMs = append(suiteMs, 0)
+		}
+		tc := junitTestCase{Name: e.Slug, Classname: suiteName, Time: junitSeconds(e.Duration)}
+		var out []string
+		switch e.Status {
+		case "failure":
+			tc.Failure = &junitFailure{Message: e.Error, Type: e.Operation, Text: e.Error}
+			doc.Suites[i].Failures++
+			doc.Failures++
+		case "warning":
+			out = append(out, "warning: "+e.Warning)
+		}
+		if e.Operation == "skip" && tc.Failure == nil {
+			tc.Skipped = &struct{}{}
+			doc.Suites[i].Skipped++
+			doc.Skipped++
+		}
+		if len(e.WorkflowSteps) > 0 {
+			out = append(out, "workflow: "+WorkflowStepsText(e.WorkflowSteps))
+		}
+		for _, c := range e.MergeConflicts {
+			out = append(out, "conflict "+c.String())
+		}
+		tc.SystemOut = strings.Join(out, "\n")
+		doc.Suites[i].Cases = append(doc.Suites[i].Cases, tc)
+		doc.Suites[i].Tests++
+		doc.Tests++
+		suiteMs[i] += e.Duration
+	}
+	for i := range doc.Suites {
+		doc.Suites[i].Time = junitSeconds(suiteMs[i])
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,83 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"storyblok-sync/internal/sb"
+)
+
+func TestRenderJUnitMapsEntriesToTestCases(t *testing.T) {
+	r := New("src (1)", "tgt (2)")
+	r.Add(Entry{Slug: "de", Status: "success", Operation: "create", Duration: 50, TargetStory: &sb.Story{ID: 5, IsFolder: true}})
+	r.Add(Entry{Slug: "de/home", Status: "success", Operation: "update", Duration: 1250})
+	r.Add(Entry{Slug: "de/broken", Status: "failure", Operation: "sync", Error: "422 slug <taken>", Duration: 300})
+	r.Add(Entry{Slug: "de/old", Status: "success", Operation: "skip"})
+	r.Add(Entry{Kind: "component", Slug: "teaser", Status: "warning", Operation: "update", Warning: "preset skipped", Duration: 80})
+
+	var buf bytes.Buffer
+	if err := r.RenderJUnit(&buf); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Fatalf("missing xml header:\n%s", buf.String())
+	}
+	var doc junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid xml: %v\n%s", err, buf.String())
+	}
+	if doc.Tests != 5 || doc.Failures != 1 || doc.Skipped != 1 {
+		t.Fatalf("totals tests=%d failures=%d skipped=%d", doc.Tests, doc.Failures, doc.Skipped)
+	}
+	suites := map[string]junitTestSuite{}
+	for _, s := range doc.Suites {
+		suites[s.Name] = s
+	}
+	stories, folders, components := suites["sbsync.story"], suites["sbsync.folder"], suites["sbsync.component"]
+	if stories.Tests != 3 || folders.Tests != 1 || components.Tests != 1 {
+		t.Fatalf("unexpected suites: %+v", doc.Suites)
+	}
+	if stories.Time != "1.550" {
+		t.Errorf("suite time = %s, want 1.550", stories.Time)
+	}
+	for _, c := range stories.Cases {
+		switch c.Name {
+		case "de/home":
+			if c.Time != "1.250" || c.Failure != nil || c.SystemOut != "" {
+				t.Errorf("unexpected success case %+v", c)
+			}
+		case "de/broken":
+			if c.Failure == nil || c.Failure.Message != "422 slug <taken>" || c.Failure.Type != "sync" {
+				t.Errorf("unexpected failure case %+v", c)
+			}
+		case "de/old":
+			if c.Skipped == nil {
+				t.Errorf("expected skipped case %+v", c)
+			}
+		}
+	}
+	if c := components.Cases[0]; c.Failure != nil || c.SystemOut != "warning: preset skipped" || c.Classname != "sbsync.component" {
+		t.Errorf("unexpected warning case %+v", c)
+	}
+}
+
+func TestRenderJUnitGroupsFanOutTargets(t *testing.T) {
+	r := New("src (1)", "de (2)")
+	r.Targets = []Target{{ID: 2, Name: "de"}, {ID: 3, Name: "at"}}
+	r.Add(Entry{Slug: "home", Status: "success", Operation: "update", TargetSpaceID: 2})
+	r.Add(Entry{Slug: "home", Status: "failure", Operation: "sync", Error: "boom", TargetSpaceID: 3})
+
+	var buf bytes.Buffer
+	if err := r.RenderJUnit(&buf); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	var doc junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid xml: %v", err)
+	}
+	if len(doc.Suites) != 2 || doc.Suites[0].Name != "sbsync.de.story" || doc.Suites[1].Name != "sbsync.at.story" || doc.Suites[1].Failures != 1 {
+		t.Fatalf("unexpected suites: %+v", doc.Suites)
+	}
+}
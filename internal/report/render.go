@@ -0,0 +1,347 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	sync "storyblok-sync/internal/core/sync"
+)
+
+// Report file formats. JSON is always written by Save; HTML and Markdown are
+// exported on demand or automatically via --report-format.
+const (
+	FormatJSON     = "json"
+	FormatHTML     = "html"
+	FormatMarkdown = "md"
+	FormatJUnit    = "junit"
+)
+
+// formatExt is the file extension of a report format.
+func formatExt(format string) string {
+	if format == FormatJUnit {
+		return "xml"
+	}
+	return format
+}
+
+// ParseFormat normalizes a report format name.
+func ParseFormat(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "json":
+		return FormatJSON, nil
+	case "html", "htm":
+		return FormatHTML, nil
+	case "md", "markdown":
+		return FormatMarkdown, nil
+	case "junit", "xml":
+		return FormatJUnit, nil
+	}
+	return "", fmt.Errorf("unknown report format %q (json, html, md, junit)", s)
+}
+
+// storyblokStoryURL links a story in the Storyblok app.
+func storyblokStoryURL(spaceID, storyID int) string {
+	if spaceID == 0 || storyID == 0 {
+		return ""
+	}
+	return fmt.Sprintf("https://app.storyblok.com/#/me/spaces/%d/stories/0/0/%d", spaceID, storyID)
+}
+
+// kind returns the entry kind, telling stories and folders of a story sync apart.
+func (e Entry) kind() string {
+	if e.Kind != "" {
+		return e.Kind
+	}
+	if (e.Story != nil && e.Story.IsFolder) || (e.TargetStory != nil && e.TargetStory.IsFolder) {
+		return KindFolder
+	}
+	return KindStory
+}
+
+// WorkflowStepsText joins workflow steps for a single report line.
+func WorkflowStepsText(steps []sync.WorkflowStep) string {
+	parts := make([]string, 0, len(steps))
+	for _, s := range steps {
+		parts = append(parts, s.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// reportRow is the flattened view of an entry shared by the renderers.
+type reportRow struct {
+	Kind      string
+	Name      string
+	Status    string
+	Operation string
+	Duration  int64
+	Target    string
+	Message   string
+	Details   []string
+	SourceURL string
+	TargetURL string
+}
+
+// rows flattens the entries for rendering, failures first.
+func (r *Report) rows() []reportRow {
+	targetNames := make(map[int]string, len(r.Targets))
+	for _, t := range r.Targets {
+		targetNames[t.ID] = t.Name
+	}
+	srcID, tgtID := r.SpaceIDs()
+	out := make([]reportRow, 0, len(r.Entries))
+	for _, status := range []string{"failure", "warning", "success"} {
+		for _, e := range r.Entries {
+			if e.Status != status {
+				continue
+			}
+			row := reportRow{Kind: e.kind(), Name: e.Slug, Status: e.Status, Operation: e.Operation, Duration: e.Duration, Message: e.Error}
+			if row.Message == "" {
+				row.Message = e.Warning
+			}
+			target := tgtID
+			if e.TargetSpaceID != 0 {
+				target = e.TargetSpaceID
+				row.Target = targetNames[target]
+			}
+			if e.Story != nil {
+				row.SourceURL = storyblokStoryURL(srcID, e.Story.ID)
+			}
+			if e.TargetStory != nil {
+				row.TargetURL = storyblokStoryURL(target, e.TargetStory.ID)
+			}
+			if e.PublishMode != "" {
+				row.Details = append(row.Details, "publish: "+e.PublishMode)
+			}
+			if e.PublishAt != "" {
+				row.Details = append(row.Details, "publish_at: "+e.PublishAt)
+			}
+			if e.RateLimit429 > 0 {
+				row.Details = append(row.Details, fmt.Sprintf("429 retries: %d", e.RateLimit429))
+			}
+			if len(e.CreatedTags) > 0 {
+				row.Details = append(row.Details, "tags+: "+strings.Join(e.CreatedTags, ", "))
+			}
+			if len(e.WorkflowSteps) > 0 {
+				row.Details = append(row.Details, "workflow: "+WorkflowStepsText(e.WorkflowSteps))
+			}
+			for _, c := range e.MergeConflicts {
+				row.Details = append(row.Details, "conflict "+c.String())
+			}
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+// reportTitle names the run for page titles and headings.
+func (r *Report) reportTitle() string {
+	title := "Sync report"
+	if r.SourceSpace != "" || r.TargetSpace != "" {
+		title += ": " + r.SourceSpace + " → " + r.TargetSpace
+	}
+	return title
+}
+
+// RenderMarkdown writes the report as Markdown for PR or ticket comments.
+func (r *Report) RenderMarkdown(w io.Writer) error {
+	r.calculateSummary()
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", r.reportTitle())
+	fmt.Fprintf(&b, "**%d succeeded, %d warnings, %d failed** (%d created, %d updated, %d skipped) in %.1fs, started %s\n\n",
+		r.Summary.Success, r.Summary.Warning, r.Summary.Failure, r.Summary.Created, r.Summary.Updated, r.Summary.Skipped,
+		float64(r.Duration)/1000, r.StartTime.Format("2006-01-02 15:04"))
+	if r.Release != "" {
+		fmt.Fprintf(&b, "- Release: %s (ID %d)\n", r.Release, r.ReleaseID)
+	}
+	if len(r.Languages) > 0 {
+		fmt.Fprintf(&b, "- Languages: %s\n", strings.Join(r.Languages, ", "))
+	}
+	if len(r.Targets) > 1 {
+		names := make([]string, 0, len(r.Targets))
+		for _, t := range r.Targets {
+			names = append(names, fmt.Sprintf("%s (%d)", t.Name, t.ID))
+		}
+		fmt.Fprintf(&b, "- Targets: %s\n", strings.Join(names, ", "))
+	}
+	if r.Release != "" || len(r.Languages) > 0 || len(r.Targets) > 1 {
+		b.WriteString("\n")
+	}
+
+	rows := r.rows()
+	if len(rows) == 0 {
+		b.WriteString("_No entries._\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	b.WriteString("| Status | Kind | Name | Operation | Duration | Target | Message |\n")
+	b.WriteString("|---|---|---|---|---:|---|---|\n")
+	for _, row := range rows {
+		name := mdEscape(row.Name)
+		if row.TargetURL != "" {
+			name = fmt.Sprintf("[%s](%s)", name, row.TargetURL)
+		} else if row.SourceURL != "" {
+			name = fmt.Sprintf("[%s](%s)", name, row.SourceURL)
+		}
+		msg := mdEscape(row.Message)
+		if len(row.Details) > 0 {
+			if msg != "" {
+				msg += "<br>"
+			}
+			msg += mdEscape(strings.Join(row.Details, "; "))
+		}
+		fmt.Fprintf(&b, "| %s %s | %s | %s | %s | %dms | %s | %s |\n",
+			statusSymbol(row.Status), row.Status, row.Kind, name, row.Operation, row.Duration, mdEscape(row.Target), msg)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mdEscape keeps values from breaking Markdown table cells.
+func mdEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r", "")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+func statusSymbol(status string) string {
+	switch status {
+	case "success":
+		return "✅"
+	case "warning":
+		return "⚠️"
+	case "failure":
+		return "❌"
+	}
+	return ""
+}
+
+// RenderHTML writes the report as a self-contained HTML page with a summary
+// and a table filterable by status and text.
+func (r *Report) RenderHTML(w io.Writer) error {
+	r.calculateSummary()
+	data := struct {
+		Title    string
+		R        *Report
+		Rows     []reportRow
+		Started  string
+		Duration string
+	}{
+		Title:    r.reportTitle(),
+		R:        r,
+		Rows:     r.rows(),
+		Started:  r.StartTime.Format("2006-01-02 15:04:05"),
+		Duration: fmt.Sprintf("%.1fs", float64(r.Duration)/1000),
+	}
+	var buf bytes.Buffer
+	if err := reportHTMLTemplate.Execute(&buf, data); err != nil {
+		return err
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+var reportHTMLTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body{font-family:system-ui,sans-serif;margin:2rem;color:#1f2937}
+h1{font-size:1.4rem}
+.summary{display:flex;gap:1rem;margin:1rem 0}
+.card{border:1px solid #e5e7eb;border-radius:8px;padding:.6rem 1rem}
+.card b{display:block;font-size:1.4rem}
+.success b{color:#10b981}.warning b{color:#f59e0b}.failure b{color:#ef4444}
+.meta{color:#6b7280;font-size:.9rem}
+.filters{margin:1rem 0;display:flex;gap:.5rem}
+table{border-collapse:collapse;width:100%;font-size:.9rem}
+th,td{text-align:left;padding:.4rem .6rem;border-bottom:1px solid #e5e7eb;vertical-align:top}
+tr.failure td:first-child{border-left:4px solid #ef4444}
+tr.warning td:first-child{border-left:4px solid #f59e0b}
+tr.success td:first-child{border-left:4px solid #10b981}
+.msg{white-space:pre-wrap}
+.details{color:#6b7280}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="meta">Started {{.Started}} · Duration {{.Duration}}{{if .R.Release}} · Release {{.R.Release}} (ID {{.R.ReleaseID}}){{end}}{{if .R.Languages}} · Languages {{range $i, $l := .R.Languages}}{{if $i}}, {{end}}{{$l}}{{end}}{{end}}</p>
+{{if gt (len .R.Targets) 1}}<p class="meta">Targets: {{range $i, $t := .R.Targets}}{{if $i}}, {{end}}{{$t.Name}} ({{$t.ID}}){{end}}</p>{{end}}
+<div class="summary">
+<div class="card success"><b>{{.R.Summary.Success}}</b>succeeded</div>
+<div class="card warning"><b>{{.R.Summary.Warning}}</b>warnings</div>
+<div class="card failure"><b>{{.R.Summary.Failure}}</b>failed</div>
+<div class="card"><b>{{.R.Summary.Created}} / {{.R.Summary.Updated}} / {{.R.Summary.Skipped}}</b>created / updated / skipped</div>
+</div>
+<div class="filters">
+<input id="q" type="search" placeholder="Filter by name or message">
+<select id="status"><option value="">All statuses</option><option value="failure">Failures</option><option value="warning">Warnings</option><option value="success">Successes</option></select>
+</div>
+<table id="entries">
+<thead><tr><th>Status</th><th>Kind</th><th>Name</th><th>Operation</th><th>Duration</th><th>Target</th><th>Message</th><th>Links</th></tr></thead>
+<tbody>
+{{range .Rows}}<tr class="{{.Status}}" data-status="{{.Status}}">
+<td>{{.Status}}</td><td>{{.Kind}}</td><td>{{.Name}}</td><td>{{.Operation}}</td><td>{{.Duration}}ms</td><td>{{.Target}}</td>
+<td><div class="msg">{{.Message}}</div>{{range .Details}}<div class="details">{{.}}</div>{{end}}</td>
+<td>{{if .SourceURL}}<a href="{{.SourceURL}}">source</a> {{end}}{{if .TargetURL}}<a href="{{.TargetURL}}">target</a>{{end}}</td>
+</tr>
+{{else}}<tr><td colspan="8">No entries.</td></tr>
+{{end}}</tbody>
+</table>
+<script>
+(function(){
+  var q=document.getElementById('q'), st=document.getElementById('status');
+  function apply(){
+    var text=q.value.toLowerCase(), status=st.value;
+    document.querySelectorAll('#entries tbody tr[data-status]').forEach(function(tr){
+      var show=(!status||tr.dataset.status===status)&&(!text||tr.textContent.toLowerCase().indexOf(text)>=0);
+      tr.style.display=show?'':'none';
+    });
+  }
+  q.addEventListener('input',apply); st.addEventListener('change',apply);
+})();
+</script>
+</body>
+</html>
+`))
+
+// Export writes the report in the given format next to the JSON reports
+// (sync-report-<timestamp>.<ext>, .xml for JUnit) and returns the file name.
+func (r *Report) Export(format string) (string, error) {
+	format, err := ParseFormat(format)
+	if err != nil {
+		return "", err
+	}
+	if r.EndTime.IsZero() {
+		r.Finalize()
+	}
+	if err := r.cleanupOldReports(formatExt(format)); err != nil {
+		// Log error but don't fail the export
+		log.Printf("Warning: failed to cleanup old reports: %v", err)
+	}
+	var buf bytes.Buffer
+	switch format {
+	case FormatHTML:
+		err = r.RenderHTML(&buf)
+	case FormatMarkdown:
+		err = r.RenderMarkdown(&buf)
+	case FormatJUnit:
+		err = r.RenderJUnit(&buf)
+	default:
+		var data []byte
+		data, err = json.MarshalIndent(r, "", "  ")
+		buf.Write(data)
+	}
+	if err != nil {
+		return "", err
+	}
+	filename := fmt.Sprintf("sync-report-%s.%s", time.Now().Format("20060102-150405"), formatExt(format))
+	return filename, os.WriteFile(filename, buf.Bytes(), 0o644)
+}
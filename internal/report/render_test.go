@@ -0,0 +1,96 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"storyblok-sync/internal/sb"
+)
+
+func renderFixture() *Report {
+	r := New("Quelle (1)", "Ziel (2)")
+	r.SourceSpaceID, r.TargetSpaceID = 1, 2
+	r.Release = "Sommer"
+	r.ReleaseID = 77
+	r.Add(Entry{Slug: "de/home", Status: "success", Operation: "update", Duration: 120,
+		Story: &sb.Story{ID: 11}, TargetStory: &sb.Story{ID: 21}, PublishMode: "publish"})
+	r.Add(Entry{Slug: "de/broken", Status: "failure", Operation: "sync", Error: "422 <invalid> | slug taken",
+		Story: &sb.Story{ID: 12}})
+	r.Add(Entry{Kind: "component", Slug: "teaser", Status: "warning", Operation: "update", Warning: "preset skipped"})
+	r.Finalize()
+	return r
+}
+
+func TestRenderMarkdownListsEntriesWithLinks(t *testing.T) {
+	var buf bytes.Buffer
+	if err := renderFixture().RenderMarkdown(&buf); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"## Sync report: Quelle (1) → Ziel (2)",
+		"**1 succeeded, 1 warnings, 1 failed**",
+		"- Release: Sommer (ID 77)",
+		"[de/home](https://app.storyblok.com/#/me/spaces/2/stories/0/0/21)",
+		"[de/broken](https://app.storyblok.com/#/me/spaces/1/stories/0/0/12)",
+		`422 <invalid> \| slug taken`,
+		"| ⚠️ warning | component | teaser |",
+		"publish: publish",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown misses %q:\n%s", want, out)
+		}
+	}
+	// failures are listed first
+	if strings.Index(out, "de/broken") > strings.Index(out, "de/home") {
+		t.Errorf("expected failures before successes:\n%s", out)
+	}
+}
+
+func TestRenderHTMLIsSelfContainedAndEscaped(t *testing.T) {
+	var buf bytes.Buffer
+	if err := renderFixture().RenderHTML(&buf); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<title>Sync report: Quelle (1) → Ziel (2)</title>",
+		`<tr class="failure" data-status="failure">`,
+		"422 &lt;invalid&gt; | slug taken",
+		`href="https://app.storyblok.com/#/me/spaces/2/stories/0/0/21"`,
+		"<td>component</td>",
+		`id="q"`,
+		"<script>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("html misses %q", want)
+		}
+	}
+	if strings.Contains(out, "<invalid>") {
+		t.Error("error message not escaped")
+	}
+	if strings.Contains(out, `src="http`) || strings.Contains(out, `rel="stylesheet"`) {
+		t.Error("html must not load external resources")
+	}
+}
+
+func TestExportWritesFormatFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	r := renderFixture()
+	name, err := r.Export("markdown")
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if !strings.HasPrefix(name, "sync-report-") || !strings.HasSuffix(name, ".md") {
+		t.Fatalf("unexpected file name %q", name)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil || !strings.Contains(string(data), "de/broken") {
+		t.Fatalf("export not written: %v", err)
+	}
+	if _, err := r.Export("pdf"); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
@@ -0,0 +1,345 @@
+// Package report holds the run report shared by every sync mode: stories,
+// components and clones all record their items as Entries of one versioned
+// Report, which is saved as JSON and rendered as HTML, Markdown or JUnit.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/sb"
+)
+
+// SchemaVersion is the version of the saved report format (see Schema).
+// Bump it whenever a field changes meaning or is removed.
+const SchemaVersion = 1
+
+// Item kinds recorded in Entry.Kind
+const (
+	KindStory      = "story"
+	KindFolder     = "folder"
+	KindComponent  = "component"
+	KindPreset     = "preset"
+	KindGroup      = "group"
+	KindTag        = "tag"
+	KindDatasource = "datasource"
+	KindAsset      = "asset"
+)
+
+// Entry captures the result of a single sync item with comprehensive details.
+type Entry struct {
+	Slug        string    `json:"slug"`
+	Status      string    `json:"status"`              // success|warning|failure
+	Operation   string    `json:"operation,omitempty"` // create|update|skip
+	Error       string    `json:"error,omitempty"`
+	Warning     string    `json:"warning,omitempty"`
+	Duration    int64     `json:"duration_ms,omitempty"`  // Duration in milliseconds
+	Story       *sb.Story `json:"source_story,omitempty"` // Complete source story for errors/warnings
+	TargetStory *sb.Story `json:"target_story,omitempty"` // Target story if created/updated
+	// Rate limit related counters (deltas captured per item)
+	RateLimit429 int `json:"rate_limit_429,omitempty"`
+	// Selected publish mode for this item (stories only): draft|publish|publish_changes
+	PublishMode string `json:"publish_mode,omitempty"`
+	// Scheduled publish time (UTC) when PublishMode is "schedule"
+	PublishAt string `json:"publish_at,omitempty"`
+	// Story tags created in the target for this item
+	CreatedTags []string `json:"created_tags,omitempty"`
+	// Workflow stage transitions performed around the write (incl. failed reverts)
+	WorkflowSteps []sync.WorkflowStep `json:"workflow_steps,omitempty"`
+	// Three-way merge conflicts with the side written for each
+	MergeConflicts []sync.MergeConflict `json:"merge_conflicts,omitempty"`
+	// Target space the item was written to (fan-out runs only)
+	TargetSpaceID int `json:"target_space_id,omitempty"`
+	// Kind of item (group|tag|component|preset|datasource|folder|story);
+	// reports written before schema version 1 leave it empty for stories
+	Kind string `json:"kind,omitempty"`
+	// Retries of the item's API calls (all causes, 429s included)
+	Retries int `json:"retries,omitempty"`
+	// Identifiers of the item in the source space and in the target space
+	// before and after the write (before is empty for creates)
+	SourceID       int `json:"source_id,omitempty"`
+	TargetIDBefore int `json:"target_id_before,omitempty"`
+	TargetIDAfter  int `json:"target_id_after,omitempty"`
+}
+
+// Target names one target space of a fan-out run.
+type Target struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Report collects all entries and provides comprehensive sync reporting.
+type Report struct {
+	SchemaVersion int       `json:"schema_version"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time,omitempty"`
+	Duration      int64     `json:"total_duration_ms,omitempty"`
+	SourceSpace   string    `json:"source_space,omitempty"`
+	TargetSpace   string    `json:"target_space,omitempty"`
+	// Space IDs of the run, so a saved report can be retried later
+	SourceSpaceID int `json:"source_space_id,omitempty"`
+	TargetSpaceID int `json:"target_space_id,omitempty"`
+	// Target release the writes were staged in (empty: live content)
+	Release   string `json:"release,omitempty"`
+	ReleaseID int    `json:"release_id,omitempty"`
+	// Languages restricted by a language-scoped sync (empty: all)
+	Languages []string `json:"languages,omitempty"`
+	// All target spaces of a fan-out run, primary first (empty: single target)
+	Targets []Target `json:"targets,omitempty"`
+	Entries []Entry  `json:"entries"`
+	Summary Summary  `json:"summary"`
+}
+
+// Summary provides aggregate statistics
+type Summary struct {
+	Total   int `json:"total"`
+	Success int `json:"success"`
+	Warning int `json:"warning"`
+	Failure int `json:"failure"`
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+}
+
+// New creates a new report with initial metadata
+func New(sourceSpace, targetSpace string) *Report {
+	return &Report{
+		SchemaVersion: SchemaVersion,
+		StartTime:     time.Now(),
+		SourceSpace:   sourceSpace,
+		TargetSpace:   targetSpace,
+		Entries:       make([]Entry, 0),
+	}
+}
+
+// Add adds an entry to the report, deriving the story/folder kind if unset
+func (r *Report) Add(e Entry) {
+	e.Kind = e.kind()
+	r.Entries = append(r.Entries, e)
+}
+
+// AddSuccess adds a successful sync entry
+func (r *Report) AddSuccess(slug, operation string, duration int64, targetStory *sb.Story) {
+	r.Add(Entry{
+		Slug:        slug,
+		Status:      "success",
+		Operation:   operation,
+		Duration:    duration,
+		TargetStory: targetStory,
+	})
+}
+
+// AddWarning adds a warning entry with complete source story
+func (r *Report) AddWarning(slug, operation, warning string, duration int64, sourceStory, targetStory *sb.Story) {
+	r.Add(Entry{
+		Slug:        slug,
+		Status:      "warning",
+		Operation:   operation,
+		Warning:     warning,
+		Duration:    duration,
+		Story:       sourceStory,
+		TargetStory: targetStory,
+	})
+}
+
+// AddError adds an error entry with complete source story
+func (r *Report) AddError(slug, operation, error string, duration int64, sourceStory *sb.Story) {
+	r.Add(Entry{
+		Slug:      slug,
+		Status:    "failure",
+		Operation: operation,
+		Error:     error,
+		Duration:  duration,
+		Story:     sourceStory,
+	})
+}
+
+// Finalize calculates final statistics and duration
+func (r *Report) Finalize() {
+	r.EndTime = time.Now()
+	r.Duration = r.EndTime.Sub(r.StartTime).Milliseconds()
+	r.calculateSummary()
+}
+
+// calculateSummary computes the report summary statistics
+func (r *Report) calculateSummary() {
+	summary := Summary{}
+
+	for _, e := range r.Entries {
+		summary.Total++
+
+		switch e.Status {
+		case "success":
+			summary.Success++
+		case "warning":
+			summary.Warning++
+		case "failure":
+			summary.Failure++
+		}
+
+		switch e.Operation {
+		case "create":
+			summary.Created++
+		case "update", "rename":
+			summary.Updated++
+		case "skip":
+			summary.Skipped++
+		}
+	}
+
+	r.Summary = summary
+}
+
+// EntriesForTarget returns the entries written to one target space of a fan-out run.
+func (r *Report) EntriesForTarget(id int) []Entry {
+	var out []Entry
+	for _, e := range r.Entries {
+		if e.TargetSpaceID == id {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Counts returns the count of success, warning, and failure entries (for backward compatibility)
+func (r *Report) Counts() (success, warning, failure int) {
+	for _, e := range r.Entries {
+		switch e.Status {
+		case "success":
+			success++
+		case "warning":
+			warning++
+		case "failure":
+			failure++
+		}
+	}
+	return
+}
+
+// GetDisplaySummary returns a German summary string for UI display
+func (r *Report) GetDisplaySummary() string {
+	r.calculateSummary()
+	return fmt.Sprintf("%d Erfolge, %d Warnungen, %d Fehler",
+		r.Summary.Success, r.Summary.Warning, r.Summary.Failure)
+}
+
+// Save writes the comprehensive report to a JSON file in the current directory.
+// It also performs cleanup of old report files to prevent disk space accumulation.
+func (r *Report) Save() error {
+	r.Finalize()
+
+	if len(r.Entries) == 0 {
+		return nil
+	}
+
+	// Clean up old report files before creating a new one
+	if err := r.cleanupOldReports(FormatJSON); err != nil {
+		// Log error but don't fail the save operation
+		log.Printf("Warning: failed to cleanup old reports: %v", err)
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("sync-report-%s.json", time.Now().Format("20060102-150405"))
+	return os.WriteFile(filename, data, 0o644)
+}
+
+// Load reads a report saved by Save. Reports written before versioning are
+// upgraded in memory; reports of a newer schema version are rejected.
+func Load(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parse report %s: %w", path, err)
+	}
+	if r.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("report %s has schema version %d, this sbsync supports up to %d", path, r.SchemaVersion, SchemaVersion)
+	}
+	if r.SchemaVersion == 0 {
+		for i := range r.Entries {
+			r.Entries[i].Kind = r.Entries[i].kind()
+		}
+		r.SchemaVersion = SchemaVersion
+	}
+	r.calculateSummary()
+	return &r, nil
+}
+
+// List returns the saved report files in dir, newest first.
+func List(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "sync-report-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(files)))
+	return files, nil
+}
+
+// SpaceIDs returns the source and target space IDs of the run. Reports saved
+// before the IDs were recorded fall back to the "Name (ID)" labels.
+func (r *Report) SpaceIDs() (source, target int) {
+	source, target = r.SourceSpaceID, r.TargetSpaceID
+	if source == 0 {
+		source = spaceIDFromLabel(r.SourceSpace)
+	}
+	if target == 0 {
+		target = spaceIDFromLabel(r.TargetSpace)
+	}
+	return source, target
+}
+
+func spaceIDFromLabel(label string) int {
+	open := strings.LastIndex(label, "(")
+	if open < 0 || !strings.HasSuffix(label, ")") {
+		return 0
+	}
+	id, err := strconv.Atoi(label[open+1 : len(label)-1])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// cleanupOldReports removes old report files with one extension, keeping only the most recent 10 files
+func (r *Report) cleanupOldReports(ext string) error {
+	files, err := filepath.Glob("sync-report-*." + ext)
+	if err != nil {
+		return fmt.Errorf("failed to find report files: %w", err)
+	}
+
+	// Keep only the most recent 10 reports
+	if len(files) <= 10 {
+		return nil
+	}
+
+	// Sort files by name (which includes timestamp, so this sorts by date)
+	sort.Strings(files)
+
+	// Remove the oldest files, keeping the last 10
+	filesToRemove := files[:len(files)-10]
+	for _, file := range filesToRemove {
+		if err := os.Remove(file); err != nil {
+			log.Printf("Warning: failed to remove old report file %s: %v", file, err)
+		}
+	}
+
+	if len(filesToRemove) > 0 {
+		log.Printf("Cleaned up %d old report files", len(filesToRemove))
+	}
+
+	return nil
+}
@@ -0,0 +1,9 @@
+package report
+
+import _ "embed"
+
+// Schema is the JSON Schema of the saved report (see SchemaVersion), for
+// tools that consume sync-report-*.json files.
+//
+//go:embed schema.json
+var Schema []byte
@@ -0,0 +1,93 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// jsonFields lists the JSON names of a struct's fields.
+func jsonFields(v interface{}) []string {
+	t := reflect.TypeOf(v)
+	var out []string
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func TestSchemaCoversReportFields(t *testing.T) {
+	var doc struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Defs       struct {
+			Entry struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"entry"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(Schema, &doc); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	for _, f := range jsonFields(Report{}) {
+		if _, ok := doc.Properties[f]; !ok {
+			t.Errorf("schema misses report field %q", f)
+		}
+	}
+	for _, f := range jsonFields(Entry{}) {
+		if _, ok := doc.Defs.Entry.Properties[f]; !ok {
+			t.Errorf("schema misses entry field %q", f)
+		}
+	}
+	var version struct {
+		Const int `json:"const"`
+	}
+	if err := json.Unmarshal(doc.Properties["schema_version"], &version); err != nil || version.Const != SchemaVersion {
+		t.Errorf("schema_version const = %d, want %d", version.Const, SchemaVersion)
+	}
+}
+
+func TestLoadUpgradesLegacyAndRejectsNewerReports(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, "legacy.json")
+	data := `{"start_time":"2025-01-01T12:00:00Z","entries":[` +
+		`{"slug":"de","status":"success","operation":"create","target_story":{"id":5,"is_folder":true}},` +
+		`{"slug":"de/home","status":"failure","operation":"sync","error":"boom"}],"summary":{}}`
+	if err := os.WriteFile(legacy, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Load(legacy)
+	if err != nil {
+		t.Fatalf("load legacy: %v", err)
+	}
+	if r.SchemaVersion != SchemaVersion || r.Entries[0].Kind != KindFolder || r.Entries[1].Kind != KindStory {
+		t.Fatalf("legacy report not upgraded: version %d, kinds %q/%q", r.SchemaVersion, r.Entries[0].Kind, r.Entries[1].Kind)
+	}
+
+	newer := filepath.Join(dir, "newer.json")
+	if err := os.WriteFile(newer, []byte(`{"schema_version":99,"entries":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(newer); err == nil || !strings.Contains(err.Error(), "schema version 99") {
+		t.Fatalf("expected newer schema to be rejected, got %v", err)
+	}
+}
+
+func TestNewReportIsVersioned(t *testing.T) {
+	r := New("src", "tgt")
+	r.Add(Entry{Slug: "teaser", Status: "success", Operation: "update", Kind: KindComponent, SourceID: 1, TargetIDBefore: 9, TargetIDAfter: 9, Retries: 2})
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"schema_version":1`, `"kind":"component"`, `"source_id":1`, `"target_id_before":9`, `"target_id_after":9`, `"retries":2`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("report JSON misses %s: %s", want, data)
+		}
+	}
+}
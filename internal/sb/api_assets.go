@@ -0,0 +1,91 @@
+package sb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Asset represents an asset's metadata (Management API /assets endpoint)
+type Asset struct {
+	ID               int           `json:"id"`
+	Filename         string        `json:"filename"`
+	InternalTagIDs   IntSlice      `json:"internal_tag_ids,omitempty"`
+	InternalTagsList []InternalTag `json:"internal_tags_list,omitempty"`
+}
+
+type assetsResp struct {
+	Assets []Asset `json:"assets"`
+}
+
+// assetsPerPage is the page size used when listing assets
+const assetsPerPage = 100
+
+// ListAssets lists all assets of a space, following pagination
+func (c *Client) ListAssets(ctx context.Context, spaceID int) ([]Asset, error) {
+	if c.token == "" {
+		return nil, errors.New("token leer")
+	}
+	var all []Asset
+	for page := 1; ; page++ {
+		u := fmt.Sprintf(base+"/spaces/%d/assets?page=%d&per_page=%d", spaceID, page, assetsPerPage)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", c.token)
+		req.Header.Add("Content-Type", "application/json")
+		res, err := c.http.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode != 200 {
+			res.Body.Close()
+			return nil, fmt.Errorf("assets.list status %s", res.Status)
+		}
+		var payload assetsResp
+		err = json.NewDecoder(res.Body).Decode(&payload)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, payload.Assets...)
+		if len(payload.Assets) < assetsPerPage {
+			return all, nil
+		}
+	}
+}
+
+// UpdateAssetInternalTags replaces the internal tags of an asset
+func (c *Client) UpdateAssetInternalTags(ctx context.Context, spaceID, assetID int, tagIDs []int) error {
+	if c.token == "" {
+		return errors.New("token leer")
+	}
+	if tagIDs == nil {
+		tagIDs = []int{}
+	}
+	u := fmt.Sprintf(base+"/spaces/%d/assets/%d", spaceID, assetID)
+	payload := map[string]interface{}{"asset": map[string]interface{}{"internal_tag_ids": tagIDs}}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.token)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 && res.StatusCode != 204 {
+		return fmt.Errorf("assets.update status %s", res.Status)
+	}
+	return nil
+}
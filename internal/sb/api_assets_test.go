@@ -0,0 +1,56 @@
+package sb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListAssetsPaginates(t *testing.T) {
+	c := New("token")
+	pages := 0
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/v1/spaces/3/assets") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		pages++
+		n := assetsPerPage
+		if req.URL.Query().Get("page") == "2" {
+			n = 1
+		}
+		assets := make([]Asset, n)
+		for i := range assets {
+			assets[i] = Asset{ID: i + 1, Filename: fmt.Sprintf("https://a.storyblok.com/f/3/x/%d.png", i)}
+		}
+		b, _ := json.Marshal(assetsResp{Assets: assets})
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(string(b))), Header: make(http.Header)}, nil
+	})}
+	got, err := c.ListAssets(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("ListAssets error: %v", err)
+	}
+	if pages != 2 || len(got) != assetsPerPage+1 {
+		t.Fatalf("expected 2 pages and %d assets, got %d pages, %d assets", assetsPerPage+1, pages, len(got))
+	}
+}
+
+func TestUpdateAssetInternalTags(t *testing.T) {
+	c := New("token")
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPut || !strings.HasSuffix(req.URL.Path, "/v1/spaces/3/assets/9") {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		b, _ := io.ReadAll(req.Body)
+		if string(b) != `{"asset":{"internal_tag_ids":[4,5]}}` {
+			t.Fatalf("unexpected payload: %s", b)
+		}
+		return &http.Response{StatusCode: 204, Body: io.NopCloser(strings.NewReader("")), Header: make(http.Header)}, nil
+	})}
+	if err := c.UpdateAssetInternalTags(context.Background(), 3, 9, []int{4, 5}); err != nil {
+		t.Fatalf("UpdateAssetInternalTags error: %v", err)
+	}
+}
@@ -178,10 +178,14 @@ func (c *Client) UpdateComponent(ctx context.Context, spaceID int, comp Componen
 	return resp.Component, nil
 }
 
-// ComponentGroup represents a component group
+// ComponentGroup represents a component group (folder). Groups can be nested
+// via parent_id/parent_uuid; root groups have no parent.
 type ComponentGroup struct {
-	UUID string `json:"uuid"`
-	Name string `json:"name"`
+	ID         int    `json:"id,omitempty"`
+	UUID       string `json:"uuid"`
+	Name       string `json:"name"`
+	ParentID   int    `json:"parent_id,omitempty"`
+	ParentUUID string `json:"parent_uuid,omitempty"`
 }
 
 type componentGroupsResp struct {
@@ -221,12 +225,20 @@ func (c *Client) ListComponentGroups(ctx context.Context, spaceID int) ([]Compon
 
 // CreateComponentGroup creates a component group by name
 func (c *Client) CreateComponentGroup(ctx context.Context, spaceID int, name string) (ComponentGroup, error) {
+	return c.CreateComponentGroupWithParent(ctx, spaceID, name, 0)
+}
+
+// CreateComponentGroupWithParent creates a component group nested under parentID (0 => root)
+func (c *Client) CreateComponentGroupWithParent(ctx context.Context, spaceID int, name string, parentID int) (ComponentGroup, error) {
 	if c.token == "" {
 		return ComponentGroup{}, errors.New("token leer")
 	}
 	u := fmt.Sprintf(base+"/spaces/%d/component_groups", spaceID)
-	payload := map[string]interface{}{"component_group": map[string]string{"name": name}}
-	body, err := json.Marshal(payload)
+	group := map[string]interface{}{"name": name}
+	if parentID > 0 {
+		group["parent_id"] = parentID
+	}
+	body, err := json.Marshal(map[string]interface{}{"component_group": group})
 	if err != nil {
 		return ComponentGroup{}, err
 	}
@@ -251,6 +263,44 @@ func (c *Client) CreateComponentGroup(ctx context.Context, spaceID int, name str
 	return resp.ComponentGroup, nil
 }
 
+// UpdateComponentGroup renames and/or moves a component group (by g.ID)
+func (c *Client) UpdateComponentGroup(ctx context.Context, spaceID int, g ComponentGroup) (ComponentGroup, error) {
+	if c.token == "" {
+		return ComponentGroup{}, errors.New("token leer")
+	}
+	if g.ID == 0 {
+		return ComponentGroup{}, errors.New("component group id fehlt")
+	}
+	u := fmt.Sprintf(base+"/spaces/%d/component_groups/%d", spaceID, g.ID)
+	group := map[string]interface{}{"name": g.Name, "parent_id": nil}
+	if g.ParentID > 0 {
+		group["parent_id"] = g.ParentID
+	}
+	body, err := json.Marshal(map[string]interface{}{"component_group": group})
+	if err != nil {
+		return ComponentGroup{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(body))
+	if err != nil {
+		return ComponentGroup{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.token)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := c.http.Do(req)
+	if err != nil {
+		return ComponentGroup{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 && res.StatusCode != 201 {
+		return ComponentGroup{}, fmt.Errorf("component_groups.update status %s", res.Status)
+	}
+	var resp componentGroupResp
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return ComponentGroup{}, err
+	}
+	return resp.ComponentGroup, nil
+}
+
 // InternalTag represents an internal tag
 type InternalTag struct {
 	ID         int    `json:"id"`
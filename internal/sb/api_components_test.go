@@ -135,6 +135,47 @@ func TestListComponentGroupsAndCreate(t *testing.T) {
 	}
 }
 
+func TestComponentGroupHierarchy(t *testing.T) {
+	c := New("token")
+	// Create nested group
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var payload map[string]map[string]any
+		_ = json.NewDecoder(req.Body).Decode(&payload)
+		if payload["component_group"]["parent_id"] != float64(5) {
+			t.Fatalf("expected parent_id 5, got %+v", payload)
+		}
+		body := `{"component_group":{"id":6,"uuid":"u6","name":"child","parent_id":5,"parent_uuid":"u5"}}`
+		return &http.Response{StatusCode: 201, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+	})}
+	grp, err := c.CreateComponentGroupWithParent(context.Background(), 1, "child", 5)
+	if err != nil {
+		t.Fatalf("CreateComponentGroupWithParent error: %v", err)
+	}
+	if grp.ID != 6 || grp.ParentID != 5 || grp.ParentUUID != "u5" {
+		t.Fatalf("unexpected group: %+v", grp)
+	}
+	// Update (rename + move to root)
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPut || !strings.HasSuffix(req.URL.Path, "/v1/spaces/1/component_groups/6") {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		var payload map[string]map[string]any
+		_ = json.NewDecoder(req.Body).Decode(&payload)
+		if v, ok := payload["component_group"]["parent_id"]; !ok || v != nil {
+			t.Fatalf("expected explicit null parent_id, got %+v", payload)
+		}
+		body := `{"component_group":{"id":6,"uuid":"u6","name":"renamed"}}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+	})}
+	grp, err = c.UpdateComponentGroup(context.Background(), 1, ComponentGroup{ID: 6, Name: "renamed"})
+	if err != nil || grp.Name != "renamed" {
+		t.Fatalf("UpdateComponentGroup: %v %+v", err, grp)
+	}
+	if _, err := c.UpdateComponentGroup(context.Background(), 1, ComponentGroup{Name: "x"}); err == nil {
+		t.Fatalf("expected error without id")
+	}
+}
+
 func TestListInternalTagsAndCreate(t *testing.T) {
 	c := New("token")
 	// List
@@ -0,0 +1,216 @@
+package sb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Datasource represents a datasource definition (Management API /datasources endpoint)
+type Datasource struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// DatasourceEntry represents a key/value entry of a datasource (default dimension)
+type DatasourceEntry struct {
+	ID           int    `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Value        string `json:"value"`
+	DatasourceID int    `json:"datasource_id,omitempty"`
+}
+
+type datasourcesResp struct {
+	Datasources []Datasource `json:"datasources"`
+}
+
+type datasourceResp struct {
+	Datasource Datasource `json:"datasource"`
+}
+
+type datasourceEntriesResp struct {
+	DatasourceEntries []DatasourceEntry `json:"datasource_entries"`
+}
+
+type datasourceEntryResp struct {
+	DatasourceEntry DatasourceEntry `json:"datasource_entry"`
+}
+
+// Page sizes used when listing datasources and their entries
+const (
+	datasourcesPerPage       = 100
+	datasourceEntriesPerPage = 1000
+)
+
+// ListDatasources lists all datasources of a space, following pagination
+func (c *Client) ListDatasources(ctx context.Context, spaceID int) ([]Datasource, error) {
+	if c.token == "" {
+		return nil, errors.New("token leer")
+	}
+	var all []Datasource
+	for page := 1; ; page++ {
+		u := fmt.Sprintf(base+"/spaces/%d/datasources?page=%d&per_page=%d", spaceID, page, datasourcesPerPage)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", c.token)
+		req.Header.Add("Content-Type", "application/json")
+		res, err := c.http.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode != 200 {
+			res.Body.Close()
+			return nil, fmt.Errorf("datasources.list status %s", res.Status)
+		}
+		var payload datasourcesResp
+		err = json.NewDecoder(res.Body).Decode(&payload)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, payload.Datasources...)
+		if len(payload.Datasources) < datasourcesPerPage {
+			return all, nil
+		}
+	}
+}
+
+// CreateDatasource creates a datasource with the given name and slug
+func (c *Client) CreateDatasource(ctx context.Context, spaceID int, ds Datasource) (Datasource, error) {
+	if c.token == "" {
+		return Datasource{}, errors.New("token leer")
+	}
+	u := fmt.Sprintf(base+"/spaces/%d/datasources", spaceID)
+	payload := map[string]interface{}{"datasource": map[string]string{"name": ds.Name, "slug": ds.Slug}}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Datasource{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return Datasource{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.token)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := c.http.Do(req)
+	if err != nil {
+		return Datasource{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 && res.StatusCode != 201 {
+		return Datasource{}, fmt.Errorf("datasource.create status %s", res.Status)
+	}
+	var resp datasourceResp
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return Datasource{}, err
+	}
+	return resp.Datasource, nil
+}
+
+// ListDatasourceEntries lists all entries of a datasource, following pagination
+func (c *Client) ListDatasourceEntries(ctx context.Context, spaceID, datasourceID int) ([]DatasourceEntry, error) {
+	if c.token == "" {
+		return nil, errors.New("token leer")
+	}
+	var all []DatasourceEntry
+	for page := 1; ; page++ {
+		u := fmt.Sprintf(base+"/spaces/%d/datasource_entries?datasource_id=%d&page=%d&per_page=%d", spaceID, datasourceID, page, datasourceEntriesPerPage)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", c.token)
+		req.Header.Add("Content-Type", "application/json")
+		res, err := c.http.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode != 200 {
+			res.Body.Close()
+			return nil, fmt.Errorf("datasource_entries.list status %s", res.Status)
+		}
+		var payload datasourceEntriesResp
+		err = json.NewDecoder(res.Body).Decode(&payload)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, payload.DatasourceEntries...)
+		if len(payload.DatasourceEntries) < datasourceEntriesPerPage {
+			return all, nil
+		}
+	}
+}
+
+// CreateDatasourceEntry creates an entry in the datasource given by e.DatasourceID
+func (c *Client) CreateDatasourceEntry(ctx context.Context, spaceID int, e DatasourceEntry) (DatasourceEntry, error) {
+	if c.token == "" {
+		return DatasourceEntry{}, errors.New("token leer")
+	}
+	if e.DatasourceID == 0 {
+		return DatasourceEntry{}, errors.New("datasource id required")
+	}
+	u := fmt.Sprintf(base+"/spaces/%d/datasource_entries", spaceID)
+	payload := map[string]interface{}{"datasource_entry": map[string]interface{}{"name": e.Name, "value": e.Value, "datasource_id": e.DatasourceID}}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return DatasourceEntry{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return DatasourceEntry{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.token)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := c.http.Do(req)
+	if err != nil {
+		return DatasourceEntry{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 && res.StatusCode != 201 {
+		return DatasourceEntry{}, fmt.Errorf("datasource_entry.create status %s", res.Status)
+	}
+	var resp datasourceEntryResp
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return DatasourceEntry{}, err
+	}
+	return resp.DatasourceEntry, nil
+}
+
+// UpdateDatasourceEntry updates name and value of an existing entry by ID
+func (c *Client) UpdateDatasourceEntry(ctx context.Context, spaceID int, e DatasourceEntry) error {
+	if c.token == "" {
+		return errors.New("token leer")
+	}
+	if e.ID == 0 {
+		return errors.New("datasource entry id required")
+	}
+	u := fmt.Sprintf(base+"/spaces/%d/datasource_entries/%d", spaceID, e.ID)
+	payload := map[string]interface{}{"datasource_entry": map[string]string{"name": e.Name, "value": e.Value}}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.token)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	// The Management API answers entry updates with 204 No Content
+	if res.StatusCode != 200 && res.StatusCode != 204 {
+		return fmt.Errorf("datasource_entry.update status %s", res.Status)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package sb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListDatasourcesPaginates(t *testing.T) {
+	c := New("token")
+	pages := 0
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/v1/spaces/3/datasources") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		pages++
+		n := datasourcesPerPage
+		if req.URL.Query().Get("page") == "2" {
+			n = 1
+		}
+		datasources := make([]Datasource, n)
+		for i := range datasources {
+			datasources[i] = Datasource{ID: i + 1, Name: fmt.Sprintf("ds%d", i), Slug: fmt.Sprintf("ds-%d", i)}
+		}
+		b, _ := json.Marshal(datasourcesResp{Datasources: datasources})
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(string(b))), Header: make(http.Header)}, nil
+	})}
+	got, err := c.ListDatasources(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("ListDatasources error: %v", err)
+	}
+	if pages != 2 || len(got) != datasourcesPerPage+1 {
+		t.Fatalf("expected 2 pages and %d datasources, got %d pages, %d datasources", datasourcesPerPage+1, pages, len(got))
+	}
+}
+
+func TestListDatasourceEntriesPaginates(t *testing.T) {
+	c := New("token")
+	pages := 0
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/v1/spaces/3/datasource_entries") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		if got := req.URL.Query().Get("datasource_id"); got != "7" {
+			t.Fatalf("unexpected datasource_id: %s", got)
+		}
+		pages++
+		n := datasourceEntriesPerPage
+		if req.URL.Query().Get("page") == "2" {
+			n = 1
+		}
+		entries := make([]DatasourceEntry, n)
+		for i := range entries {
+			entries[i] = DatasourceEntry{ID: i + 1, Name: fmt.Sprintf("k%d", i), Value: "v"}
+		}
+		b, _ := json.Marshal(datasourceEntriesResp{DatasourceEntries: entries})
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(string(b))), Header: make(http.Header)}, nil
+	})}
+	got, err := c.ListDatasourceEntries(context.Background(), 3, 7)
+	if err != nil {
+		t.Fatalf("ListDatasourceEntries error: %v", err)
+	}
+	if pages != 2 || len(got) != datasourceEntriesPerPage+1 {
+		t.Fatalf("expected 2 pages and %d entries, got %d pages, %d entries", datasourceEntriesPerPage+1, pages, len(got))
+	}
+}
+
+func TestCreateDatasourceEntry(t *testing.T) {
+	c := New("token")
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost {
+			t.Fatalf("want POST, got %s", req.Method)
+		}
+		b, _ := io.ReadAll(req.Body)
+		var payload struct {
+			Entry DatasourceEntry `json:"datasource_entry"`
+		}
+		if err := json.Unmarshal(b, &payload); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if payload.Entry.Name != "red" || payload.Entry.Value != "#f00" || payload.Entry.DatasourceID != 7 {
+			t.Fatalf("unexpected payload: %s", string(b))
+		}
+		res := `{"datasource_entry":{"id":11,"name":"red","value":"#f00","datasource_id":7}}`
+		return &http.Response{StatusCode: 201, Body: io.NopCloser(strings.NewReader(res)), Header: make(http.Header)}, nil
+	})}
+	got, err := c.CreateDatasourceEntry(context.Background(), 3, DatasourceEntry{Name: "red", Value: "#f00", DatasourceID: 7})
+	if err != nil {
+		t.Fatalf("CreateDatasourceEntry error: %v", err)
+	}
+	if got.ID != 11 {
+		t.Fatalf("unexpected entry: %+v", got)
+	}
+}
@@ -0,0 +1,69 @@
+package sb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Release represents a Storyblok release (staged changes merged later)
+type Release struct {
+	ID        int    `json:"id"`
+	UUID      string `json:"uuid,omitempty"`
+	Name      string `json:"name"`
+	ReleaseAt string `json:"release_at,omitempty"`
+	Released  bool   `json:"released"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+type releasesResp struct {
+	Releases []Release `json:"releases"`
+}
+
+type releaseResp struct {
+	Release Release `json:"release"`
+}
+
+// ListReleases lists the releases of a space
+func (c *Client) ListReleases(ctx context.Context, spaceID int) ([]Release, error) {
+	var payload releasesResp
+	if err := c.getJSON(ctx, fmt.Sprintf(base+"/spaces/%d/releases", spaceID), "releases.list", &payload); err != nil {
+		return nil, err
+	}
+	return payload.Releases, nil
+}
+
+// CreateRelease creates a new, unscheduled release
+func (c *Client) CreateRelease(ctx context.Context, spaceID int, name string) (Release, error) {
+	if c.token == "" {
+		return Release{}, errors.New("token leer")
+	}
+	u := fmt.Sprintf(base+"/spaces/%d/releases", spaceID)
+	payload := map[string]interface{}{"release": map[string]string{"name": name}}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Release{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return Release{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.token)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := c.http.Do(req)
+	if err != nil {
+		return Release{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 && res.StatusCode != 201 {
+		return Release{}, fmt.Errorf("releases.create status %s", res.Status)
+	}
+	var resp releaseResp
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return Release{}, err
+	}
+	return resp.Release, nil
+}
@@ -0,0 +1,69 @@
+package sb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListAndCreateReleases(t *testing.T) {
+	c := New("token")
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/v1/spaces/1/releases") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		if req.Method == http.MethodPost {
+			b, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(b), `"name":"Sync 1"`) {
+				t.Fatalf("unexpected payload: %s", string(b))
+			}
+			res := `{"release":{"id":8,"name":"Sync 1"}}`
+			return &http.Response{StatusCode: 201, Body: io.NopCloser(strings.NewReader(res)), Header: make(http.Header)}, nil
+		}
+		body := `{"releases":[{"id":7,"name":"Spring","released":false}]}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+	})}
+	rels, err := c.ListReleases(context.Background(), 1)
+	if err != nil || len(rels) != 1 || rels[0].ID != 7 || rels[0].Name != "Spring" {
+		t.Fatalf("ListReleases = %+v, %v", rels, err)
+	}
+	rel, err := c.CreateRelease(context.Background(), 1, "Sync 1")
+	if err != nil || rel.ID != 8 {
+		t.Fatalf("CreateRelease = %+v, %v", rel, err)
+	}
+}
+
+func TestStoryWritesCarryReleaseID(t *testing.T) {
+	var payloads []map[string]interface{}
+	c := New("token")
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		b, _ := io.ReadAll(req.Body)
+		var p map[string]interface{}
+		if err := json.Unmarshal(b, &p); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		payloads = append(payloads, p)
+		res := `{"story":{"id":5,"full_slug":"a"}}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(res)), Header: make(http.Header)}, nil
+	})}
+	ctx := WithReleaseID(context.Background(), 8)
+	story := map[string]interface{}{"full_slug": "a"}
+	if _, err := c.CreateStoryRawWithPublish(ctx, 1, story, false); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := c.UpdateStoryRawWithPublish(ctx, 1, 5, story, false); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, err := c.UpdateStoryRawWithPublish(context.Background(), 1, 5, story, false); err != nil {
+		t.Fatalf("update live: %v", err)
+	}
+	if payloads[0]["release_id"] != float64(8) || payloads[1]["release_id"] != float64(8) {
+		t.Fatalf("expected release_id in release writes: %+v", payloads[:2])
+	}
+	if _, ok := payloads[2]["release_id"]; ok {
+		t.Fatalf("live write must not carry release_id")
+	}
+}
@@ -0,0 +1,87 @@
+package sb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Tag represents a story tag (Management API /tags endpoint)
+type Tag struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	TaggingsCount int    `json:"taggings_count"`
+}
+
+type tagsResp struct {
+	Tags []Tag `json:"tags"`
+}
+
+type tagResp struct {
+	Tag Tag `json:"tag"`
+}
+
+// ListTags lists the story tags of a space
+func (c *Client) ListTags(ctx context.Context, spaceID int) ([]Tag, error) {
+	if c.token == "" {
+		return nil, errors.New("token leer")
+	}
+	u := fmt.Sprintf(base+"/spaces/%d/tags", spaceID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.token)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("tags.list status %s", res.Status)
+	}
+	var payload tagsResp
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+	return payload.Tags, nil
+}
+
+// CreateTag creates a story tag in a space
+func (c *Client) CreateTag(ctx context.Context, spaceID int, name string) (Tag, error) {
+	if c.token == "" {
+		return Tag{}, errors.New("token leer")
+	}
+	u := fmt.Sprintf(base+"/spaces/%d/tags", spaceID)
+	payload := map[string]interface{}{"tag": map[string]string{"name": name}}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Tag{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return Tag{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.token)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := c.http.Do(req)
+	if err != nil {
+		return Tag{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 && res.StatusCode != 201 {
+		return Tag{}, fmt.Errorf("tags.create status %s", res.Status)
+	}
+	var resp tagResp
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return Tag{}, err
+	}
+	if resp.Tag.Name == "" {
+		resp.Tag.Name = name
+	}
+	return resp.Tag, nil
+}
@@ -0,0 +1,64 @@
+package sb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListTags(t *testing.T) {
+	c := New("token")
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			t.Fatalf("want GET, got %s", req.Method)
+		}
+		if !strings.HasSuffix(req.URL.Path, "/v1/spaces/3/tags") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		body := `{"tags":[{"id":1,"name":"news","taggings_count":4}]}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+	})}
+	tags, err := c.ListTags(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("ListTags error: %v", err)
+	}
+	if len(tags) != 1 || tags[0].Name != "news" || tags[0].TaggingsCount != 4 {
+		t.Fatalf("unexpected tags: %+v", tags)
+	}
+}
+
+func TestCreateTag(t *testing.T) {
+	c := New("token")
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost {
+			t.Fatalf("want POST, got %s", req.Method)
+		}
+		if !strings.HasSuffix(req.URL.Path, "/v1/spaces/3/tags") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		b, _ := io.ReadAll(req.Body)
+		var payload struct {
+			Tag struct {
+				Name string `json:"name"`
+			} `json:"tag"`
+		}
+		if err := json.Unmarshal(b, &payload); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if payload.Tag.Name != "news" {
+			t.Fatalf("unexpected payload: %s", string(b))
+		}
+		res := `{"tag":{"id":9,"name":"news"}}`
+		return &http.Response{StatusCode: 201, Body: io.NopCloser(strings.NewReader(res)), Header: make(http.Header)}, nil
+	})}
+	got, err := c.CreateTag(context.Background(), 3, "news")
+	if err != nil {
+		t.Fatalf("CreateTag error: %v", err)
+	}
+	if got.ID != 9 || got.Name != "news" {
+		t.Fatalf("unexpected tag: %+v", got)
+	}
+}
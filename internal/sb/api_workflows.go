@@ -0,0 +1,141 @@
+package sb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Workflow represents a space workflow
+type Workflow struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	IsDefault    bool     `json:"is_default"`
+	ContentTypes []string `json:"content_types,omitempty"`
+}
+
+// WorkflowStage represents a stage of a workflow
+type WorkflowStage struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	WorkflowID    int    `json:"workflow_id"`
+	Position      int    `json:"position"`
+	IsDefault     bool   `json:"is_default"`
+	AllowPublish  bool   `json:"allow_publish"`
+	AllowAllUsers bool   `json:"allow_all_users"`
+	UserIDs       []int  `json:"user_ids,omitempty"`
+	SpaceRoleIDs  []int  `json:"space_role_ids,omitempty"`
+}
+
+// WorkflowStageChange records a story entering a workflow stage
+type WorkflowStageChange struct {
+	ID              int    `json:"id"`
+	WorkflowStageID int    `json:"workflow_stage_id"`
+	StoryID         int    `json:"story_id"`
+	CreatedAt       string `json:"created_at,omitempty"`
+}
+
+type workflowsResp struct {
+	Workflows []Workflow `json:"workflows"`
+}
+
+type workflowStagesResp struct {
+	WorkflowStages []WorkflowStage `json:"workflow_stages"`
+}
+
+type workflowStageChangesResp struct {
+	WorkflowStageChanges []WorkflowStageChange `json:"workflow_stage_changes"`
+}
+
+type workflowStageChangeResp struct {
+	WorkflowStageChange WorkflowStageChange `json:"workflow_stage_change"`
+}
+
+// ListWorkflows lists the workflows of a space
+func (c *Client) ListWorkflows(ctx context.Context, spaceID int) ([]Workflow, error) {
+	var payload workflowsResp
+	if err := c.getJSON(ctx, fmt.Sprintf(base+"/spaces/%d/workflows", spaceID), "workflows.list", &payload); err != nil {
+		return nil, err
+	}
+	return payload.Workflows, nil
+}
+
+// ListWorkflowStages lists the workflow stages of a space (all workflows)
+func (c *Client) ListWorkflowStages(ctx context.Context, spaceID int) ([]WorkflowStage, error) {
+	var payload workflowStagesResp
+	if err := c.getJSON(ctx, fmt.Sprintf(base+"/spaces/%d/workflow_stages", spaceID), "workflow_stages.list", &payload); err != nil {
+		return nil, err
+	}
+	return payload.WorkflowStages, nil
+}
+
+// ListWorkflowStageChanges lists the stage changes of a story, newest first
+func (c *Client) ListWorkflowStageChanges(ctx context.Context, spaceID, storyID int) ([]WorkflowStageChange, error) {
+	u, _ := url.Parse(fmt.Sprintf(base+"/spaces/%d/workflow_stage_changes", spaceID))
+	q := u.Query()
+	q.Set("with_story", fmt.Sprint(storyID))
+	u.RawQuery = q.Encode()
+	var payload workflowStageChangesResp
+	if err := c.getJSON(ctx, u.String(), "workflow_stage_changes.list", &payload); err != nil {
+		return nil, err
+	}
+	return payload.WorkflowStageChanges, nil
+}
+
+// CreateWorkflowStageChange moves a story into the given workflow stage
+func (c *Client) CreateWorkflowStageChange(ctx context.Context, spaceID, storyID, stageID int) (WorkflowStageChange, error) {
+	if c.token == "" {
+		return WorkflowStageChange{}, errors.New("token leer")
+	}
+	u := fmt.Sprintf(base+"/spaces/%d/workflow_stage_changes", spaceID)
+	payload := map[string]interface{}{"workflow_stage_change": map[string]int{"story_id": storyID, "workflow_stage_id": stageID}}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return WorkflowStageChange{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return WorkflowStageChange{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.token)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := c.http.Do(req)
+	if err != nil {
+		return WorkflowStageChange{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 && res.StatusCode != 201 {
+		return WorkflowStageChange{}, fmt.Errorf("workflow_stage_changes.create status %s", res.Status)
+	}
+	var resp workflowStageChangeResp
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return WorkflowStageChange{}, err
+	}
+	return resp.WorkflowStageChange, nil
+}
+
+// getJSON performs an authorized GET and decodes a 200 response into out.
+func (c *Client) getJSON(ctx context.Context, u, op string, out interface{}) error {
+	if c.token == "" {
+		return errors.New("token leer")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.token)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		return fmt.Errorf("%s status %s", op, res.Status)
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
@@ -0,0 +1,77 @@
+package sb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListWorkflowStages(t *testing.T) {
+	c := New("token")
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/v1/spaces/1/workflow_stages") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		body := `{"workflow_stages":[{"id":3,"name":"Review","workflow_id":1,"position":2,"allow_all_users":true}]}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+	})}
+	stages, err := c.ListWorkflowStages(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListWorkflowStages error: %v", err)
+	}
+	if len(stages) != 1 || stages[0].Name != "Review" || stages[0].WorkflowID != 1 || !stages[0].AllowAllUsers {
+		t.Fatalf("unexpected stages: %+v", stages)
+	}
+}
+
+func TestListWorkflowStageChanges_FiltersByStory(t *testing.T) {
+	c := New("token")
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if got := req.URL.Query().Get("with_story"); got != "42" {
+			t.Fatalf("with_story = %q", got)
+		}
+		body := `{"workflow_stage_changes":[{"id":9,"workflow_stage_id":3,"story_id":42}]}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+	})}
+	changes, err := c.ListWorkflowStageChanges(context.Background(), 1, 42)
+	if err != nil {
+		t.Fatalf("ListWorkflowStageChanges error: %v", err)
+	}
+	if len(changes) != 1 || changes[0].WorkflowStageID != 3 {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestCreateWorkflowStageChange(t *testing.T) {
+	c := New("token")
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost {
+			t.Fatalf("want POST, got %s", req.Method)
+		}
+		b, _ := io.ReadAll(req.Body)
+		var payload struct {
+			Change struct {
+				StoryID int `json:"story_id"`
+				StageID int `json:"workflow_stage_id"`
+			} `json:"workflow_stage_change"`
+		}
+		if err := json.Unmarshal(b, &payload); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if payload.Change.StoryID != 42 || payload.Change.StageID != 5 {
+			t.Fatalf("unexpected payload: %s", string(b))
+		}
+		res := `{"workflow_stage_change":{"id":10,"workflow_stage_id":5,"story_id":42}}`
+		return &http.Response{StatusCode: 201, Body: io.NopCloser(strings.NewReader(res)), Header: make(http.Header)}, nil
+	})}
+	got, err := c.CreateWorkflowStageChange(context.Background(), 1, 42, 5)
+	if err != nil {
+		t.Fatalf("CreateWorkflowStageChange error: %v", err)
+	}
+	if got.ID != 10 || got.WorkflowStageID != 5 {
+		t.Fatalf("unexpected change: %+v", got)
+	}
+}
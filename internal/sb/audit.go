@@ -0,0 +1,212 @@
+package sb
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"storyblok-sync/internal/infra/audit"
+	"storyblok-sync/internal/infra/logx"
+)
+
+// ---------- Users ----------
+
+// User is the owner of a Management API token.
+type User struct {
+	ID           int    `json:"id"`
+	Email        string `json:"email"`
+	FriendlyName string `json:"friendly_name"`
+}
+
+// CurrentUser returns the owner of the client's token (/users/me).
+func (c *Client) CurrentUser(ctx context.Context) (User, error) {
+	if c.token == "" {
+		return User{}, errors.New("token leer")
+	}
+	return fetchCurrentUser(ctx, c.http.Do, c.token)
+}
+
+func fetchCurrentUser(ctx context.Context, do func(*http.Request) (*http.Response, error), token string) (User, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/users/me", nil)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", token)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := do(req)
+	if err != nil {
+		return User{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		return User{}, fmt.Errorf("users.me status %s", res.Status)
+	}
+	var payload struct {
+		User User `json:"user"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		return User{}, err
+	}
+	return payload.User, nil
+}
+
+// ---------- Audit ----------
+
+// auditTransport records every POST/PUT/DELETE in the audit sink. It wraps
+// the retrying transport, so a write is recorded once with its final outcome.
+type auditTransport struct {
+	next http.RoundTripper
+}
+
+// auditOwner caches the token owner lookup, once per token and process.
+type auditOwner struct {
+	once sync.Once
+	user User
+}
+
+var auditOwners sync.Map // token -> *auditOwner
+
+func (a *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !audit.Enabled() || (req.Method != http.MethodPost && req.Method != http.MethodPut && req.Method != http.MethodDelete) {
+		return a.next.RoundTrip(req)
+	}
+	payload := requestPayload(req)
+	rec := auditRecord(req, payload)
+	start := time.Now()
+	resp, err := a.next.RoundTrip(req)
+	rec.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		rec.Error = err.Error()
+	} else {
+		rec.Status = resp.StatusCode
+		if resp.Body != nil {
+			data, rerr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(data))
+			if rerr == nil && resp.StatusCode < 300 {
+				applyAuditObject(&rec, data)
+			}
+		}
+	}
+	// the response knows the written object best; the payload fills the rest
+	applyAuditObject(&rec, payload)
+	if token := req.Header.Get("Authorization"); token != "" {
+		owner := a.owner(req.Context(), token)
+		rec.OwnerID = owner.ID
+		rec.Owner = owner.Email
+		if rec.Owner == "" {
+			rec.Owner = owner.FriendlyName
+		}
+	}
+	audit.Write(rec)
+	return resp, err
+}
+
+// owner resolves the token owner on the first write of each token.
+func (a *auditTransport) owner(ctx context.Context, token string) User {
+	v, _ := auditOwners.LoadOrStore(token, &auditOwner{})
+	o := v.(*auditOwner)
+	o.once.Do(func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		u, err := fetchCurrentUser(ctx, a.next.RoundTrip, token)
+		if err != nil {
+			// keep the records flowing; the token hash still identifies the writer
+			logx.Warnf("AUDIT owner lookup failed: %v", err)
+			return
+		}
+		o.user = u
+	})
+	return o.user
+}
+
+// requestPayload returns a copy of the request body without consuming it.
+func requestPayload(req *http.Request) []byte {
+	if _, err := ensureGetBody(req); err != nil || req.GetBody == nil {
+		return nil
+	}
+	rc, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	return data
+}
+
+// auditRecord describes a write from its URL, token, payload hash and the
+// sync source attached to its context.
+func auditRecord(req *http.Request, payload []byte) audit.Record {
+	rec := audit.Record{Method: req.Method, Endpoint: strings.TrimPrefix(req.URL.Path, "/v1")}
+	if token := req.Header.Get("Authorization"); token != "" {
+		sum := sha256.Sum256([]byte(token))
+		rec.TokenHash = hex.EncodeToString(sum[:])[:16]
+	}
+	src := SourceFrom(req.Context())
+	rec.SourceSpaceID, rec.SourceStoryID, rec.SourceFullSlug = src.SpaceID, src.StoryID, src.FullSlug
+	if len(payload) > 0 {
+		sum := sha256.Sum256(payload)
+		rec.PayloadSHA = hex.EncodeToString(sum[:])
+	}
+	// /spaces/<id>/<resource>/<object id>/...
+	parts := strings.Split(strings.Trim(rec.Endpoint, "/"), "/")
+	if len(parts) >= 2 && parts[0] == "spaces" {
+		rec.SpaceID, _ = strconv.Atoi(parts[1])
+		if len(parts) >= 3 {
+			rec.Resource = parts[2]
+		}
+		if len(parts) >= 4 {
+			rec.ObjectID, _ = strconv.Atoi(parts[3])
+		}
+	}
+	return rec
+}
+
+// applyAuditObject fills identifiers from a {"<resource>": {...}} JSON body,
+// keeping values already known.
+func applyAuditObject(rec *audit.Record, data []byte) {
+	var wrap map[string]json.RawMessage
+	if len(data) == 0 || json.Unmarshal(data, &wrap) != nil {
+		return
+	}
+	keys := make([]string, 0, len(wrap))
+	for k := range wrap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var obj struct {
+			ID       int    `json:"id"`
+			UUID     string `json:"uuid"`
+			FullSlug string `json:"full_slug"`
+			Name     string `json:"name"`
+		}
+		if json.Unmarshal(wrap[k], &obj) != nil || (obj.ID == 0 && obj.UUID == "" && obj.FullSlug == "" && obj.Name == "") {
+			continue
+		}
+		if rec.ObjectID == 0 {
+			rec.ObjectID = obj.ID
+		}
+		if rec.UUID == "" {
+			rec.UUID = obj.UUID
+		}
+		if rec.FullSlug == "" {
+			rec.FullSlug = obj.FullSlug
+		}
+		if rec.Name == "" {
+			rec.Name = obj.Name
+		}
+		return
+	}
+}
@@ -0,0 +1,106 @@
+package sb
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"storyblok-sync/internal/infra/audit"
+)
+
+func TestAuditTransportRecordsWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := audit.NewFileSink(path, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	audit.SetSink(sink)
+	defer audit.Close()
+
+	var gotBody string
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		status, body := 200, `{"stories":[]}`
+		switch {
+		case req.URL.Path == "/v1/users/me":
+			body = `{"user":{"id":42,"email":"editor@example.com"}}`
+		case req.Method == http.MethodPost:
+			data, _ := io.ReadAll(req.Body)
+			gotBody = string(data)
+			status, body = 201, `{"story":{"id":900,"uuid":"u-900","full_slug":"de/home","name":"Home"}}`
+		}
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+	})
+	c := New("secret-token")
+	c.http = &http.Client{Transport: &auditTransport{next: next}}
+
+	payload := `{"story":{"name":"Home","slug":"home"},"publish":1}`
+	ctx := WithSource(context.Background(), Source{SpaceID: 3, StoryID: 300, FullSlug: "de/home"})
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, base+"/spaces/7/stories", strings.NewReader(payload))
+	req.Header.Set("Authorization", c.token)
+	res, err := c.http.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	if !strings.Contains(string(data), `"id":900`) || gotBody != payload {
+		t.Fatalf("transport must pass bodies through untouched: req=%q res=%q", gotBody, data)
+	}
+	if _, err := c.ListStories(context.Background(), ListStoriesOpts{SpaceID: 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var recs []audit.Record
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var r audit.Record
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+	}
+	if len(recs) != 1 {
+		t.Fatalf("expected only the write to be recorded, got %+v", recs)
+	}
+	r := recs[0]
+	sum := sha256.Sum256([]byte(payload))
+	if r.Method != "POST" || r.SpaceID != 7 || r.Resource != "stories" || r.Endpoint != "/spaces/7/stories" || r.Status != 201 {
+		t.Fatalf("unexpected request fields %+v", r)
+	}
+	if r.ObjectID != 900 || r.UUID != "u-900" || r.FullSlug != "de/home" || r.Name != "Home" {
+		t.Fatalf("unexpected object fields %+v", r)
+	}
+	if r.SourceSpaceID != 3 || r.SourceStoryID != 300 || r.SourceFullSlug != "de/home" {
+		t.Fatalf("unexpected source fields %+v", r)
+	}
+	if r.PayloadSHA != hex.EncodeToString(sum[:]) {
+		t.Fatalf("payload hash = %s", r.PayloadSHA)
+	}
+	if r.OwnerID != 42 || r.Owner != "editor@example.com" || r.TokenHash == "" {
+		t.Fatalf("unexpected owner fields %+v", r)
+	}
+	if raw, _ := os.ReadFile(path); strings.Contains(string(raw), "secret-token") {
+		t.Fatal("audit log must not contain the token")
+	}
+}
+
+func TestAuditRecordParsesObjectIDFromPath(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodDelete, base+"/spaces/3/components/55", nil)
+	r := auditRecord(req, nil)
+	if r.SpaceID != 3 || r.Resource != "components" || r.ObjectID != 55 || r.PayloadSHA != "" {
+		t.Fatalf("unexpected record %+v", r)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"storyblok-sync/internal/infra/logx"
 	"strings"
+	"time"
 )
 
 const base = "https://mapi.storyblok.com/v1"
@@ -27,7 +28,7 @@ func New(token string) *Client {
 	opts := DefaultTransportOptionsFromEnv()
 	rt := NewRetryingLimiterTransport(opts)
 	return &Client{
-		http:    &http.Client{Transport: rt, Timeout: 0}, // rely on per-request contexts
+		http:    &http.Client{Transport: &auditTransport{next: rt}, Timeout: 0}, // rely on per-request contexts
 		token:   token,
 		metrics: opts.Metrics,
 	}
@@ -37,7 +38,7 @@ func New(token string) *Client {
 func NewWithOptions(token string, opts TransportOptions) *Client {
 	rt := NewRetryingLimiterTransport(opts)
 	return &Client{
-		http:    &http.Client{Transport: rt, Timeout: 0},
+		http:    &http.Client{Transport: &auditTransport{next: rt}, Timeout: 0},
 		token:   token,
 		metrics: opts.Metrics,
 	}
@@ -107,9 +108,10 @@ type spacesResp struct {
 
 // ---------- Space Details ----------
 type SpaceDetails struct {
-	ID      int          `json:"id"`
-	Name    string       `json:"name"`
-	Options SpaceOptions `json:"options"`
+	ID       int          `json:"id"`
+	Name     string       `json:"name"`
+	Timezone string       `json:"timezone,omitempty"` // IANA name; empty means UTC
+	Options  SpaceOptions `json:"options"`
 }
 
 type SpaceOptions struct {
@@ -203,6 +205,7 @@ type Story struct {
 	IsStartpage               bool             `json:"is_startpage"`
 	Position                  int              `json:"position"`
 	TagList                   []string         `json:"tag_list,omitempty"`
+	PublishAt                 string           `json:"publish_at,omitempty"`
 	TranslatedSlugs           []TranslatedSlug `json:"translated_slugs,omitempty"`
 	TranslatedSlugsAttributes []TranslatedSlug `json:"translated_slugs_attributes,omitempty"`
 }
@@ -218,6 +221,11 @@ type ListStoriesOpts struct {
 	SpaceID int
 	Page    int
 	PerPage int // 0 => Default 50
+	// ContainComponent restricts results to stories using this component (contain_component)
+	ContainComponent string
+	// UpdatedAfter restricts results to stories updated after this time
+	// (updated_at_gt, minute precision); zero => all stories
+	UpdatedAfter time.Time
 	// Optional später: by content type, folder, etc.
 }
 
@@ -239,6 +247,12 @@ func (c *Client) ListStories(ctx context.Context, opt ListStoriesOpts) ([]Story,
 		q := u.Query()
 		q.Set("page", fmt.Sprint(page))
 		q.Set("per_page", fmt.Sprint(opt.PerPage))
+		if opt.ContainComponent != "" {
+			q.Set("contain_component", opt.ContainComponent)
+		}
+		if !opt.UpdatedAfter.IsZero() {
+			q.Set("updated_at_gt", opt.UpdatedAfter.UTC().Format("2006-01-02 15:04"))
+		}
 		u.RawQuery = q.Encode()
 
 		req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
@@ -309,7 +323,8 @@ func (c *Client) CreateStory(ctx context.Context, spaceID int, st Story) (Story,
 		return Story{}, errors.New("token leer")
 	}
 	u := fmt.Sprintf(base+"/spaces/%d/stories", spaceID)
-	payload := storyResp{Story: st}
+	payload := map[string]interface{}{"story": st}
+	applyReleaseID(ctx, payload)
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return Story{}, err
@@ -351,6 +366,7 @@ func (c *Client) UpdateStory(ctx context.Context, spaceID int, st Story, publish
 	if !st.IsFolder && publish {
 		payload["publish"] = 1
 	}
+	applyReleaseID(ctx, payload)
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return Story{}, err
@@ -399,6 +415,7 @@ func (c *Client) CreateStoryWithPublish(ctx context.Context, spaceID int, st Sto
 	if !st.IsFolder && publish {
 		payload["publish"] = 1
 	}
+	applyReleaseID(ctx, payload)
 
 	// DEBUG: Keep only compact info before marshalling
 	log.Printf("DEBUG: Creating story: full_slug=%s is_folder=%t published=%t content_present=%t",
@@ -609,6 +626,7 @@ func (c *Client) CreateStoryRawWithPublish(ctx context.Context, spaceID int, sto
 			payload["publish"] = 1
 		}
 	}
+	applyReleaseID(ctx, payload)
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return Story{}, err
@@ -654,6 +672,7 @@ func (c *Client) UpdateStoryRawWithPublish(ctx context.Context, spaceID int, sto
 			payload["publish"] = 1
 		}
 	}
+	applyReleaseID(ctx, payload)
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return Story{}, err
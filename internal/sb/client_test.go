@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 type roundTripFunc func(*http.Request) (*http.Response, error)
@@ -80,6 +81,43 @@ func TestListStoriesPagination(t *testing.T) {
 	}
 }
 
+func TestListStoriesContainComponent(t *testing.T) {
+	c := New("token")
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if got := req.URL.Query().Get("contain_component"); got != "hero" {
+			t.Fatalf("expected contain_component=hero, got %q", got)
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"stories":[{"id":1,"name":"a"}]}`)),
+			Header:     make(http.Header),
+		}, nil
+	})}
+	stories, err := c.ListStories(context.Background(), ListStoriesOpts{SpaceID: 1, ContainComponent: "hero"})
+	if err != nil || len(stories) != 1 {
+		t.Fatalf("unexpected result: %v %+v", err, stories)
+	}
+}
+
+func TestListStoriesUpdatedAfter(t *testing.T) {
+	c := New("token")
+	c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if got := req.URL.Query().Get("updated_at_gt"); got != "2026-03-01 09:30" {
+			t.Fatalf("expected updated_at_gt=2026-03-01 09:30, got %q", got)
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"stories":[{"id":1,"name":"a"}]}`)),
+			Header:     make(http.Header),
+		}, nil
+	})}
+	since := time.Date(2026, 3, 1, 10, 30, 45, 0, time.FixedZone("CET", 3600))
+	stories, err := c.ListStories(context.Background(), ListStoriesOpts{SpaceID: 1, UpdatedAfter: since})
+	if err != nil || len(stories) != 1 {
+		t.Fatalf("unexpected result: %v %+v", err, stories)
+	}
+}
+
 func TestPublishFlagNumericUpdateStory(t *testing.T) {
 	tests := []struct {
 		publish bool
@@ -6,6 +6,10 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"storyblok-sync/internal/infra/metricsx"
 )
 
 // Metrics holds lightweight counters for HTTP activity.
@@ -19,20 +23,39 @@ type Metrics struct {
 	ReadRequests  atomic.Int64 // GET
 	WriteRequests atomic.Int64 // POST/PUT/PATCH/DELETE
 
-	mu         sync.Mutex
-	hostCounts map[string]int64
-	status2xx  int64
-	status3xx  int64
-	status4xx  int64
-	status429  int64
-	status5xx  int64
+	mu           sync.Mutex
+	hostCounts   map[string]int64
+	methodCounts map[hostMethod]int64
+	status2xx    int64
+	status3xx    int64
+	status4xx    int64
+	status429    int64
+	status5xx    int64
+
+	// parent also receives every update; clients come and go during a
+	// session, the process totals behind /metrics must not
+	parent *Metrics
 }
 
+type hostMethod struct{ host, method string }
+
+// processMetrics aggregates the metrics of all clients for the exporter.
+var processMetrics = newMetrics(nil)
+
+func init() { metricsx.Registry.MustRegister(processCollector{processMetrics}) }
+
 // NewMetrics creates a new metrics collector.
-func NewMetrics() *Metrics { return &Metrics{hostCounts: make(map[string]int64)} }
+func NewMetrics() *Metrics { return newMetrics(processMetrics) }
+
+func newMetrics(parent *Metrics) *Metrics {
+	return &Metrics{hostCounts: make(map[string]int64), methodCounts: make(map[hostMethod]int64), parent: parent}
+}
 
 // IncRequest increments per-host and total request counters.
 func (m *Metrics) IncRequest(host, method string) {
+	if m.parent != nil {
+		m.parent.IncRequest(host, method)
+	}
 	m.TotalRequests.Add(1)
 	switch strings.ToUpper(method) {
 	case http.MethodGet:
@@ -42,17 +65,31 @@ func (m *Metrics) IncRequest(host, method string) {
 	}
 	m.mu.Lock()
 	m.hostCounts[host]++
+	m.methodCounts[hostMethod{host, strings.ToUpper(method)}]++
 	m.mu.Unlock()
 }
 
 // IncRetry increments retry counter.
-func (m *Metrics) IncRetry() { m.TotalRetries.Add(1) }
+func (m *Metrics) IncRetry() {
+	if m.parent != nil {
+		m.parent.IncRetry()
+	}
+	m.TotalRetries.Add(1)
+}
 
 // AddBackoff accumulates backoff sleep time.
-func (m *Metrics) AddBackoff(d time.Duration) { m.TotalBackoffNanos.Add(d.Nanoseconds()) }
+func (m *Metrics) AddBackoff(d time.Duration) {
+	if m.parent != nil {
+		m.parent.AddBackoff(d)
+	}
+	m.TotalBackoffNanos.Add(d.Nanoseconds())
+}
 
 // IncStatus tracks status buckets.
 func (m *Metrics) IncStatus(code int) {
+	if m.parent != nil {
+		m.parent.IncStatus(code)
+	}
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	if code == 429 {
@@ -113,3 +150,41 @@ func (m *Metrics) Snapshot() MetricsSnapshot {
 		Status5xx:         m.status5xx,
 	}
 }
+
+// processCollector exports the process totals to Prometheus at scrape time.
+type processCollector struct{ m *Metrics }
+
+var (
+	requestsDesc = prometheus.NewDesc("sbsync_http_requests_total",
+		"HTTP requests sent, by host and method (retries excluded).", []string{"host", "method"}, nil)
+	responsesDesc = prometheus.NewDesc("sbsync_http_responses_total",
+		"HTTP responses received, by status class (429 counted separately).", []string{"class"}, nil)
+	retriesDesc = prometheus.NewDesc("sbsync_http_retries_total",
+		"HTTP attempts retried after 429/5xx responses.", nil, nil)
+	backoffDesc = prometheus.NewDesc("sbsync_http_backoff_seconds_total",
+		"Time slept in retry backoff.", nil, nil)
+)
+
+func (c processCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- requestsDesc
+	ch <- responsesDesc
+	ch <- retriesDesc
+	ch <- backoffDesc
+}
+
+func (c processCollector) Collect(ch chan<- prometheus.Metric) {
+	m := c.m
+	m.mu.Lock()
+	for k, n := range m.methodCounts {
+		ch <- prometheus.MustNewConstMetric(requestsDesc, prometheus.CounterValue, float64(n), k.host, k.method)
+	}
+	for _, s := range []struct {
+		class string
+		n     int64
+	}{{"2xx", m.status2xx}, {"3xx", m.status3xx}, {"4xx", m.status4xx}, {"429", m.status429}, {"5xx", m.status5xx}} {
+		ch <- prometheus.MustNewConstMetric(responsesDesc, prometheus.CounterValue, float64(s.n), s.class)
+	}
+	m.mu.Unlock()
+	ch <- prometheus.MustNewConstMetric(retriesDesc, prometheus.CounterValue, float64(m.TotalRetries.Load()))
+	ch <- prometheus.MustNewConstMetric(backoffDesc, prometheus.CounterValue, time.Duration(m.TotalBackoffNanos.Load()).Seconds())
+}
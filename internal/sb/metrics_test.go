@@ -5,6 +5,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"storyblok-sync/internal/infra/metricsx"
 )
 
 func TestNewMetrics(t *testing.T) {
@@ -441,3 +443,38 @@ func TestMetrics_RealisticUsage(t *testing.T) {
 		}
 	}
 }
+
+func TestMetrics_ExportAggregatesClients(t *testing.T) {
+	before := processMetrics.TotalRetries.Load()
+	a, b := NewMetrics(), NewMetrics()
+	a.IncRequest("mapi.storyblok.com", http.MethodPut)
+	b.IncRequest("mapi.storyblok.com", http.MethodPut)
+	a.IncRetry()
+	b.IncStatus(429)
+	if got := processMetrics.TotalRetries.Load() - before; got != 1 {
+		t.Fatalf("process retries grew by %d, want 1", got)
+	}
+
+	families, err := metricsx.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var put, limited float64 = -1, -1
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			switch {
+			case f.GetName() == "sbsync_http_requests_total" && labels["host"] == "mapi.storyblok.com" && labels["method"] == "PUT":
+				put = m.GetCounter().GetValue()
+			case f.GetName() == "sbsync_http_responses_total" && labels["class"] == "429":
+				limited = m.GetCounter().GetValue()
+			}
+		}
+	}
+	if put < 2 || limited < 1 {
+		t.Fatalf("expected both clients in the process totals, got put=%v 429=%v", put, limited)
+	}
+}
@@ -0,0 +1,33 @@
+package sb
+
+import "context"
+
+// releaseCtxKey is an unexported key type for storing the target release in context.
+type releaseCtxKey struct{}
+
+// WithReleaseID attaches a release ID to the context. Story writes issued with
+// this context are staged in that release instead of the live content.
+func WithReleaseID(ctx context.Context, releaseID int) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, releaseCtxKey{}, releaseID)
+}
+
+// ReleaseIDFrom returns the release ID attached to the context (0 if none).
+func ReleaseIDFrom(ctx context.Context) int {
+	if ctx == nil {
+		return 0
+	}
+	if id, ok := ctx.Value(releaseCtxKey{}).(int); ok {
+		return id
+	}
+	return 0
+}
+
+// applyReleaseID adds release_id to a story write payload when the context carries one.
+func applyReleaseID(ctx context.Context, payload map[string]interface{}) {
+	if id := ReleaseIDFrom(ctx); id > 0 {
+		payload["release_id"] = id
+	}
+}
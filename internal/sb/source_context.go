@@ -0,0 +1,33 @@
+package sb
+
+import "context"
+
+// sourceCtxKey is an unexported key type for storing the sync source in context.
+type sourceCtxKey struct{}
+
+// Source identifies the source space and story a write was synced from.
+type Source struct {
+	SpaceID  int
+	StoryID  int
+	FullSlug string
+}
+
+// WithSource attaches the sync source to the context. Writes issued with this
+// context carry it into their audit records.
+func WithSource(ctx context.Context, src Source) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, sourceCtxKey{}, src)
+}
+
+// SourceFrom returns the sync source attached to the context (zero if none).
+func SourceFrom(ctx context.Context) Source {
+	if ctx == nil {
+		return Source{}
+	}
+	if src, ok := ctx.Value(sourceCtxKey{}).(Source); ok {
+		return src
+	}
+	return Source{}
+}
@@ -0,0 +1,97 @@
+package sb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"storyblok-sync/internal/infra/tracex"
+)
+
+type collectExporter struct{ spans []sdktrace.ReadOnlySpan }
+
+func (c *collectExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	c.spans = append(c.spans, spans...)
+	return nil
+}
+func (c *collectExporter) Shutdown(context.Context) error { return nil }
+
+func TestTransportTracesAttemptsAndWaits(t *testing.T) {
+	exp := &collectExporter{}
+	tracex.Install(exp)
+
+	tr := NewRetryingLimiterTransport(TransportOptions{
+		RetryMax:    2,
+		BackoffBase: 250 * time.Millisecond,
+		Clock:       newFakeClock(),
+		HostLimits:  map[string]Limit{"mapi.storyblok.com": {RPS: 1000, Burst: 1000}},
+	})
+	base := &fakeRT{queue: []any{
+		&http.Response{StatusCode: 503, Body: http.NoBody},
+		&http.Response{StatusCode: 200, Body: http.NoBody},
+	}}
+	var sent []string
+	tr.Base = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		sent = append(sent, r.Header.Get("traceparent"))
+		return base.RoundTrip(r)
+	})
+	ctx, root := tracex.Start(context.Background(), "sync.item")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://mapi.storyblok.com/v1/spaces/1/stories", nil)
+	if _, err := tr.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	root.End()
+	tracex.Shutdown()
+
+	var item sdktrace.ReadOnlySpan
+	for _, s := range exp.spans {
+		if s.Name() == "sync.item" {
+			item = s
+		}
+	}
+	if item == nil {
+		t.Fatal("item span not exported")
+	}
+	count := map[string]int{}
+	for _, s := range exp.spans {
+		count[s.Name()]++
+		if s.SpanContext().TraceID() != item.SpanContext().TraceID() {
+			t.Fatalf("span %s is not part of the item trace", s.Name())
+		}
+		if s != item && s.Parent().SpanID() != item.SpanContext().SpanID() {
+			t.Fatalf("span %s should be a child of the item span", s.Name())
+		}
+	}
+	if count["http.attempt"] != 2 || count["http.limiter_wait"] != 2 || count["http.backoff"] != 1 {
+		t.Fatalf("unexpected spans %v", count)
+	}
+	for _, s := range exp.spans {
+		if s.Name() != "http.attempt" {
+			continue
+		}
+		failed := hasAttr(s, "http.response.status_code", 503)
+		if failed != (s.Status().Code == codes.Error) || (!failed && !hasAttr(s, "http.response.status_code", 200)) {
+			t.Fatalf("unexpected attempt span %+v (status %+v)", s.Attributes(), s.Status())
+		}
+	}
+	if req.Header.Get("traceparent") != "" {
+		t.Fatal("caller's request was modified")
+	}
+	if len(sent) != 2 || sent[0] == "" || sent[0] == sent[1] {
+		t.Fatalf("expected one traceparent per attempt, got %q", sent)
+	}
+}
+
+func hasAttr(s sdktrace.ReadOnlySpan, key string, v int64) bool {
+	for _, a := range s.Attributes() {
+		if a.Key == attribute.Key(key) && a.Value.AsInt64() == v {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"math"
 	"math/rand"
@@ -16,6 +17,7 @@ import (
 	"time"
 
 	"storyblok-sync/internal/infra/logx"
+	"storyblok-sync/internal/infra/tracex"
 )
 
 // Clock abstracts time for deterministic tests.
@@ -271,7 +273,11 @@ func (t *RetryingLimiterTransport) RoundTrip(req *http.Request) (*http.Response,
 	var lastErr error
 	for attempt := 0; attempt < attempts; attempt++ {
 		// rate limit per attempt
-		if err := lim.Wait(req.Context()); err != nil {
+		_, waitSpan := tracex.Start(req.Context(), "http.limiter_wait", tracex.String("server.address", req.URL.Host))
+		err := lim.Wait(req.Context())
+		waitSpan.SetError(err)
+		waitSpan.End()
+		if err != nil {
 			return nil, err
 		}
 
@@ -284,7 +290,26 @@ func (t *RetryingLimiterTransport) RoundTrip(req *http.Request) (*http.Response,
 			req.Body = body
 		}
 
-		resp, err := t.base().RoundTrip(req)
+		actx, span := tracex.Start(req.Context(), "http.attempt",
+			tracex.String("http.request.method", req.Method),
+			tracex.String("url.path", req.URL.Path),
+			tracex.String("server.address", req.URL.Host),
+			tracex.Int("attempt", attempt))
+		areq := req
+		if span != nil {
+			// the attempt's traceparent goes on a copy; the caller's request stays untouched
+			areq = req.Clone(actx)
+			tracex.Inject(actx, areq.Header)
+		}
+		resp, err := t.base().RoundTrip(areq)
+		span.SetError(err)
+		if err == nil {
+			span.SetAttrs(tracex.Int("http.response.status_code", resp.StatusCode))
+			if resp.StatusCode >= 400 {
+				span.SetError(fmt.Errorf("http status %d", resp.StatusCode))
+			}
+		}
+		span.End()
 		if err != nil {
 			// Retry on transient network errors
 			if isTransientNetErr(err) && attempt < attempts-1 {
@@ -293,7 +318,7 @@ func (t *RetryingLimiterTransport) RoundTrip(req *http.Request) (*http.Response,
 					rc.Total++
 					rc.Net++
 				}
-				t.sleepBackoff(attempt)
+				t.traceBackoff(req, "network_error", func() { t.sleepBackoff(attempt) })
 				// adaptive: back off slightly on network errors
 				lim.adjustRPS(-0.1, 1, t.maxRPSForHost(req.URL.Host))
 				logx.Warnf("HTTP net-error retry-backoff err=%v method=%s path=%s attempt=%d", err, req.Method, req.URL.Path, attempt)
@@ -336,12 +361,12 @@ func (t *RetryingLimiterTransport) RoundTrip(req *http.Request) (*http.Response,
 				// adaptive: back off RPS slightly on 429/5xx
 				lim.adjustRPS(-0.3, 1, t.maxRPSForHost(req.URL.Host))
 				resp.Body.Close()
-				t.clock().Sleep(minDur(ra, t.Opts.BackoffCap))
+				t.traceBackoff(req, "retry_after", func() { t.clock().Sleep(minDur(ra, t.Opts.BackoffCap)) })
 				continue
 			}
 			// Otherwise exponential backoff with jitter
 			resp.Body.Close()
-			t.sleepBackoff(attempt)
+			t.traceBackoff(req, "exponential", func() { t.sleepBackoff(attempt) })
 			if resp.StatusCode == 429 {
 				logx.Warnf("HTTP 429 retry-backoff method=%s path=%s attempt=%d", req.Method, req.URL.Path, attempt)
 			} else if resp.StatusCode >= 500 {
@@ -371,6 +396,15 @@ func (t *RetryingLimiterTransport) RoundTrip(req *http.Request) (*http.Response,
 	return nil, lastErr
 }
 
+// traceBackoff runs sleep inside an "http.backoff" span.
+func (t *RetryingLimiterTransport) traceBackoff(req *http.Request, reason string, sleep func()) {
+	_, span := tracex.Start(req.Context(), "http.backoff",
+		tracex.String("reason", reason),
+		tracex.String("url.path", req.URL.Path))
+	sleep()
+	span.End()
+}
+
 func (t *RetryingLimiterTransport) sleepBackoff(attempt int) {
 	base := t.Opts.BackoffBase
 	if base <= 0 {
@@ -105,6 +105,9 @@ func (m Model) handleBrowseActions(key string) (Model, tea.Cmd) {
 	case "r":
 		// Rescan stories
 		m.state = stateScanning
+		if m.currentMode == modeCombined {
+			return m, m.scanCombinedCmd()
+		}
 		return m, m.scanStoriesCmd()
 	case "s":
 		// Start sync
@@ -113,6 +116,9 @@ func (m Model) handleBrowseActions(key string) (Model, tea.Cmd) {
 			return m, nil
 		}
 		m.startPreflight()
+		if m.currentMode == modeCombined && m.state == statePreflight {
+			return m, m.resolveCombinedComponents()
+		}
 		return m, nil
 	}
 	return m, nil
@@ -42,7 +42,6 @@ func (m Model) toggleFolderSelection(st sb.Story) {
 	for _, story := range m.storiesSource {
 		if story.FullSlug == prefix || (len(story.FullSlug) > len(prefix) &&
 			story.FullSlug[:len(prefix)] == prefix &&
-			len(story.FullSlug) > len(prefix) &&
 			story.FullSlug[len(prefix)] == '/') {
 			m.selection.selected[story.FullSlug] = mark
 		}
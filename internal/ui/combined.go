@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/infra/logx"
+	"storyblok-sync/internal/sb"
+)
+
+// combinedScanMsg carries the stories and components scans of a combined run
+type combinedScanMsg struct {
+	stories scanMsg
+	comps   compScanMsg
+}
+
+// combinedComponentsMsg carries the components used by the selected stories
+type combinedComponentsMsg struct {
+	gen   int
+	names []string
+	err   error
+}
+
+// scanCombinedCmd scans stories and components of both spaces in one go.
+func (m Model) scanCombinedCmd() tea.Cmd {
+	storiesCmd := m.scanStoriesCmd()
+	compsCmd := m.scanComponentsCmd()
+	return func() tea.Msg {
+		st, _ := storiesCmd().(scanMsg)
+		if st.err != nil {
+			return combinedScanMsg{stories: st}
+		}
+		cs, _ := compsCmd().(compScanMsg)
+		return combinedScanMsg{stories: st, comps: cs}
+	}
+}
+
+func (m Model) handleCombinedScanResult(msg combinedScanMsg) (tea.Model, tea.Cmd) {
+	if msg.stories.err == nil && msg.comps.err != nil {
+		m.statusMsg = "Component-Scan-Fehler: " + msg.comps.err.Error()
+		m.state = stateModePicker
+		return m, nil
+	}
+	if msg.stories.err == nil {
+		m, _ = m.handleCompScanResult(msg.comps)
+	}
+	// Stories part behaves exactly like a stories-only scan
+	model, cmd := m.Update(msg.stories)
+	if mm, ok := model.(Model); ok && mm.state == stateBrowseList {
+		mm.statusMsg = fmt.Sprintf("Scan ok. Source: %d Stories, %d Components. Target: %d Stories, %d Components.",
+			len(mm.storiesSource), len(mm.componentsSource), len(mm.storiesTarget), len(mm.componentsTarget))
+		return mm, cmd
+	}
+	return model, cmd
+}
+
+// resolveCombinedComponents starts deriving the components used by the
+// stories in the current preflight. It runs again whenever skips change, so
+// the components section only lists what the remaining stories use.
+func (m *Model) resolveCombinedComponents() tea.Cmd {
+	m.compPre = CompPreflightState{input: m.compPre.input, forceUpdateAll: m.compPre.forceUpdateAll}
+	m.combinedResolving = true
+	m.combinedGen++
+	m.updateViewportContent()
+	return m.usedComponentsCmd()
+}
+
+// usedComponentsCmd loads the content of all selected preflight stories and
+// collects the components they reference.
+func (m Model) usedComponentsCmd() tea.Cmd {
+	srcID := 0
+	if m.sourceSpace != nil {
+		srcID = m.sourceSpace.ID
+	}
+	token := m.cfg.Token
+	gen := m.combinedGen
+	var stories []sb.Story
+	for _, it := range m.preflight.items {
+		if it.Story.IsFolder || !it.Selected || it.Skip {
+			continue
+		}
+		stories = append(stories, it.Story)
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		c := sb.New(token)
+		seen := make(map[string]bool)
+		for _, st := range stories {
+			full, err := c.GetStoryWithContent(ctx, srcID, st.ID)
+			if err != nil {
+				return combinedComponentsMsg{gen: gen, err: fmt.Errorf("source story %s: %w", st.FullSlug, err)}
+			}
+			if full.ContentType == "" {
+				full.ContentType = st.ContentType
+			}
+			for _, n := range sync.CollectStoryComponents(full) {
+				seen[n] = true
+			}
+		}
+		names := make([]string, 0, len(seen))
+		for n := range seen {
+			names = append(names, n)
+		}
+		return combinedComponentsMsg{gen: gen, names: names}
+	}
+}
+
+// applyCombinedComponents builds the components section of the combined preflight.
+func (m *Model) applyCombinedComponents(msg combinedComponentsMsg) {
+	if msg.gen != m.combinedGen {
+		// a later resolve (after a skip change) is still running
+		return
+	}
+	m.combinedResolving = false
+	if msg.err != nil {
+		m.statusMsg = "Components konnten nicht ermittelt werden: " + msg.err.Error()
+		return
+	}
+	known := make(map[string]bool, len(m.componentsSource))
+	for _, c := range m.componentsSource {
+		known[c.Name] = true
+	}
+	selected := make(map[string]bool, len(msg.names))
+	var missing []string
+	for _, n := range msg.names {
+		if known[n] {
+			selected[n] = true
+		} else {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) > 0 {
+		logx.Warnf("COMBINED components not found in source: %s", strings.Join(missing, ", "))
+	}
+	m.compPre.items = m.buildCompPreflightItems(selected)
+	m.compPre.listIndex = 0
+	m.statusMsg = fmt.Sprintf("Preflight: %d Stories, %d Components (Kollisionen: %d)",
+		len(m.preflight.items), len(m.compPre.items), countCompCollisions(m.compPre.items))
+}
+
+// startCombinedSync applies the components first; stories follow once all
+// component items are done (see compItemDoneMsg).
+func (m Model) startCombinedSync() (Model, tea.Cmd) {
+	if m.combinedResolving {
+		m.statusMsg = "Components werden noch ermittelt…"
+		return m, nil
+	}
+	pending := 0
+	for _, it := range m.compPre.items {
+		if !it.Skip {
+			pending++
+		}
+	}
+	if pending == 0 {
+		return m.beginStorySync(true)
+	}
+	if m.api == nil {
+		m.api = sb.New(m.cfg.Token)
+	}
+	m.lastSnapTime = time.Now()
+	m.lastSnap = m.api.MetricsSnapshot()
+	m.state = stateCompSync
+	m.syncing = true
+	m.statusMsg = fmt.Sprintf("Phase 1/2: %d Components…", pending)
+	m.updateViewportContent()
+	return m, tea.Batch(m.startCompApply(), m.spinner.Tick, m.statsTick())
+}
+
+// renderCombinedCompSection renders the components section shown above the
+// stories in the combined preflight. Returns nil outside combined mode.
+func (m Model) renderCombinedCompSection() []string {
+	if m.currentMode != modeCombined {
+		return nil
+	}
+	lines := []string{listHeaderStyle.Render("Components")}
+	switch {
+	case m.combinedResolving:
+		lines = append(lines, "  "+m.spinner.View()+" "+subtleStyle.Render("ermittle verwendete Components…"))
+	case len(m.compPre.items) == 0:
+		lines = append(lines, "  "+subtleStyle.Render("keine"))
+	default:
+		for _, it := range m.compPre.items {
+			stateCell := stateStyles[it.State].Render(stateLabel(it.State))
+			line := " " + stateCell + fmt.Sprintf(" %s %s", symbolComp, it.Source.Name)
+			if it.Issue != "" {
+				line += " " + subtleStyle.Render("("+it.Issue+")")
+			}
+			lines = append(lines, line)
+		}
+	}
+	lines = append(lines, "", listHeaderStyle.Render("Stories"))
+	return lines
+}
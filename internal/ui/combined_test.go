@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"storyblok-sync/internal/report"
+	"storyblok-sync/internal/sb"
+)
+
+func TestModePickerSelectsCombined(t *testing.T) {
+	m := InitialModel()
+	m.state = stateModePicker
+	m, _ = m.handleModePickerKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m, _ = m.handleModePickerKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m, _ = m.handleModePickerKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if m.modePickerIndex != 2 {
+		t.Fatalf("expected index 2, got %d", m.modePickerIndex)
+	}
+	m, cmd := m.handleModePickerKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.currentMode != modeCombined || m.state != stateScanning || cmd == nil {
+		t.Fatalf("expected combined scan to start, got mode=%v state=%v", m.currentMode, m.state)
+	}
+}
+
+func newCombinedPreflightModel() Model {
+	st := sb.Story{ID: 1, Name: "one", Slug: "one", FullSlug: "one"}
+	m := InitialModel()
+	m.currentMode = modeCombined
+	m.storiesSource = []sb.Story{st}
+	m.rebuildStoryIndex()
+	m.applyFilter()
+	m.selection.selected[st.FullSlug] = true
+	m.componentsSource = []sb.Component{{ID: 1, Name: "page"}, {ID: 2, Name: "teaser", DisplayName: "changed"}, {ID: 3, Name: "unused"}}
+	m.componentsTarget = []sb.Component{{ID: 20, Name: "teaser"}}
+	m.startPreflight()
+	return m
+}
+
+func TestCombinedPreflightBuildsComponentSection(t *testing.T) {
+	m := newCombinedPreflightModel()
+	m.combinedResolving = true
+	if !strings.Contains(m.renderPreflightContent(), "ermittle") {
+		t.Fatalf("expected resolving hint in components section")
+	}
+	// Enter is refused while components are still being resolved
+	m, _ = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != statePreflight {
+		t.Fatalf("expected to stay in preflight while resolving, got %v", m.state)
+	}
+
+	m.applyCombinedComponents(combinedComponentsMsg{names: []string{"page", "teaser", "missing"}})
+	if len(m.compPre.items) != 2 {
+		t.Fatalf("expected only used source components, got %+v", m.compPre.items)
+	}
+	for _, it := range m.compPre.items {
+		if it.Source.Name == "teaser" && it.State != StateUpdate {
+			t.Fatalf("teaser should update existing target component: %+v", it)
+		}
+		if it.Source.Name == "page" && it.State != StateCreate {
+			t.Fatalf("page should be created: %+v", it)
+		}
+	}
+	out := m.renderPreflightContent()
+	if !strings.Contains(out, "Components") || !strings.Contains(out, "teaser") || !strings.Contains(out, "Stories") {
+		t.Fatalf("expected components and stories sections, got:\n%s", out)
+	}
+}
+
+func TestCombinedSyncRunsStoriesAfterComponents(t *testing.T) {
+	m := newCombinedPreflightModel()
+	m.applyCombinedComponents(combinedComponentsMsg{names: []string{"page"}})
+	m.report = *report.New("src", "tgt")
+	m.state = stateCompSync
+	m.syncing = true
+	m.compPre.items[0].Run = RunRunning
+
+	model, _ := m.Update(compItemDoneMsg{idx: 0, entry: compReportEntry{Name: "page", Operation: "create"}})
+	m = model.(Model)
+	if m.state != stateSync {
+		t.Fatalf("expected stories phase to start, got %v", m.state)
+	}
+	if len(m.report.Entries) != 1 || m.report.Entries[0].Slug != "page" {
+		t.Fatalf("expected component entry to be kept in the unified report, got %+v", m.report.Entries)
+	}
+}
+
+func TestCombinedSkipRecomputesUsedComponents(t *testing.T) {
+	m := newCombinedPreflightModel()
+	m.storiesTarget = []sb.Story{{ID: 9, Name: "one", Slug: "one", FullSlug: "one"}}
+	m.startPreflight()
+	m.applyCombinedComponents(combinedComponentsMsg{gen: m.combinedGen, names: []string{"page", "teaser"}})
+	if !m.preflight.items[0].Collision || len(m.compPre.items) != 2 {
+		t.Fatalf("unexpected setup: %+v / %+v", m.preflight.items, m.compPre.items)
+	}
+	stale := m.combinedGen
+
+	m, cmd := m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if !m.preflight.items[0].Skip || cmd == nil || !m.combinedResolving || len(m.compPre.items) != 0 {
+		t.Fatalf("expected skip to restart component resolution, resolving=%v items=%+v", m.combinedResolving, m.compPre.items)
+	}
+	// a result of the run before the skip must not bring the components back
+	m.applyCombinedComponents(combinedComponentsMsg{gen: stale, names: []string{"page", "teaser"}})
+	if !m.combinedResolving || len(m.compPre.items) != 0 {
+		t.Fatalf("stale result applied: %+v", m.compPre.items)
+	}
+	m.applyCombinedComponents(combinedComponentsMsg{gen: m.combinedGen})
+	if m.combinedResolving || len(m.compPre.items) != 0 {
+		t.Fatalf("expected no components for skipped stories, got %+v", m.compPre.items)
+	}
+
+	// X does not restart anything when all collisions are already skipped
+	m, cmd = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	if cmd != nil || m.combinedResolving {
+		t.Fatal("expected no recompute without skip changes")
+	}
+	m, _ = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m.applyCombinedComponents(combinedComponentsMsg{gen: m.combinedGen, names: []string{"page"}})
+	m, cmd = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	if cmd == nil || !m.combinedResolving {
+		t.Fatal("expected X to recompute components after new skips")
+	}
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -9,6 +10,7 @@ import (
 	comps "storyblok-sync/internal/core/componentsync"
 	synccore "storyblok-sync/internal/core/sync"
 	"storyblok-sync/internal/infra/logx"
+	"storyblok-sync/internal/report"
 	"storyblok-sync/internal/sb"
 	"strings"
 )
@@ -18,10 +20,40 @@ type compReportEntry struct {
 	Name       string
 	Operation  string
 	Err        string
+	Warning    string
 	DurationMs int64
 	Retry429   int
 	RetryTotal int
+	// Component IDs in the source and in the target before and after the write
+	SourceID       int
+	TargetIDBefore int
+	TargetID       int
 }
+
+// reportEntry converts a component result into a report entry.
+func (e compReportEntry) reportEntry() ReportEntry {
+	status := "success"
+	if e.Err != "" {
+		status = "failure"
+	} else if e.Warning != "" {
+		status = "warning"
+	}
+	return ReportEntry{
+		Kind:           report.KindComponent,
+		Slug:           e.Name,
+		Status:         status,
+		Operation:      e.Operation,
+		Duration:       e.DurationMs,
+		Error:          e.Err,
+		Warning:        e.Warning,
+		RateLimit429:   e.Retry429,
+		Retries:        e.RetryTotal,
+		SourceID:       e.SourceID,
+		TargetIDBefore: e.TargetIDBefore,
+		TargetIDAfter:  e.TargetID,
+	}
+}
+
 type compApplyDoneMsg struct {
 	entries []compReportEntry
 }
@@ -322,6 +354,8 @@ func (m *Model) startCompApply() tea.Cmd {
 		}
 		if it.CopyAsNew {
 			decisions[it.Source.Name] = comps.Decision{Action: "fork", ForkName: it.ForkName}
+		} else if it.Rename {
+			decisions[it.Source.Name] = comps.Decision{Action: "rename", RenameFrom: it.RenameFrom}
 		} else if it.Collision {
 			decisions[it.Source.Name] = comps.Decision{Action: "update"}
 		} else {
@@ -387,8 +421,9 @@ func (m *Model) startCompApply() tea.Cmd {
 
 // Single item command: performs create/update and returns a done message
 type compItemDoneMsg struct {
-	idx   int
-	entry compReportEntry
+	idx        int
+	entry      compReportEntry
+	renameFrom string // old component name still to be rewritten in target stories
 }
 
 func (m Model) runCompItemCmd(idx int) tea.Cmd {
@@ -486,7 +521,7 @@ func (m Model) runCompItemCmd(idx int) tea.Cmd {
 					}
 					logx.Infof("Presets in sync for %s — created: %d, updated: %d", mapped.Name, createdCount, updatedCount)
 					m.compLimiter.NudgeWrite(maps.tgtID, +0.02, 1, 7)
-					return compItemDoneMsg{idx: idx, entry: compReportEntry{Name: mapped.Name, Operation: "update", DurationMs: time.Since(start).Milliseconds(), Retry429: int(rc.Status429), RetryTotal: int(rc.Total)}}
+					return compItemDoneMsg{idx: idx, entry: compReportEntry{Name: mapped.Name, Operation: "update", TargetID: mapped.ID, DurationMs: time.Since(start).Milliseconds(), Retry429: int(rc.Status429), RetryTotal: int(rc.Total)}}
 				}
 				if synccore.IsRateLimited(err) {
 					m.compLimiter.NudgeWrite(maps.tgtID, -0.2, 1, 7)
@@ -507,7 +542,7 @@ func (m Model) runCompItemCmd(idx int) tea.Cmd {
 			}
 			logx.Infof("Presets in sync for %s — created: %d, updated: %d", mapped.Name, createdCount, 0)
 			m.compLimiter.NudgeWrite(maps.tgtID, +0.02, 1, 7)
-			return compItemDoneMsg{idx: idx, entry: compReportEntry{Name: mapped.Name, Operation: "create", DurationMs: time.Since(start).Milliseconds(), Retry429: int(rc.Status429), RetryTotal: int(rc.Total)}}
+			return compItemDoneMsg{idx: idx, entry: compReportEntry{Name: mapped.Name, Operation: "create", TargetID: createdComp.ID, DurationMs: time.Since(start).Milliseconds(), Retry429: int(rc.Status429), RetryTotal: int(rc.Total)}}
 		case "update":
 			mapped.ID = p.TargetID
 			_ = m.compLimiter.WaitWrite(ctx2, maps.tgtID)
@@ -542,13 +577,67 @@ func (m Model) runCompItemCmd(idx int) tea.Cmd {
 			}
 			logx.Infof("Presets in sync for %s — created: %d, updated: %d", mapped.Name, createdCount, updatedCount)
 			m.compLimiter.NudgeWrite(maps.tgtID, +0.02, 1, 7)
-			return compItemDoneMsg{idx: idx, entry: compReportEntry{Name: mapped.Name, Operation: "update", DurationMs: time.Since(start).Milliseconds(), Retry429: int(rc.Status429), RetryTotal: int(rc.Total)}}
+			op := "update"
+			if p.RenameFrom != "" {
+				op = "rename"
+			}
+			return compItemDoneMsg{idx: idx, entry: compReportEntry{Name: mapped.Name, Operation: op, TargetID: mapped.ID, DurationMs: time.Since(start).Milliseconds(), Retry429: int(rc.Status429), RetryTotal: int(rc.Total)}, renameFrom: p.RenameFrom}
 		default:
 			return compItemDoneMsg{idx: idx, entry: compReportEntry{Name: comp.Name, Operation: "skip", DurationMs: time.Since(start).Milliseconds(), Retry429: int(rc.Status429), RetryTotal: int(rc.Total)}}
 		}
 	}
 }
 
+// newCompLimiter creates the write limiter for component syncs with the
+// target plan's default limits.
+func (m Model) newCompLimiter() *synccore.SpaceLimiter {
+	plan := 0
+	if m.targetSpace != nil {
+		plan = m.targetSpace.PlanLevel
+	}
+	r, w, b := synccore.DefaultLimitsForPlan(plan)
+	return synccore.NewSpaceLimiter(r, w, b)
+}
+
+// propagateCompRenameCmd rewrites a renamed component in all target stories
+// under the sync context and then reports the item as done.
+func (m Model) propagateCompRenameCmd(done compItemDoneMsg) tea.Cmd {
+	ctx := m.syncContext
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	api, tgtID := m.api, m.compMaps.tgtID
+	var lim comps.WriteLimiter
+	if m.compLimiter != nil {
+		lim = m.compLimiter
+	}
+	return func() tea.Msg {
+		start := time.Now()
+		rc := &sb.RetryCounters{}
+		done.entry.Warning = propagateCompRename(sb.WithRetryCounters(ctx, rc), api, lim, tgtID, done.renameFrom, done.entry.Name)
+		done.entry.DurationMs += time.Since(start).Milliseconds()
+		done.entry.Retry429 += int(rc.Status429)
+		done.entry.RetryTotal += int(rc.Total)
+		done.renameFrom = ""
+		return done
+	}
+}
+
+// propagateCompRename rewrites the renamed component in all target stories and
+// returns a warning for stories that could not be updated.
+func propagateCompRename(ctx context.Context, api *sb.Client, lim comps.WriteLimiter, tgtID int, oldName, newName string) string {
+	res, err := comps.PropagateRename(ctx, api, lim, tgtID, oldName, newName)
+	if err != nil {
+		logx.Errorf("COMP_RENAME %s→%s: %v", oldName, newName, err)
+		return "rename propagation failed: " + err.Error()
+	}
+	logx.Infof("COMP_RENAME %s→%s stories scanned=%d updated=%d failed=%d", oldName, newName, res.Scanned, res.Updated, len(res.Failed))
+	if len(res.Failed) > 0 {
+		return fmt.Sprintf("rename not applied to %d stories: %s", len(res.Failed), strings.Join(res.Failed, ", "))
+	}
+	return ""
+}
+
 func findTargetComponentIDByName(tgt []sb.Component, name string) int {
 	ln := strings.ToLower(name)
 	for _, c := range tgt {
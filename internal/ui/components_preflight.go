@@ -10,6 +10,19 @@ import (
 
 // startCompPreflight builds component preflight items from current selection
 func (m *Model) startCompPreflight() {
+	items := m.buildCompPreflightItems(m.comp.selected)
+	m.compPre.items = items
+	m.compPre.listIndex = 0
+	m.updateCompPreflightViewport()
+	if len(items) == 0 {
+		m.statusMsg = "Keine markierten Components – zurück mit 'm' oder 'q'"
+	} else {
+		m.statusMsg = fmt.Sprintf("Preflight: %d ausgewählt (Kollisionen: %d)", len(items), countCompCollisions(items))
+	}
+}
+
+// buildCompPreflightItems plans create/update/skip for the selected source components
+func (m *Model) buildCompPreflightItems(selected map[string]bool) []CompPreflightItem {
 	// Build target name -> ID map
 	tgtByName := make(map[string]int, len(m.componentsTarget))
 	for _, t := range m.componentsTarget {
@@ -32,7 +45,7 @@ func (m *Model) startCompPreflight() {
 	s2n, n2t := comps.BuildGroupNameMaps(m.componentGroupsSource, m.componentGroupsTarget)
 	items := make([]CompPreflightItem, 0, len(m.componentsSource))
 	for _, c := range m.componentsSource {
-		if !m.comp.selected[c.Name] {
+		if !selected[c.Name] {
 			continue
 		}
 		lower := strings.ToLower(c.Name)
@@ -60,13 +73,35 @@ func (m *Model) startCompPreflight() {
 		it.Run = RunPending
 		items = append(items, it)
 	}
-	m.compPre.items = items
-	m.compPre.listIndex = 0
-	m.updateCompPreflightViewport()
-	if len(items) == 0 {
-		m.statusMsg = "Keine markierten Components – zurück mit 'm' oder 'q'"
+	// Offer rename candidates for components that only exist in the source
+	candidates := make(map[string]comps.RenameCandidate)
+	for _, rc := range comps.DetectRenames(m.componentsSource, m.componentsTarget, comps.DefaultRenameThreshold) {
+		candidates[rc.Source.Name] = rc
+	}
+	for i := range items {
+		if rc, ok := candidates[items[i].Source.Name]; ok && !items[i].Collision {
+			items[i].RenameFrom = rc.Target.Name
+			items[i].RenameScore = rc.Score
+		}
+	}
+	return items
+}
+
+// toggleCompRename confirms or withdraws the rename candidate of an item.
+func (m *Model) toggleCompRename(i int) {
+	it := &m.compPre.items[i]
+	if it.RenameFrom == "" {
+		return
+	}
+	it.Rename = !it.Rename
+	it.CopyAsNew = false
+	it.Skip = false
+	if it.Rename {
+		it.State = StateUpdate
+		it.TargetID = findTargetComponentIDByName(m.componentsTarget, it.RenameFrom)
 	} else {
-		m.statusMsg = fmt.Sprintf("Preflight: %d ausgewählt (Kollisionen: %d)", len(items), countCompCollisions(items))
+		it.State = StateCreate
+		it.TargetID = 0
 	}
 }
 
@@ -99,6 +134,13 @@ func (m *Model) updateCompPreflightViewport() {
 			if it.Collision && !it.CopyAsNew {
 				suffix = subtleStyle.Render(" (overwrite)")
 			}
+			if it.RenameFrom != "" && !it.CopyAsNew {
+				if it.Rename {
+					suffix = okStyle.Render(" ← ") + it.RenameFrom + subtleStyle.Render(" (rename)")
+				} else {
+					suffix = subtleStyle.Render(fmt.Sprintf(" (rename von %s? %.0f%% – r)", it.RenameFrom, it.RenameScore*100))
+				}
+			}
 			if it.CopyAsNew {
 				fn := it.ForkName
 				if fn == "" {
@@ -113,5 +155,23 @@ func (m *Model) updateCompPreflightViewport() {
 	if len(lines) == 0 {
 		lines = append(lines, warnStyle.Render("Keine Items im Preflight."))
 	}
+	// Group tree differences are listed below the items (read-only)
+	if diffs := comps.DiffGroups(m.componentGroupsSource, m.componentGroupsTarget); len(diffs) > 0 {
+		lines = append(lines, "", listHeaderStyle.Render("Gruppen"))
+		for _, d := range diffs {
+			lines = append(lines, "  "+renderGroupDiff(d))
+		}
+	}
 	m.viewport.SetContent(strings.Join(lines, "\n"))
 }
+
+func renderGroupDiff(d comps.GroupDiff) string {
+	switch d.Kind {
+	case comps.GroupDiffCreate:
+		return stateStyles[StateCreate].Render(stateLabel(StateCreate)) + " " + d.Path
+	case comps.GroupDiffRename, comps.GroupDiffMove:
+		return stateStyles[StateUpdate].Render(stateLabel(StateUpdate)) + " " + d.From + okStyle.Render(" → ") + d.Path + subtleStyle.Render(" ("+d.Kind+")")
+	default:
+		return subtleStyle.Render("  " + d.Path + " (nur im Ziel)")
+	}
+}
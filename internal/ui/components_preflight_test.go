@@ -1,9 +1,12 @@
 package ui
 
 import (
+	"encoding/json"
 	"storyblok-sync/internal/sb"
 	"strings"
 	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestStartCompPreflight_ClassifiesCollisions(t *testing.T) {
@@ -152,3 +155,44 @@ func TestCompPreflight_ForceUpdateToggle(t *testing.T) {
 		t.Fatalf("expected back to skip, got %+v", it)
 	}
 }
+
+func TestCompPreflight_RenameCandidateConfirm(t *testing.T) {
+	m := InitialModel()
+	m.currentMode = modeComponents
+	m.state = stateCompPreflight
+	schema := json.RawMessage(`{"title":{"type":"text"},"image":{"type":"asset"}}`)
+	m.componentsSource = []sb.Component{{ID: 1, Name: "hero_banner", Schema: schema}}
+	m.componentsTarget = []sb.Component{{ID: 10, Name: "hero", Schema: schema}}
+	m.comp.selected = map[string]bool{"hero_banner": true}
+	m.startCompPreflight()
+	it := m.compPre.items[0]
+	if it.RenameFrom != "hero" || it.Rename || it.State != StateCreate {
+		t.Fatalf("expected unconfirmed rename candidate, got %+v", it)
+	}
+
+	m, _ = m.handleCompPreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	it = m.compPre.items[0]
+	if !it.Rename || it.State != StateUpdate || it.TargetID != 10 {
+		t.Fatalf("expected confirmed rename as update of target 10, got %+v", it)
+	}
+
+	m, _ = m.handleCompPreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if it = m.compPre.items[0]; it.Rename || it.State != StateCreate {
+		t.Fatalf("expected rename withdrawn, got %+v", it)
+	}
+}
+
+func TestCompPreflight_ShowsGroupDiffs(t *testing.T) {
+	m := InitialModel()
+	m.currentMode = modeComponents
+	m.state = stateCompPreflight
+	m.componentsSource = []sb.Component{{ID: 1, Name: "A"}}
+	m.componentGroupsSource = []sb.ComponentGroup{{ID: 1, UUID: "p", Name: "Layout"}, {ID: 2, UUID: "c", Name: "Teasers", ParentID: 1}}
+	m.componentGroupsTarget = []sb.ComponentGroup{{ID: 5, UUID: "x", Name: "Layout"}}
+	m.comp.selected = map[string]bool{"A": true}
+	m.startCompPreflight()
+	out := m.viewport.View()
+	if !strings.Contains(out, "Gruppen") || !strings.Contains(out, "Layout/Teasers") {
+		t.Fatalf("expected nested group diff in preflight, got:\n%s", out)
+	}
+}
@@ -18,6 +18,11 @@ type CompPreflightItem struct {
 	CopyAsNew bool
 	ForkName  string
 	Issue     string
+	// Rename detection: RenameFrom is a target component with a similar schema
+	// that is likely this component's previous name; Rename is set once confirmed.
+	RenameFrom  string
+	RenameScore float64
+	Rename      bool
 }
 
 type CompPreflightState struct {
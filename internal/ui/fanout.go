@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/core/tagsync"
+	"storyblok-sync/internal/sb"
+)
+
+// FanOutState holds the additional target spaces of a multi-target run. The
+// primary target stays in Model.targetSpace; merge conflicts are checked for
+// it only. A run with a release or workflow checks cannot fan out.
+type FanOutState struct {
+	marked   map[int]bool // target IDs marked in the space selection
+	targets  []sb.Space   // additional targets in space list order
+	stories  map[int][]sb.Story
+	tags     map[int]*tagsync.Reconciler
+	limiter  *sync.SpaceLimiter
+	expanded bool // preflight items were fanned out for the running sync
+}
+
+// fanOutActive reports whether the run writes to more than one target.
+func (m Model) fanOutActive() bool {
+	return len(m.fanOut.targets) > 0
+}
+
+// targetCount returns the number of target spaces of the run.
+func (m Model) targetCount() int {
+	return 1 + len(m.fanOut.targets)
+}
+
+// syncTargets returns the number of targets the current sync writes to.
+func (m Model) syncTargets() int {
+	if m.fanOut.expanded {
+		return m.targetCount()
+	}
+	return 1
+}
+
+// toggleTargetMark marks the space under the cursor as additional target.
+func (m *Model) toggleTargetMark(sp sb.Space) {
+	if m.fanOut.marked == nil {
+		m.fanOut.marked = make(map[int]bool)
+	}
+	if m.fanOut.marked[sp.ID] {
+		delete(m.fanOut.marked, sp.ID)
+	} else {
+		m.fanOut.marked[sp.ID] = true
+	}
+}
+
+// applyTargetSelection makes primary the run's target and all other marked
+// spaces additional targets.
+func (m *Model) applyTargetSelection(primary sb.Space) {
+	m.fanOut = FanOutState{marked: m.fanOut.marked}
+	for _, sp := range m.selectableSpaces() {
+		if sp.ID != primary.ID && m.fanOut.marked[sp.ID] {
+			m.fanOut.targets = append(m.fanOut.targets, sp)
+		}
+	}
+}
+
+// itemTarget returns the target space an item is written to.
+func (m *Model) itemTarget(it PreflightItem) *sb.Space {
+	for i := range m.fanOut.targets {
+		if m.fanOut.targets[i].ID == it.TargetSpaceID {
+			return &m.fanOut.targets[i]
+		}
+	}
+	return m.targetSpace
+}
+
+// isPrimaryTarget reports whether an item is written to the primary target.
+func (m *Model) isPrimaryTarget(it PreflightItem) bool {
+	return it.TargetSpaceID == 0 || m.targetSpace == nil || it.TargetSpaceID == m.targetSpace.ID
+}
+
+// targetStoriesFor returns the scanned stories of the item's target space.
+func (m *Model) targetStoriesFor(it PreflightItem) []sb.Story {
+	if m.isPrimaryTarget(it) {
+		return m.storiesTarget
+	}
+	return m.fanOut.stories[it.TargetSpaceID]
+}
+
+// upsertTargetStory keeps the story index of the item's target space fresh.
+func (m *Model) upsertTargetStory(it PreflightItem, st sb.Story) {
+	list := m.targetStoriesFor(it)
+	updated := false
+	for i := range list {
+		if list[i].FullSlug == st.FullSlug {
+			list[i] = st
+			updated = true
+			break
+		}
+	}
+	if !updated {
+		list = append(list, st)
+	}
+	if m.isPrimaryTarget(it) {
+		m.storiesTarget = list
+		return
+	}
+	if m.fanOut.stories == nil {
+		m.fanOut.stories = make(map[int][]sb.Story)
+	}
+	m.fanOut.stories[it.TargetSpaceID] = list
+}
+
+// reportTargetID returns the target space ID recorded on report entries of
+// fan-out runs (0 otherwise).
+func (m *Model) reportTargetID(it PreflightItem) int {
+	if !m.fanOut.expanded {
+		return 0
+	}
+	if sp := m.itemTarget(it); sp != nil {
+		return sp.ID
+	}
+	return 0
+}
+
+// runKey identifies an item across targets for per-item bookkeeping.
+func (m *Model) runKey(it PreflightItem) string {
+	if m.isPrimaryTarget(it) {
+		return it.Story.FullSlug
+	}
+	return fmt.Sprintf("%d:%s", it.TargetSpaceID, it.Story.FullSlug)
+}
+
+// expandFanOut copies the planned items for every additional target and sets
+// up the shared per-space limiter and tag reconcilers. Single-target runs get
+// the shared limiter too, so their rates are published as well.
+func (m *Model) expandFanOut() {
+	if m.fanOutActive() && m.targetSpace != nil {
+		m.preflight.items = sync.ExpandForTargets(m.preflight.items, m.storiesSource, m.targetSpace.ID, m.fanOut.targets, m.fanOut.stories)
+		m.preflight.visibleIdx = nil
+		m.fanOut.expanded = true
+	}
+	m.setupFanOutClients()
+}
+
+// setupFanOutClients creates the shared per-space limiter and the tag
+// reconcilers of the additional targets.
+func (m *Model) setupFanOutClients() {
+	if m.targetSpace == nil {
+		return
+	}
+	spaces := []sb.Space{*m.targetSpace}
+	if m.sourceSpace != nil {
+		spaces = append(spaces, *m.sourceSpace)
+	}
+	spaces = append(spaces, m.fanOut.targets...)
+	m.fanOut.limiter = sync.NewFanOutLimiter(spaces...)
+	m.fanOut.tags = make(map[int]*tagsync.Reconciler, len(m.fanOut.targets))
+	if m.api != nil {
+		for _, sp := range m.fanOut.targets {
+			m.fanOut.tags[sp.ID] = tagsync.NewReconciler(m.api, m.api, sp.ID)
+		}
+	}
+}
+
+// ensureLimiter creates the shared per-space limiter before the first sync,
+// so preflight checks draw from the same read budget as the sync.
+func (m *Model) ensureLimiter() {
+	if m.fanOut.limiter == nil {
+		m.setupFanOutClients()
+	}
+}
+
+// waitRead takes a read token of spaceID from lim (nil: unthrottled).
+func waitRead(ctx context.Context, lim *sync.SpaceLimiter, spaceID int) error {
+	if lim == nil {
+		return nil
+	}
+	return lim.WaitRead(ctx, spaceID)
+}
+
+// targetBadge names the target of an item in fan-out runs ("" otherwise).
+func (m *Model) targetBadge(it PreflightItem) string {
+	if !m.fanOut.expanded {
+		return ""
+	}
+	if sp := m.itemTarget(it); sp != nil {
+		return "[→" + sp.Name + "]"
+	}
+	return ""
+}
+
+// renderFanOutOverview summarizes the preflight for every target space.
+func (m Model) renderFanOutOverview() []string {
+	if !m.fanOutActive() || m.targetSpace == nil {
+		return nil
+	}
+	lines := []string{"", listHeaderStyle.Render(fmt.Sprintf("Ziele (%d)", m.targetCount()))}
+	row := func(sp sb.Space, stories []sb.Story, note string) string {
+		s := sync.SummarizeTarget(sp.ID, m.preflight.items, stories)
+		parts := []string{
+			fmt.Sprintf("%d neu", s.Create),
+			fmt.Sprintf("%d überschreiben", s.Update),
+			fmt.Sprintf("%d übersprungen", s.Skip),
+		}
+		if s.MissingFolders > 0 {
+			parts = append(parts, fmt.Sprintf("+%d Ordner", s.MissingFolders))
+		}
+		return fmt.Sprintf("  → %s (%d)%s: %s", sp.Name, sp.ID, note, strings.Join(parts, " · "))
+	}
+	lines = append(lines, row(*m.targetSpace, m.storiesTarget, " [primär]"))
+	for _, sp := range m.fanOut.targets {
+		lines = append(lines, row(sp, m.fanOut.stories[sp.ID], ""))
+	}
+	lines = append(lines, subtleStyle.Render("  Konflikte werden nur für das primäre Ziel geprüft; Release und Workflow-Prüfung sperren mehrere Ziele."))
+	return lines
+}
@@ -0,0 +1,210 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"storyblok-sync/internal/infra/metricsx"
+	"storyblok-sync/internal/report"
+	"storyblok-sync/internal/sb"
+)
+
+func TestSpaceSelectMarksFanOutTargets(t *testing.T) {
+	m := InitialModel()
+	m.state = stateSpaceSelect
+	m.spaces = []sb.Space{{ID: 1, Name: "src"}, {ID: 2, Name: "de"}, {ID: 3, Name: "at"}, {ID: 4, Name: "ch"}}
+	src := m.spaces[0]
+	m.sourceSpace = &src
+	m.selectingSource = false
+
+	press := func(k string) {
+		var msg tea.KeyMsg
+		if k == " " {
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+		} else {
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		model, _ := m.Update(msg)
+		m = model.(Model)
+	}
+	press(" ") // mark "de"
+	press("j")
+	press("j")
+	press(" ") // mark "ch"
+	if !strings.Contains(m.viewSpaceSelect(), "[x] ch") {
+		t.Fatalf("marked target not shown:\n%s", m.viewSpaceSelect())
+	}
+	press("k") // cursor on "at"
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+
+	if m.targetSpace == nil || m.targetSpace.ID != 3 {
+		t.Fatalf("expected primary target at (3), got %+v", m.targetSpace)
+	}
+	if len(m.fanOut.targets) != 2 || m.fanOut.targets[0].ID != 2 || m.fanOut.targets[1].ID != 4 {
+		t.Fatalf("expected additional targets de, ch: %+v", m.fanOut.targets)
+	}
+	if m.state != stateModePicker {
+		t.Fatalf("expected mode picker, got %v", m.state)
+	}
+}
+
+func TestFanOutSchedulesTargetsIndependently(t *testing.T) {
+	m := InitialModel()
+	folderID := 10
+	folder := sb.Story{ID: 10, Name: "app", Slug: "app", FullSlug: "app", IsFolder: true}
+	page := sb.Story{ID: 11, Name: "page", Slug: "page", FullSlug: "app/page", FolderID: &folderID}
+	m.storiesSource = []sb.Story{folder, page}
+	m.sourceSpace = &sb.Space{ID: 1, Name: "src"}
+	m.targetSpace = &sb.Space{ID: 2, Name: "de", PlanLevel: 1}
+	m.fanOut.targets = []sb.Space{{ID: 3, Name: "at", PlanLevel: 1}}
+	// "at" already has the folder, "de" has nothing yet
+	m.fanOut.stories = map[int][]sb.Story{3: {{ID: 90, FullSlug: "app", IsFolder: true}}}
+	m.preflight.items = []PreflightItem{
+		{Story: folder, Selected: true, State: StateCreate, Run: RunPending},
+		{Story: page, Selected: true, State: StateCreate, Run: RunPending},
+	}
+	m.api = sb.New("")
+	m.expandFanOut()
+
+	if len(m.preflight.items) != 4 {
+		t.Fatalf("expected items for both targets, got %d", len(m.preflight.items))
+	}
+	if m.fanOut.limiter == nil || m.syncTargets() != 2 {
+		t.Fatalf("expected shared limiter and two sync targets")
+	}
+	m.maxWorkers = 6
+
+	if cmd := m.runNextItem(); cmd == nil {
+		t.Fatal("expected a first item to be scheduled")
+	}
+	if cmd := m.runNextItem(); cmd == nil {
+		t.Fatal("expected a second item to be scheduled")
+	}
+	running := map[string]bool{}
+	for _, it := range m.preflight.items {
+		if it.Run == RunRunning {
+			running[m.runKey(it)] = true
+		}
+	}
+	// de runs its folder phase; at updates its folder; neither story may start yet
+	if !running["app"] || !running["3:app"] || len(running) != 2 {
+		t.Fatalf("expected both folders running, got %v", running)
+	}
+	if cmd := m.runNextItem(); cmd != nil {
+		t.Fatal("stories must wait for their target's folders")
+	}
+
+	// Once at's folder is done, at's story may start while de is still busy
+	for i := range m.preflight.items {
+		if m.runKey(m.preflight.items[i]) == "3:app" {
+			m.preflight.items[i].Run = RunDone
+		}
+	}
+	if cmd := m.runNextItem(); cmd == nil {
+		t.Fatal("expected at's story to be scheduled")
+	}
+	for _, it := range m.preflight.items {
+		if it.Story.FullSlug == "app/page" && it.Run == RunRunning && it.TargetSpaceID != 3 {
+			t.Fatalf("de story started during de folder phase: %+v", it)
+		}
+	}
+}
+
+func TestReportGroupsFanOutTargets(t *testing.T) {
+	m := InitialModel()
+	m.report = *report.New("src (1)", "de (2)")
+	m.report.Targets = []ReportTarget{{ID: 2, Name: "de"}, {ID: 3, Name: "at"}}
+	m.report.Add(ReportEntry{Slug: "home", Status: "success", Operation: "update", TargetSpaceID: 2})
+	m.report.Add(ReportEntry{Slug: "home", Status: "failure", Operation: "sync", Error: "boom", TargetSpaceID: 3})
+	m.report.Finalize()
+
+	out := m.renderReportContent()
+	de := strings.Index(out, "→ de (2) – 1 ok, 0 Warnungen, 0 Fehler")
+	at := strings.Index(out, "→ at (3) – 0 ok, 0 Warnungen, 1 Fehler")
+	if de < 0 || at < 0 || de > at {
+		t.Fatalf("expected report sections per target in order:\n%s", out)
+	}
+	if !strings.Contains(out[at:], "boom") {
+		t.Fatalf("failure must be listed under its target:\n%s", out)
+	}
+
+	// Retrying keeps the failed item on its target
+	m.storiesSource = []sb.Story{{ID: 5, FullSlug: "home"}}
+	m.fanOut.stories = map[int][]sb.Story{3: {{ID: 9, FullSlug: "home"}}}
+	m.targetSpace = &sb.Space{ID: 2, Name: "de"}
+	m.fanOut.targets = []sb.Space{{ID: 3, Name: "at"}}
+	items := m.getFailedItemsForRetry()
+	if len(items) != 1 || items[0].TargetSpaceID != 3 || !items[0].Collision {
+		t.Fatalf("retry item must target at and collide there: %+v", items)
+	}
+}
+
+func TestFanOutBlockedWithReleaseOrWorkflowChecks(t *testing.T) {
+	setup := func() Model {
+		m := InitialModel()
+		m.sourceSpace = &sb.Space{ID: 1, Name: "src"}
+		m.targetSpace = &sb.Space{ID: 2, Name: "de"}
+		m.fanOut.targets = []sb.Space{{ID: 3, Name: "at"}}
+		m.preflight.items = []PreflightItem{{Story: sb.Story{ID: 11, FullSlug: "page"}, Selected: true, State: StateCreate, Run: RunPending}}
+		return m
+	}
+
+	m := setup()
+	m.release = &sb.Release{ID: 7, Name: "Sommer"}
+	m, _ = m.beginStorySync(true)
+	if m.syncing || m.fanOut.expanded || len(m.preflight.items) != 1 || !strings.Contains(m.statusMsg, "Mehrere Ziele") {
+		t.Fatalf("release must block fan-out: syncing=%v items=%d status=%q", m.syncing, len(m.preflight.items), m.statusMsg)
+	}
+
+	m = setup()
+	m.workflowLockedStages = []string{"Review"}
+	m, _ = m.beginStorySync(true)
+	if m.syncing || m.fanOut.expanded {
+		t.Fatal("workflow checks must block fan-out")
+	}
+
+	m = setup()
+	m, _ = m.beginStorySync(true)
+	if !m.syncing || !m.fanOut.expanded || len(m.preflight.items) != 2 {
+		t.Fatalf("expected fan-out without release and workflow checks: %d items", len(m.preflight.items))
+	}
+	m.syncCancel()
+}
+
+func TestSingleTargetSyncPublishesLimiterRates(t *testing.T) {
+	m := InitialModel()
+	m.sourceSpace = &sb.Space{ID: 4341, Name: "src"}
+	m.targetSpace = &sb.Space{ID: 4343, Name: "tgt", PlanLevel: 1}
+	m.preflight.items = []PreflightItem{{Story: sb.Story{ID: 11, FullSlug: "page"}, Selected: true, State: StateCreate, Run: RunPending}}
+	m, _ = m.beginStorySync(true)
+	defer m.syncCancel()
+	if m.fanOut.limiter == nil {
+		t.Fatal("single-target sync must use the shared limiter")
+	}
+
+	got := map[string]float64{}
+	families, err := metricsx.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		if f.GetName() != "sbsync_space_limiter_rps" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["space_id"] == "4343" {
+				got[labels["kind"]] = m.GetGauge().GetValue()
+			}
+		}
+	}
+	if got["read"] != 7 || got["write"] != 7 {
+		t.Fatalf("expected published target rates, got %v", got)
+	}
+}
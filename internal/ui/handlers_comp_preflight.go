@@ -104,14 +104,21 @@ func (m Model) handleCompPreflightKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 			}
 			it.Skip = false
 			it.CopyAsNew = false
+			it.Rename = false
 		default:
 			// Move to Skip
 			it.State = StateSkip
 			it.Skip = true
 			it.CopyAsNew = false
+			it.Rename = false
 		}
 		m.updateCompPreflightViewport()
 		return m, nil
+	case "r":
+		// confirm/withdraw detected rename
+		m.toggleCompRename(m.compPre.listIndex)
+		m.updateCompPreflightViewport()
+		return m, nil
 	case "f":
 		// fork/rename
 		i := m.compPre.listIndex
@@ -119,6 +126,7 @@ func (m Model) handleCompPreflightKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 		it.State = StateCreate
 		it.Skip = false
 		it.CopyAsNew = true
+		it.Rename = false
 		// start rename input prefilled
 		m.compPre.input.SetValue(it.ForkName)
 		if it.ForkName == "" {
@@ -9,7 +9,7 @@ func (m Model) handleModePickerKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 	key := msg.String()
 	switch key {
 	case "j", "down":
-		if m.modePickerIndex < 1 { // 0: Stories, 1: Components
+		if m.modePickerIndex < 2 { // 0: Stories, 1: Components, 2: Stories + Components
 			m.modePickerIndex++
 		}
 	case "k", "up":
@@ -23,11 +23,19 @@ func (m Model) handleModePickerKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 			m.statusMsg = "Scanne Stories…"
 			return m, tea.Batch(m.spinner.Tick, m.scanStoriesCmd())
 		}
+		if m.modePickerIndex == 2 {
+			m.currentMode = modeCombined
+			m.state = stateScanning
+			m.statusMsg = "Scanne Stories & Components…"
+			return m, tea.Batch(m.spinner.Tick, m.scanCombinedCmd())
+		}
 		// Components mode selected – kick off components scan
 		m.currentMode = modeComponents
 		m.state = stateScanning
 		m.statusMsg = "Scanne Components…"
 		return m, tea.Batch(m.spinner.Tick, m.scanComponentsCmd())
+	case "o":
+		return m.openReportPicker()
 	case "esc", "b":
 		// Back to space select
 		m.state = stateSpaceSelect
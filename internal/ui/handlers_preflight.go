@@ -10,6 +10,10 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/core/tagsync"
+	"storyblok-sync/internal/infra/notify"
+	"storyblok-sync/internal/infra/tracex"
+	"storyblok-sync/internal/report"
 	"storyblok-sync/internal/sb"
 )
 
@@ -17,8 +21,17 @@ func (m Model) handlePreflightKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 	if m.syncing {
 		return m, nil
 	}
+	if m.schedule.active {
+		return m.handleScheduleInputKey(msg)
+	}
 	key := msg.String()
 	switch key {
+	case "t":
+		// Schedule publishing of the story under the cursor
+		return m.openScheduleInput(false)
+	case "T":
+		// Schedule publishing of all selected stories
+		return m.openScheduleInput(true)
 	case "p":
 		// Cycle publish mode for the current visible story item
 		if len(m.preflight.items) > 0 && m.preflight.listIndex >= 0 {
@@ -208,6 +221,9 @@ func (m Model) handlePreflightKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 		}
 		if removed {
 			m.startPreflight()
+			if m.currentMode == modeCombined {
+				return m, m.resolveCombinedComponents()
+			}
 		}
 	case "f":
 		// Open fork view: story copy-as-new or folder fork depending on item
@@ -335,17 +351,71 @@ func (m Model) handlePreflightKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 			if it.Collision && it.Selected {
 				it.Skip = !it.Skip
 				recalcState(it)
+				if m.currentMode == modeCombined {
+					return m, m.resolveCombinedComponents()
+				}
 				m.updateViewportContent()
 			}
 		}
 	case "X":
+		changed := false
 		for i := range m.preflight.items {
 			if m.preflight.items[i].Collision && m.preflight.items[i].Selected {
+				changed = changed || !m.preflight.items[i].Skip
 				m.preflight.items[i].Skip = true
 				recalcState(&m.preflight.items[i])
 			}
 		}
+		if changed && m.currentMode == modeCombined {
+			return m, m.resolveCombinedComponents()
+		}
+		m.updateViewportContent()
+	case "v":
+		// Validate selected stories against the target's component schemas
+		if len(m.preflight.items) > 0 && !m.schemaValidating {
+			m.schemaValidating = true
+			m.statusMsg = "Prüfe Stories gegen Ziel-Schemas…"
+			m.ensureLimiter()
+			return m, m.validateSchemasCmd()
+		}
+	case "V":
+		m.toggleSchemaPolicy()
 		m.updateViewportContent()
+		return m, nil
+	case "w":
+		// Look up the workflow stage of target stories that would be updated
+		if len(m.preflight.items) > 0 && !m.workflowChecking {
+			m.workflowChecking = true
+			m.statusMsg = "Prüfe Workflow-Stages im Ziel…"
+			return m, m.workflowCheckCmd()
+		}
+	case "W":
+		m.toggleWorkflowTransition()
+		m.updateViewportContent()
+		return m, nil
+	case "R":
+		// Choose or create a target release for all story writes
+		return m.openReleasePicker()
+	case "i":
+		// Restrict the sync to selected languages' translations
+		return m.openLanguagePicker()
+	case "m":
+		m.toggleMergePolicy()
+		m.updateViewportContent()
+		return m, nil
+	case "d":
+		// Field-level diff of the selected story under the current merge settings
+		return m.openMergeDiff()
+	case "C":
+		// Three-way merge against recorded bases to find conflicting edits
+		if len(m.preflight.items) > 0 && !m.conflictChecking {
+			m.conflictChecking = true
+			m.statusMsg = "Prüfe Merge-Konflikte gegen gespeicherte Bases…"
+			m.ensureLimiter()
+			return m, m.conflictCheckCmd()
+		}
+	case "K":
+		return m.openConflictResolver()
 	case "esc", "q":
 		// restore browse collapse state
 		if m.collapsedBeforePreflight != nil {
@@ -361,20 +431,64 @@ func (m Model) handlePreflightKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 		m.updateViewportContent()
 		return m, nil
 	case "enter":
-		m.optimizePreflight()
-		if len(m.preflight.items) == 0 {
-			m.statusMsg = "Keine Items zum Sync"
-			return m, nil
+		if m.currentMode == modeCombined {
+			return m.startCombinedSync()
 		}
-		m.plan = SyncPlan{Items: append([]PreflightItem(nil), m.preflight.items...)}
-		m.syncing = true
-		m.syncIndex = 0
+		return m.beginStorySync(true)
+	}
+	return m, nil
+}
+
+// beginStorySync plans the preflight items and starts the story workers.
+// fresh is false when stories run as the second phase of a combined sync:
+// the report (with its component entries), the API client and the running
+// stats tick are kept.
+func (m Model) beginStorySync(fresh bool) (Model, tea.Cmd) {
+	// Fanned-out items repeat slugs per target and must not be deduplicated again
+	expand := !m.fanOut.expanded
+	// Release and workflow stages exist in the primary target only
+	if expand && m.fanOutActive() && (m.release != nil || m.workflowChecksEnabled()) {
+		m.statusMsg = "Mehrere Ziele: Release und Workflow-Prüfung nur mit einem Ziel möglich"
+		return m, nil
+	}
+	if expand {
+		m.optimizePreflight()
+	}
+	if len(m.preflight.items) == 0 {
+		m.statusMsg = "Keine Items zum Sync"
+		return m, nil
+	}
+	m.syncing = true
+	m.syncIndex = 0
+	if fresh || m.api == nil {
 		m.api = sb.New(m.cfg.Token)
-		m.state = stateSync
+	}
+	if (fresh || m.tagReconciler == nil) && m.targetSpace != nil {
+		m.tagReconciler = tagsync.NewReconciler(m.api, m.api, m.targetSpace.ID)
+	}
+	if expand {
+		m.expandFanOut()
+	} else if fresh || m.fanOut.limiter == nil {
+		// all items share one limiter; items restored from the journal
+		// arrive already expanded
+		m.setupFanOutClients()
+	}
+	m.plan = SyncPlan{Items: append([]PreflightItem(nil), m.preflight.items...)}
+	m.workflowGuard = m.newWorkflowGuard(m.api)
+	m.state = stateSync
 
-		// Set up cancellation context for sync operations
-		m.syncContext, m.syncCancel = context.WithCancel(context.Background())
+	// Set up cancellation context for sync operations; a fresh run opens the
+	// root span all item spans hang off
+	if fresh || m.syncSpan == nil {
+		m.syncSpan.End()
+		_, m.syncSpan = tracex.Start(context.Background(), "sync.run", tracex.Int("items", len(m.preflight.items)))
+		if m.targetSpace != nil {
+			m.syncSpan.SetAttrs(tracex.Int("target_space_id", m.targetSpace.ID))
+		}
+	}
+	m.syncContext, m.syncCancel = context.WithCancel(tracex.WithSpan(context.Background(), m.syncSpan))
 
+	if fresh {
 		// Initialize comprehensive report with space information
 		sourceSpaceName := ""
 		targetSpaceName := ""
@@ -384,35 +498,60 @@ func (m Model) handlePreflightKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 		if m.targetSpace != nil {
 			targetSpaceName = fmt.Sprintf("%s (%d)", m.targetSpace.Name, m.targetSpace.ID)
 		}
-		m.report = *NewReport(sourceSpaceName, targetSpaceName)
-
-		m.statusMsg = fmt.Sprintf("Synchronisiere %d Items…", len(m.preflight.items))
-		// Start worker pool. During folder phase, run sequentially to avoid parent/child races.
-		// After all folders are done, allow some parallelism for stories.
-		cmds := []tea.Cmd{m.spinner.Tick}
-		hasFolders := false
-		for _, it := range m.preflight.items {
-			if it.Story.IsFolder {
-				hasFolders = true
-				break
-			}
+		m.report = *report.New(sourceSpaceName, targetSpaceName)
+		if m.sourceSpace != nil {
+			m.report.SourceSpaceID = m.sourceSpace.ID
 		}
-		parallel := 6
-		if hasFolders {
-			parallel = 1
+		if m.targetSpace != nil {
+			m.report.TargetSpaceID = m.targetSpace.ID
 		}
-		m.maxWorkers = parallel
-		for i := 0; i < parallel; i++ {
-			cmds = append(cmds, m.runNextItem())
+		if m.release != nil {
+			m.report.Release = m.release.Name
+			m.report.ReleaseID = m.release.ID
 		}
-		// kick off stats tick for performance panel
+		m.report.Languages = m.syncLanguages
+		if m.fanOut.expanded && m.targetSpace != nil {
+			m.report.Targets = append(m.report.Targets, ReportTarget{ID: m.targetSpace.ID, Name: m.targetSpace.Name})
+			for _, sp := range m.fanOut.targets {
+				m.report.Targets = append(m.report.Targets, ReportTarget{ID: sp.ID, Name: sp.Name})
+			}
+		}
+		m.startJournal()
+		m.failureNotified = false
+		m.notifyRun(notify.EventStarted)
+	}
+
+	m.statusMsg = fmt.Sprintf("Synchronisiere %d Items…", len(m.preflight.items))
+	// Start worker pool. During folder phase, run sequentially to avoid parent/child races.
+	// After all folders are done, allow some parallelism for stories.
+	cmds := []tea.Cmd{m.spinner.Tick}
+	hasFolders := false
+	for _, it := range m.preflight.items {
+		if it.Story.IsFolder {
+			hasFolders = true
+			break
+		}
+	}
+	parallel := 6
+	if hasFolders && !m.fanOut.expanded {
+		parallel = 1
+	}
+	m.maxWorkers = parallel
+	// Fan-out: every target gets its own worker budget
+	for i := 0; i < parallel*m.syncTargets(); i++ {
+		cmds = append(cmds, m.runNextItem())
+	}
+	// kick off stats tick for performance panel
+	if fresh {
 		cmds = append(cmds, m.statsTick())
-		return m, tea.Batch(cmds...)
 	}
-	return m, nil
+	return m, tea.Batch(cmds...)
 }
 
 func (m *Model) startPreflight() {
+	_, span := tracex.Start(context.Background(), "preflight")
+	defer span.End()
+	m.fanOut.expanded = false
 	target := make(map[string]bool, len(m.storiesTarget))
 	for _, st := range m.storiesTarget {
 		target[st.FullSlug] = true
@@ -483,6 +622,7 @@ func (m *Model) startPreflight() {
 			collisions++
 		}
 	}
+	span.SetAttrs(tracex.Int("items", len(items)), tracex.Int("collisions", collisions))
 	m.statusMsg = fmt.Sprintf("Preflight: %d Items, %d Kollisionen", len(items), collisions)
 	m.updateViewportContent()
 }
@@ -523,8 +663,9 @@ func (m *Model) calculatePreflightCursorLine() int {
 		contentWidth = 80
 	}
 
-	// Sum wrapped lines up to the cursor's visible position (exclusive)
-	sum := 0
+	// Sum wrapped lines up to the cursor's visible position (exclusive),
+	// starting below the components section in combined mode
+	sum := len(m.renderCombinedCompSection())
 	max := m.preflight.listIndex
 	if max > len(order) {
 		max = len(order)
@@ -3,9 +3,12 @@ package ui
 import (
 	"context"
 	"fmt"
+	"log"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"storyblok-sync/internal/infra/tracex"
+	"storyblok-sync/internal/report"
 	"storyblok-sync/internal/sb"
 )
 
@@ -49,6 +52,13 @@ func (m Model) handleReportKey(key string) (Model, tea.Cmd) {
 		m.state = stateModePicker
 		m.statusMsg = "Zurück zur Modus-Auswahl…"
 		return m, nil
+	case "f":
+		m.reportFormat = nextReportFormat(m.reportFormat)
+		m.statusMsg = "Export-Format: " + m.reportFormat
+		return m, nil
+	case "e":
+		m.exportReport(m.reportFormat)
+		return m, nil
 	case "r":
 		// Resume any pending work first; else retry failures if any
 		next := -1
@@ -66,7 +76,7 @@ func (m Model) handleReportKey(key string) (Model, tea.Cmd) {
 			if m.api == nil {
 				m.api = sb.New(m.cfg.Token)
 			}
-			m.syncContext, m.syncCancel = context.WithCancel(context.Background())
+			m.syncContext, m.syncCancel = context.WithCancel(tracex.WithSpan(context.Background(), m.syncSpan))
 			m.statusMsg = "Resuming sync…"
 			return m, tea.Batch(m.spinner.Tick, m.runNextItem())
 		}
@@ -91,9 +101,58 @@ func (m Model) handleReportKey(key string) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// WithReportFormat selects the export format and writes it automatically
+// next to the JSON report whenever a run finishes (--report-format).
+func (m Model) WithReportFormat(format string) Model {
+	m.reportFormat = format
+	m.reportAutoExport = true
+	return m
+}
+
+// nextReportFormat cycles the export formats offered on the report screen.
+func nextReportFormat(f string) string {
+	switch f {
+	case report.FormatHTML:
+		return report.FormatMarkdown
+	case report.FormatMarkdown:
+		return report.FormatJUnit
+	case report.FormatJUnit:
+		return report.FormatJSON
+	}
+	return report.FormatHTML
+}
+
+// exportReport writes the current report in format and reports the file name.
+func (m *Model) exportReport(format string) {
+	name, err := m.report.Export(format)
+	if err != nil {
+		m.statusMsg = "Report-Export fehlgeschlagen: " + err.Error()
+		return
+	}
+	m.statusMsg = "Report exportiert: " + name
+}
+
+// autoExportReport writes the --report-format export after a finished run.
+func (m *Model) autoExportReport() {
+	if !m.reportAutoExport || m.reportFormat == report.FormatJSON || len(m.report.Entries) == 0 {
+		return
+	}
+	if _, err := m.report.Export(m.reportFormat); err != nil {
+		log.Printf("Warning: report export failed: %v", err)
+	}
+}
+
 // getFailedItemsForRetry creates preflight items from failed report entries
 func (m Model) getFailedItemsForRetry() []PreflightItem {
+	items, _ := m.failedItemsFromEntries(m.report.Entries)
+	return items
+}
+
+// failedItemsFromEntries resolves failed story entries against the scanned
+// source stories by slug. It also returns the slugs no longer in the source.
+func (m Model) failedItemsFromEntries(entries []ReportEntry) ([]PreflightItem, []string) {
 	var failedItems []PreflightItem
+	var missing []string
 
 	// Build a map of source stories by slug for quick lookup
 	sourceMap := make(map[string]sb.Story)
@@ -101,28 +160,51 @@ func (m Model) getFailedItemsForRetry() []PreflightItem {
 		sourceMap[story.FullSlug] = story
 	}
 
-	// Build target stories map for collision detection
-	targetMap := make(map[string]bool)
-	for _, story := range m.storiesTarget {
-		targetMap[story.FullSlug] = true
+	// Build target stories maps for collision detection (per target in fan-out runs)
+	targetMaps := make(map[int]map[string]bool)
+	collides := func(it PreflightItem) bool {
+		tm, ok := targetMaps[it.TargetSpaceID]
+		if !ok {
+			tm = make(map[string]bool)
+			for _, story := range m.targetStoriesFor(it) {
+				tm[story.FullSlug] = true
+			}
+			targetMaps[it.TargetSpaceID] = tm
+		}
+		return tm[it.Story.FullSlug]
 	}
 
-	// Create preflight items for each failed entry
-	for _, entry := range m.report.Entries {
-		if entry.Status == "failure" {
-			if sourceStory, exists := sourceMap[entry.Slug]; exists {
-				item := PreflightItem{
-					Story:     sourceStory,
-					Collision: targetMap[entry.Slug],
-					Skip:      false,
-					Selected:  true, // Auto-select failed items for retry
-					Run:       RunPending,
-				}
-				recalcState(&item)
-				failedItems = append(failedItems, item)
+	// Create preflight items for each failed entry (once per target)
+	seen := make(map[string]bool)
+	seenMissing := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.Status != "failure" || (entry.Kind != "" && entry.Kind != report.KindStory && entry.Kind != report.KindFolder) {
+			continue
+		}
+		sourceStory, exists := sourceMap[entry.Slug]
+		if !exists {
+			if !seenMissing[entry.Slug] {
+				seenMissing[entry.Slug] = true
+				missing = append(missing, entry.Slug)
 			}
+			continue
+		}
+		item := PreflightItem{
+			Story:         sourceStory,
+			Skip:          false,
+			Selected:      true, // Auto-select failed items for retry
+			Run:           RunPending,
+			TargetSpaceID: entry.TargetSpaceID,
+		}
+		key := m.runKey(item)
+		if seen[key] {
+			continue
 		}
+		seen[key] = true
+		item.Collision = collides(item)
+		recalcState(&item)
+		failedItems = append(failedItems, item)
 	}
 
-	return failedItems
+	return failedItems, missing
 }
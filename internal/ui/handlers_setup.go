@@ -68,6 +68,11 @@ func (m Model) handleSpaceSelectKey(key string) (Model, tea.Cmd) {
 		if m.selectedIndex > 0 {
 			m.selectedIndex--
 		}
+	case " ":
+		// Mark additional targets for a fan-out run
+		if !m.selectingSource && len(visible) > 0 {
+			m.toggleTargetMark(visible[m.selectedIndex])
+		}
 	case "enter":
 		if len(visible) == 0 {
 			return m, nil
@@ -82,7 +87,11 @@ func (m Model) handleSpaceSelectKey(key string) (Model, tea.Cmd) {
 			m.selectedIndex = 0
 		} else {
 			m.targetSpace = &chosen
+			m.applyTargetSelection(chosen)
 			m.statusMsg = fmt.Sprintf("Target gesetzt: %s (%d). Wähle Sync-Modus…", chosen.Name, chosen.ID)
+			if m.fanOutActive() {
+				m.statusMsg = fmt.Sprintf("Targets gesetzt: %s (%d) + %d weitere. Wähle Sync-Modus…", chosen.Name, chosen.ID, len(m.fanOut.targets))
+			}
 			m.state = stateModePicker
 			m.modePickerIndex = 0
 			return m, nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	tea "github.com/charmbracelet/bubbletea"
 	"log"
+	"storyblok-sync/internal/infra/tracex"
 	"storyblok-sync/internal/sb"
 )
 
@@ -57,7 +58,7 @@ func (m Model) handleSyncKey(key string) (tea.Model, tea.Cmd) {
 				} else {
 					log.Printf("RESUME(SYNC): targetSpace=%s(%d)", m.targetSpace.Name, m.targetSpace.ID)
 				}
-				m.syncContext, m.syncCancel = context.WithCancel(context.Background())
+				m.syncContext, m.syncCancel = context.WithCancel(tracex.WithSpan(context.Background(), m.syncSpan))
 				log.Printf("RESUME(SYNC): created new context and starting next item")
 				return m, tea.Batch(m.spinner.Tick, m.runNextItem())
 			}
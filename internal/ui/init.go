@@ -3,7 +3,10 @@ package ui
 import (
 	"os"
 	"storyblok-sync/internal/config"
+	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/core/transform"
 	"storyblok-sync/internal/infra/logx"
+	"storyblok-sync/internal/report"
 	"storyblok-sync/internal/sb"
 	"strings"
 	"time"
@@ -122,6 +125,39 @@ func InitialModel() Model {
 	// publish mode maps
 	m.publishMode = make(map[string]string)
 	m.unpublishAfter = make(map[string]bool)
+	m.publishAt = make(map[string]string)
+
+	// schema validation policy (warn by default)
+	m.schemaPolicy = schemaPolicyFromEnv(os.Getenv("SB_SCHEMA_POLICY"))
+
+	// workflow stages treated as locked and opt-in for temporary stage changes
+	m.workflowLockedStages = lockedStagesFromEnv(os.Getenv("SB_WORKFLOW_LOCKED_STAGES"))
+	m.workflowTransition = enableFlag(os.Getenv("SB_WORKFLOW_TRANSITION"))
+
+	// content merge policy and protected/allowed component fields
+	m.mergePolicy, m.fieldRules = mergeSettingsFromEnv(os.Getenv("SB_MERGE_POLICY"), os.Getenv("SB_MERGE_DENY"), os.Getenv("SB_MERGE_ALLOW"))
+
+	// content transforms applied before each story write (SB_TRANSFORMS, default .sbsync/transforms.json)
+	if p, err := transform.FromEnv(); err != nil {
+		m.statusMsg += " Transform-Regeln ignoriert: " + err.Error()
+	} else {
+		m.transforms = p
+	}
+
+	// merge bases for three-way merges, only with SB_BASE_DIR or a merge policy (default .sbsync/base)
+	if dir := os.Getenv("SB_BASE_DIR"); dir != "" || m.mergePolicy != sync.MergeOverwrite {
+		m.baseStore = sync.NewBaseStore(dir)
+	}
+
+	// report export format on the report screen
+	m.reportFormat = report.FormatHTML
+
+	// sync journal for resuming interrupted runs (default .sbsync/journal.jsonl, "off" disables)
+	m.journalPath = journalPathFromEnv(os.Getenv("SB_JOURNAL_PATH"))
+	m.loadPendingJournal()
+	if m.pendingJournal != nil {
+		m.statusMsg += " Unterbrochener Sync gefunden."
+	}
 
 	// components UI defaults
 	m.comp = CompListState{selected: make(map[string]bool), collapsed: make(map[string]bool), sortKey: compSortUpdated, sortAsc: false}
@@ -140,6 +176,18 @@ func InitialModel() Model {
 
 func (m Model) Init() tea.Cmd { return nil }
 
+// journalPathFromEnv returns the journal location; "off" disables the journal.
+func journalPathFromEnv(v string) string {
+	v = strings.TrimSpace(v)
+	switch strings.ToLower(v) {
+	case "":
+		return sync.DefaultJournalPath
+	case "off", "0", "false", "no":
+		return ""
+	}
+	return v
+}
+
 // enableFlag returns true for common truthy values: 1, true, yes (case-insensitive)
 func enableFlag(v string) bool {
 	switch strings.ToLower(strings.TrimSpace(v)) {
@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/infra/logx"
+	"storyblok-sync/internal/sb"
+)
+
+// journalPlan captures the running items and the settings needed to run
+// them again after an interruption.
+func (m *Model) journalPlan() sync.JournalPlan {
+	plan := sync.JournalPlan{
+		Items:        append([]PreflightItem(nil), m.preflight.items...),
+		PublishModes: m.publishMode,
+		PublishAt:    m.publishAt,
+		Languages:    m.syncLanguages,
+		MergePolicy:  m.mergePolicy,
+	}
+	if m.sourceSpace != nil {
+		plan.SourceSpaceID = m.sourceSpace.ID
+	}
+	if m.targetSpace != nil {
+		plan.TargetSpaceID = m.targetSpace.ID
+	}
+	if m.fanOut.expanded {
+		for _, sp := range m.fanOut.targets {
+			plan.FanOutTargets = append(plan.FanOutTargets, sp.ID)
+		}
+	}
+	if m.release != nil {
+		plan.ReleaseID = m.release.ID
+		plan.ReleaseName = m.release.Name
+	}
+	return plan
+}
+
+// startJournal begins a new journal for the items about to be synced.
+// Journal errors never block the sync; they only cost the resume option.
+func (m *Model) startJournal() {
+	if m.journalPath == "" {
+		return
+	}
+	if m.journal != nil {
+		_ = m.journal.Close()
+	}
+	j, err := sync.StartJournal(m.journalPath, m.journalPlan())
+	if err != nil {
+		logx.Errorf("JOURNAL start: %v", err)
+		m.journal = nil
+		return
+	}
+	m.journal = j
+}
+
+// journalResult records the outcome of a finished item (errMsg empty: success).
+func (m *Model) journalResult(it PreflightItem, errMsg string) {
+	if m.journal == nil {
+		return
+	}
+	if err := m.journal.Result(m.runKey(it), errMsg); err != nil {
+		logx.Errorf("JOURNAL result %s: %v", it.Story.FullSlug, err)
+	}
+}
+
+// finishJournal marks the running sync as complete.
+func (m *Model) finishJournal() {
+	if m.journal == nil {
+		return
+	}
+	if err := m.journal.Finish(); err != nil {
+		logx.Errorf("JOURNAL finish: %v", err)
+	}
+	m.journal = nil
+}
+
+// loadPendingJournal looks for an interrupted sync with items left to run.
+func (m *Model) loadPendingJournal() {
+	if m.journalPath == "" {
+		return
+	}
+	st, err := sync.LoadUnfinishedJournal(m.journalPath)
+	if err != nil {
+		logx.Errorf("JOURNAL load: %v", err)
+		return
+	}
+	if st == nil {
+		return
+	}
+	if _, failed, pending := st.Counts(); failed+pending == 0 {
+		return
+	}
+	m.pendingJournal = st
+}
+
+// handleResumePromptKey resumes or discards the interrupted sync.
+func (m Model) handleResumePromptKey(key string) (Model, tea.Cmd) {
+	switch key {
+	case "enter", "y":
+		return m.startJournalResume()
+	case "n", "esc":
+		if err := sync.DiscardJournal(m.journalPath); err != nil {
+			logx.Errorf("JOURNAL discard: %v", err)
+		}
+		m.pendingJournal = nil
+		m.statusMsg = "Unterbrochener Sync verworfen."
+		return m.routeAfterValidation()
+	}
+	return m, nil
+}
+
+// startJournalResume restores spaces and settings of the interrupted sync and
+// rescans, so the target index reflects what the interrupted run created.
+func (m Model) startJournalResume() (Model, tea.Cmd) {
+	plan := m.pendingJournal.Plan
+	src, okSrc := containsSpaceID(m.spaces, strconv.Itoa(plan.SourceSpaceID))
+	tgt, okTgt := containsSpaceID(m.spaces, strconv.Itoa(plan.TargetSpaceID))
+	if !okSrc || !okTgt {
+		m.pendingJournal = nil
+		m.statusMsg = "Spaces des unterbrochenen Syncs nicht gefunden – Journal ignoriert."
+		return m.routeAfterValidation()
+	}
+	m.sourceSpace = &src
+	m.targetSpace = &tgt
+	m.fanOut = FanOutState{}
+	for _, id := range plan.FanOutTargets {
+		if sp, ok := containsSpaceID(m.spaces, strconv.Itoa(id)); ok {
+			m.fanOut.targets = append(m.fanOut.targets, sp)
+		}
+	}
+	if plan.PublishModes != nil {
+		m.publishMode = plan.PublishModes
+	}
+	if plan.PublishAt != nil {
+		m.publishAt = plan.PublishAt
+	}
+	m.release = nil
+	if plan.ReleaseID > 0 {
+		m.release = &sb.Release{ID: plan.ReleaseID, Name: plan.ReleaseName}
+	}
+	m.syncLanguages = plan.Languages
+	if plan.MergePolicy != "" {
+		m.mergePolicy = plan.MergePolicy
+		m.enableBaseStore()
+	}
+	m.currentMode = modeStories
+	m.resumingJournal = true
+	m.state = stateScanning
+	m.statusMsg = "Scanne Spaces für die Fortsetzung…"
+	return m, tea.Batch(m.spinner.Tick, m.scanStoriesCmd())
+}
+
+// resumeFromJournal runs the pending and failed items of the interrupted sync
+// against the freshly scanned target stories.
+func (m Model) resumeFromJournal() (Model, tea.Cmd) {
+	st := m.pendingJournal
+	m.pendingJournal = nil
+	m.resumingJournal = false
+	items := st.ResumeItems()
+	for i := range items {
+		it := &items[i]
+		if it.CopyAsNew {
+			continue
+		}
+		it.Collision = false
+		for _, t := range m.targetStoriesFor(*it) {
+			if t.FullSlug == it.Story.FullSlug {
+				it.Collision = true
+				break
+			}
+		}
+		recalcState(it)
+	}
+	m.preflight = PreflightState{items: items}
+	// Fan-out items are already expanded per target and must not be expanded again
+	m.fanOut.expanded = len(m.fanOut.targets) > 0
+	return m.beginStorySync(true)
+}
+
+// viewResumePrompt offers to resume the sync found in the journal.
+func (m Model) viewResumePrompt() string {
+	st := m.pendingJournal
+	title := titleStyle.Render("⏯ Unterbrochener Sync gefunden")
+	var lines []string
+	if st != nil {
+		name := func(id int) string {
+			if sp, ok := containsSpaceID(m.spaces, strconv.Itoa(id)); ok {
+				return fmt.Sprintf("%s (%d)", sp.Name, id)
+			}
+			return strconv.Itoa(id)
+		}
+		route := name(st.Plan.SourceSpaceID) + " → " + name(st.Plan.TargetSpaceID)
+		if n := len(st.Plan.FanOutTargets); n > 0 {
+			route += fmt.Sprintf(" (+%d Ziele)", n)
+		}
+		done, failed, pending := st.Counts()
+		lines = append(lines,
+			subtitleStyle.Render(route),
+			subtleStyle.Render("Gestartet: "+st.Started.Local().Format("02.01.2006 15:04")),
+			"",
+			okStyle.Render(fmt.Sprintf("✓ %d erledigt", done))+"  "+
+				errorStyle.Render(fmt.Sprintf("✗ %d fehlgeschlagen", failed))+"  "+
+				warnStyle.Render(fmt.Sprintf("… %d offen", pending)),
+		)
+		if st.Plan.ReleaseName != "" {
+			lines = append(lines, subtleStyle.Render("Release: "+st.Plan.ReleaseName))
+		}
+	}
+	content := title + "\n\n" + strings.Join(lines, "\n")
+	boxContent := welcomeBoxStyle.Render(content)
+	help := renderFooter("", "⌨️  Enter: offene & fehlgeschlagene Items fortsetzen  •  n: verwerfen  •  q: beenden")
+	return centeredStyle.Width(m.width).Render(boxContent) + "\n\n" +
+		centeredStyle.Width(m.width).Render(help)
+}
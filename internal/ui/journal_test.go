@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/sb"
+)
+
+func TestMain(m *testing.M) {
+	// keep tests from picking up or leaving behind a journal in the package directory
+	os.Setenv("SB_JOURNAL_PATH", "off")
+	os.Exit(m.Run())
+}
+
+func TestResumeInterruptedSyncFromJournal(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	path := filepath.Join(dir, "journal.jsonl")
+	folderID := 10
+	folder := sb.Story{ID: 10, Name: "app", Slug: "app", FullSlug: "app", IsFolder: true}
+	page := sb.Story{ID: 11, Name: "page", Slug: "page", FullSlug: "app/page", FolderID: &folderID, Published: true}
+	other := sb.Story{ID: 12, Name: "other", Slug: "other", FullSlug: "app/other", FolderID: &folderID}
+	j, err := sync.StartJournal(path, sync.JournalPlan{
+		SourceSpaceID: 1,
+		TargetSpaceID: 2,
+		Items: []PreflightItem{
+			{Story: folder, Selected: true, State: StateCreate, Run: RunPending},
+			{Story: page, Selected: true, State: StateCreate, Run: RunPending},
+			{Story: other, Selected: true, State: StateCreate, Run: RunPending},
+		},
+		PublishModes: map[string]string{"app/page": PublishModePublish},
+	})
+	if err != nil {
+		t.Fatalf("start journal: %v", err)
+	}
+	_ = j.Result("app", "")
+	_ = j.Result("app/page", "rate limited")
+	_ = j.Close()
+
+	m := InitialModel()
+	m.cfg.Path = filepath.Join(dir, ".sbrc")
+	m.journalPath = path
+	m.loadPendingJournal()
+	if m.pendingJournal == nil {
+		t.Fatal("expected pending journal")
+	}
+
+	model, _ := m.Update(validateMsg{spaces: []sb.Space{{ID: 1, Name: "src"}, {ID: 2, Name: "tgt"}}})
+	m = model.(Model)
+	if m.state != stateResumePrompt {
+		t.Fatalf("expected resume prompt, got %v", m.state)
+	}
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+	if m.state != stateScanning || m.sourceSpace == nil || m.sourceSpace.ID != 1 || m.targetSpace.ID != 2 {
+		t.Fatalf("expected rescan of journal spaces, got state %v", m.state)
+	}
+
+	// the interrupted run already created the folder and the page
+	tgt := []sb.Story{{ID: 90, FullSlug: "app", IsFolder: true}, {ID: 91, FullSlug: "app/page"}}
+	model, _ = m.Update(scanMsg{src: []sb.Story{folder, page, other}, tgt: tgt})
+	m = model.(Model)
+	if m.state != stateSync {
+		t.Fatalf("expected sync to resume, got %v", m.state)
+	}
+	states := map[string]string{}
+	for _, it := range m.preflight.items {
+		states[it.Story.FullSlug] = it.State
+	}
+	if len(m.preflight.items) != 2 || states["app/page"] == "" || states["app/other"] == "" {
+		t.Fatalf("expected only failed and pending items, got %+v", m.preflight.items)
+	}
+	if states["app/page"] != StateUpdate || states["app/other"] != StateCreate {
+		t.Fatalf("expected states against fresh target index, got %v", states)
+	}
+	if m.getPublishMode("app/page") != PublishModePublish {
+		t.Fatalf("publish mode not restored")
+	}
+
+	st, err := sync.LoadUnfinishedJournal(path)
+	if err != nil || st == nil || len(st.Plan.Items) != 2 {
+		t.Fatalf("expected new journal for resumed items, got %+v, %v", st, err)
+	}
+
+	for i := range m.preflight.items {
+		res := syncItemResult{Operation: "update"}
+		model, _ = m.Update(syncResultMsg{Index: i, Result: &res})
+		m = model.(Model)
+	}
+	if m.state != stateReport {
+		t.Fatalf("expected report, got %v", m.state)
+	}
+	if st, err := sync.LoadUnfinishedJournal(path); st != nil || err != nil {
+		t.Fatalf("expected finished journal, got %+v, %v", st, err)
+	}
+}
+
+func TestSyncResultsAreJournaled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	m := InitialModel()
+	m.journalPath = path
+	m.sourceSpace = &sb.Space{ID: 1, Name: "src"}
+	m.targetSpace = &sb.Space{ID: 2, Name: "tgt"}
+	m.preflight.items = []PreflightItem{
+		{Story: sb.Story{ID: 1, FullSlug: "a"}, Selected: true, State: StateCreate, Run: RunPending},
+		{Story: sb.Story{ID: 2, FullSlug: "b"}, Selected: true, State: StateCreate, Run: RunPending},
+		{Story: sb.Story{ID: 3, FullSlug: "c"}, Selected: true, State: StateCreate, Run: RunPending},
+	}
+	m, _ = m.beginStorySync(true)
+
+	res := syncItemResult{Operation: "create"}
+	model, _ := m.Update(syncResultMsg{Index: 0, Result: &res})
+	m = model.(Model)
+	model, _ = m.Update(syncResultMsg{Index: 1, Err: errors.New("boom")})
+	m = model.(Model)
+
+	st, err := sync.LoadUnfinishedJournal(path)
+	if err != nil || st == nil {
+		t.Fatalf("expected unfinished journal, got %v", err)
+	}
+	if done, failed, pending := st.Counts(); done != 1 || failed != 1 || pending != 1 {
+		t.Fatalf("counts = %d/%d/%d, want 1/1/1", done, failed, pending)
+	}
+	if st.Errors["b"] != "boom" {
+		t.Fatalf("expected error journaled, got %q", st.Errors["b"])
+	}
+	m.journal.Close()
+}
@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/sb"
+)
+
+// LanguagePickerState holds the language scope selection screen.
+// Options: index 0 = all languages (full sync), 1..n = source languages.
+type LanguagePickerState struct {
+	source   []sb.Language
+	target   []sb.Language
+	chosen   map[string]bool
+	index    int
+	loading  bool
+	errorMsg string
+}
+
+// languagesMsg carries the configured languages of source and target space
+type languagesMsg struct {
+	source []sb.Language
+	target []sb.Language
+	err    error
+}
+
+// openLanguagePicker switches to the language picker and loads both spaces' languages.
+func (m Model) openLanguagePicker() (Model, tea.Cmd) {
+	chosen := make(map[string]bool, len(m.syncLanguages))
+	for _, l := range m.syncLanguages {
+		chosen[l] = true
+	}
+	m.langPick = LanguagePickerState{loading: true, chosen: chosen}
+	m.state = stateLanguagePicker
+	return m, m.loadLanguagesCmd()
+}
+
+func (m Model) loadLanguagesCmd() tea.Cmd {
+	srcID, tgtID := 0, 0
+	if m.sourceSpace != nil {
+		srcID = m.sourceSpace.ID
+	}
+	if m.targetSpace != nil {
+		tgtID = m.targetSpace.ID
+	}
+	token := m.cfg.Token
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		client := sb.New(token)
+		src, err := client.GetSpaceDetails(ctx, srcID)
+		if err != nil {
+			return languagesMsg{err: err}
+		}
+		tgt, err := client.GetSpaceDetails(ctx, tgtID)
+		if err != nil {
+			return languagesMsg{err: err}
+		}
+		return languagesMsg{source: src.Options.Languages, target: tgt.Options.Languages}
+	}
+}
+
+// applyLanguages stores the loaded language lists.
+func (m *Model) applyLanguages(msg languagesMsg) {
+	m.langPick.loading = false
+	if msg.err != nil {
+		m.langPick.errorMsg = "Sprachen konnten nicht geladen werden: " + msg.err.Error()
+		return
+	}
+	m.langPick.source = msg.source
+	m.langPick.target = msg.target
+	if len(msg.source) == 0 {
+		m.langPick.errorMsg = "Quell-Space hat keine zusätzlichen Sprachen konfiguriert"
+	}
+}
+
+// chosenLanguages returns the selected codes in source order.
+func (lp LanguagePickerState) chosenLanguages() []string {
+	var out []string
+	for _, l := range lp.source {
+		if lp.chosen[l.Code] {
+			out = append(out, l.Code)
+		}
+	}
+	return out
+}
+
+// setLanguages applies the language scope (nil: full sync) and returns to preflight.
+func (m *Model) setLanguages(langs []string) {
+	m.syncLanguages = langs
+	m.state = statePreflight
+	if len(langs) == 0 {
+		m.statusMsg = "Sync überträgt alle Sprachen"
+	} else {
+		m.statusMsg = "Sync überträgt nur Übersetzungen: " + strings.Join(langs, ", ")
+	}
+	m.updateViewportContent()
+}
+
+func (m Model) handleLanguagePickerKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	lp := &m.langPick
+	switch msg.String() {
+	case "esc", "q", "b":
+		m.state = statePreflight
+		m.updateViewportContent()
+	case "j", "down":
+		if lp.index < len(lp.source) {
+			lp.index++
+		}
+	case "k", "up":
+		if lp.index > 0 {
+			lp.index--
+		}
+	case " ", "space":
+		if lp.index == 0 {
+			lp.chosen = map[string]bool{}
+		} else if lp.index <= len(lp.source) {
+			code := lp.source[lp.index-1].Code
+			lp.chosen[code] = !lp.chosen[code]
+		}
+		lp.errorMsg = ""
+	case "enter":
+		if lp.loading {
+			return m, nil
+		}
+		if lp.index == 0 {
+			m.setLanguages(nil)
+			return m, nil
+		}
+		langs := lp.chosenLanguages()
+		if len(langs) == 0 {
+			lp.errorMsg = "Keine Sprache gewählt (Leertaste zum Markieren)"
+			return m, nil
+		}
+		if err := sync.ValidateLanguages(langs, lp.target); err != nil {
+			lp.errorMsg = err.Error()
+			return m, nil
+		}
+		m.setLanguages(langs)
+	}
+	return m, nil
+}
+
+// languagesLabel renders the current language scope for headers.
+func (m Model) languagesLabel() string {
+	if len(m.syncLanguages) == 0 {
+		return "alle"
+	}
+	return strings.Join(m.syncLanguages, ",")
+}
+
+func (m Model) viewLanguagePicker() string {
+	title := listHeaderStyle.Render("Sprachen für den Sync wählen")
+	var lines []string
+	lines = append(lines, subtitleStyle.Render("Nur die Übersetzungen (__i18n__) und übersetzten Slugs der gewählten Sprachen werden in bestehende Ziel-Stories übernommen."))
+	lines = append(lines, "")
+	if m.langPick.loading {
+		lines = append(lines, m.spinner.View()+" lade Sprachen…")
+	} else {
+		inTarget := make(map[string]bool, len(m.langPick.target))
+		for _, l := range m.langPick.target {
+			inTarget[l.Code] = true
+		}
+		options := []string{"Alle Sprachen (voller Sync)"}
+		for _, l := range m.langPick.source {
+			box := "[ ]"
+			if m.langPick.chosen[l.Code] {
+				box = "[x]"
+			}
+			opt := fmt.Sprintf("%s %s (%s)", box, l.Name, l.Code)
+			if !inTarget[l.Code] {
+				opt += " – im Ziel nicht konfiguriert"
+			}
+			options = append(options, opt)
+		}
+		for i, opt := range options {
+			marker := "  "
+			if i == m.langPick.index {
+				marker = "> "
+			}
+			lines = append(lines, spaceItemStyle.Render(marker+opt))
+		}
+	}
+	if m.langPick.errorMsg != "" {
+		lines = append(lines, "", errorStyle.Render("❌ "+m.langPick.errorMsg))
+	}
+	help := renderFooter("", "⌨️  ↑↓/j/k: wählen  •  Leertaste: markieren  •  Enter: bestätigen  •  Esc: zurück")
+	return title + "\n\n" + strings.Join(lines, "\n") + "\n\n" + help
+}
@@ -7,6 +7,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"storyblok-sync/internal/infra/tracex"
 	"storyblok-sync/internal/sb"
 )
 
@@ -19,7 +20,9 @@ type validateMsg struct {
 type scanMsg struct {
 	src []sb.Story
 	tgt []sb.Story
-	err error
+	// stories of additional fan-out targets by space ID
+	extra map[int][]sb.Story
+	err   error
 }
 
 func (m Model) validateTokenCmd() tea.Cmd {
@@ -44,24 +47,45 @@ func (m Model) scanStoriesCmd() tea.Cmd {
 		tgtID = m.targetSpace.ID
 	}
 	token := m.cfg.Token
+	extraTargets := append([]sb.Space(nil), m.fanOut.targets...)
 
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
+		ctx, span := tracex.Start(ctx, "scan", tracex.String("kind", "stories"),
+			tracex.Int("source_space_id", srcID), tracex.Int("target_space_id", tgtID))
+		defer span.End()
 		c := sb.New(token)
 
 		// Sequentiell für Klarheit
 		src, err := c.ListStories(ctx, sb.ListStoriesOpts{SpaceID: srcID, PerPage: 1000})
 		if err != nil {
+			span.SetError(err)
 			return scanMsg{err: fmt.Errorf("source scan: %w", err)}
 		}
 		sortStories(src)
 
 		tgt, err := c.ListStories(ctx, sb.ListStoriesOpts{SpaceID: tgtID, PerPage: 1000})
 		if err != nil {
+			span.SetError(err)
 			return scanMsg{err: fmt.Errorf("target scan: %w", err)}
 		}
 		sortStories(tgt)
-		return scanMsg{src: src, tgt: tgt, err: nil}
+
+		var extra map[int][]sb.Story
+		for _, sp := range extraTargets {
+			stories, err := c.ListStories(ctx, sb.ListStoriesOpts{SpaceID: sp.ID, PerPage: 1000})
+			if err != nil {
+				span.SetError(err)
+				return scanMsg{err: fmt.Errorf("target scan %s: %w", sp.Name, err)}
+			}
+			sortStories(stories)
+			if extra == nil {
+				extra = make(map[int][]sb.Story, len(extraTargets))
+			}
+			extra[sp.ID] = stories
+		}
+		span.SetAttrs(tracex.Int("source_stories", len(src)), tracex.Int("target_stories", len(tgt)))
+		return scanMsg{src: src, tgt: tgt, extra: extra, err: nil}
 	}
 }
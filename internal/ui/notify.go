@@ -0,0 +1,40 @@
+package ui
+
+import "storyblok-sync/internal/infra/notify"
+
+// notifyRun sends a run webhook event built from the current report.
+func (m *Model) notifyRun(event string) {
+	if !notify.Enabled() {
+		return
+	}
+	notify.Send(notify.FromReport(event, &m.report))
+}
+
+// notifyFailures sends the failure threshold event once per run when the
+// run's failures (report entries plus extra, e.g. pending component
+// results) reach the configured threshold.
+func (m *Model) notifyFailures(extra int) {
+	threshold := notify.Threshold()
+	if threshold <= 0 || m.failureNotified {
+		return
+	}
+	_, _, failures := m.report.Counts()
+	if failures+extra < threshold {
+		return
+	}
+	m.failureNotified = true
+	ev := notify.FromReport(notify.EventFailureThreshold, &m.report)
+	ev.Threshold = threshold
+	notify.Send(ev)
+}
+
+// compFailures counts the failed component results not yet in the report.
+func (m Model) compFailures() int {
+	n := 0
+	for _, e := range m.compResults {
+		if e.Err != "" {
+			n++
+		}
+	}
+	return n
+}
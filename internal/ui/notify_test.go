@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"storyblok-sync/internal/infra/notify"
+	"storyblok-sync/internal/sb"
+)
+
+func TestSyncRunSendsWebhooks(t *testing.T) {
+	t.Chdir(t.TempDir())
+	var mu sync.Mutex
+	events := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		events[r.Header.Get("X-Sbsync-Event")]++
+		mu.Unlock()
+	}))
+	defer srv.Close()
+	notify.Install(&notify.Notifier{Hooks: []notify.Hook{{URL: srv.URL}}, FailThreshold: 1})
+	defer notify.Close()
+
+	m := InitialModel()
+	m.sourceSpace = &sb.Space{ID: 1, Name: "src"}
+	m.targetSpace = &sb.Space{ID: 2, Name: "tgt"}
+	m.preflight.items = []PreflightItem{
+		{Story: sb.Story{ID: 1, FullSlug: "a"}, Selected: true, State: StateCreate, Run: RunPending},
+		{Story: sb.Story{ID: 2, FullSlug: "b"}, Selected: true, State: StateCreate, Run: RunPending},
+		{Story: sb.Story{ID: 3, FullSlug: "c"}, Selected: true, State: StateCreate, Run: RunPending},
+	}
+	m, _ = m.beginStorySync(true)
+	res := syncItemResult{Operation: "create"}
+	for i, msg := range []syncResultMsg{{Index: 0, Err: errors.New("boom")}, {Index: 1, Err: errors.New("boom")}, {Index: 2, Result: &res}} {
+		m.preflight.items[i].Run = RunRunning
+		model, _ := m.Update(msg)
+		m = model.(Model)
+	}
+	if m.state != stateReport {
+		t.Fatalf("expected the run to finish, got state %v", m.state)
+	}
+	notify.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if events[notify.EventStarted] != 1 || events[notify.EventFailureThreshold] != 1 || events[notify.EventCompleted] != 1 {
+		t.Fatalf("unexpected webhook events %v", events)
+	}
+}
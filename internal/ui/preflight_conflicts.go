@@ -0,0 +1,215 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/sb"
+)
+
+// ConflictResolverState holds the resolver screen for one preflight item.
+type ConflictResolverState struct {
+	itemIdx int
+	index   int
+}
+
+// conflictCheckMsg carries three-way merge conflicts per full slug for every
+// colliding story that has a recorded base.
+type conflictCheckMsg struct {
+	conflicts map[string][]sync.MergeConflict
+	checked   int
+	err       error
+}
+
+// conflictCheckCmd three-way merges source and target content against the
+// recorded base of each selected story that would be updated. Reads go
+// through the shared per-space limiter.
+func (m Model) conflictCheckCmd() tea.Cmd {
+	srcID, tgtID := 0, 0
+	if m.sourceSpace != nil {
+		srcID = m.sourceSpace.ID
+	}
+	if m.targetSpace != nil {
+		tgtID = m.targetSpace.ID
+	}
+	token := m.cfg.Token
+	store := m.baseStore
+	lim := m.fanOut.limiter
+	targetIDs := make(map[string]int)
+	for _, st := range m.storiesTarget {
+		targetIDs[st.FullSlug] = st.ID
+	}
+	type job struct {
+		slug        string
+		sourceID    int
+		targetID    int
+		resolutions map[string]string
+	}
+	var jobs []job
+	for _, it := range m.preflight.items {
+		if it.Story.IsFolder || !it.Selected || !it.Collision || it.CopyAsNew || it.Skip {
+			continue
+		}
+		if id, ok := targetIDs[it.Story.FullSlug]; ok {
+			jobs = append(jobs, job{slug: it.Story.FullSlug, sourceID: it.Story.ID, targetID: id, resolutions: it.Resolutions})
+		}
+	}
+
+	return func() tea.Msg {
+		if store == nil {
+			return conflictCheckMsg{err: fmt.Errorf("kein Base-Speicher konfiguriert")}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		c := sb.New(token)
+		out := make(map[string][]sync.MergeConflict)
+		checked := 0
+		for _, j := range jobs {
+			base, ok, err := store.Load(tgtID, j.targetID)
+			if err != nil {
+				return conflictCheckMsg{err: err}
+			}
+			if !ok {
+				continue
+			}
+			checked++
+			if err := waitRead(ctx, lim, srcID); err != nil {
+				return conflictCheckMsg{err: err}
+			}
+			src, err := c.GetStoryRaw(ctx, srcID, j.sourceID)
+			if err != nil {
+				return conflictCheckMsg{err: fmt.Errorf("source story %s: %w", j.slug, err)}
+			}
+			if err := waitRead(ctx, lim, tgtID); err != nil {
+				return conflictCheckMsg{err: err}
+			}
+			tgt, err := c.GetStoryRaw(ctx, tgtID, j.targetID)
+			if err != nil {
+				return conflictCheckMsg{err: fmt.Errorf("target story %s: %w", j.slug, err)}
+			}
+			baseContent, _ := base["content"].(map[string]interface{})
+			srcContent, _ := src["content"].(map[string]interface{})
+			tgtContent, _ := tgt["content"].(map[string]interface{})
+			if _, conflicts := sync.ThreeWayMerge(baseContent, tgtContent, srcContent, j.resolutions); len(conflicts) > 0 {
+				out[j.slug] = conflicts
+			}
+		}
+		return conflictCheckMsg{conflicts: out, checked: checked}
+	}
+}
+
+// applyConflictCheck stores the conflicts on the preflight items.
+func (m *Model) applyConflictCheck(msg conflictCheckMsg) {
+	m.conflictChecking = false
+	if msg.err != nil {
+		m.statusMsg = "Konflikt-Prüfung fehlgeschlagen: " + msg.err.Error()
+		return
+	}
+	affected := 0
+	for i := range m.preflight.items {
+		it := &m.preflight.items[i]
+		it.Conflicts = msg.conflicts[it.Story.FullSlug]
+		if len(it.Conflicts) > 0 {
+			affected++
+		}
+	}
+	m.statusMsg = fmt.Sprintf("Konflikt-Prüfung: %d Stories mit Base geprüft, %d mit Konflikten (K: auflösen)", msg.checked, affected)
+}
+
+// openConflictResolver shows the conflicts of the selected item.
+func (m Model) openConflictResolver() (Model, tea.Cmd) {
+	if m.preflight.listIndex < 0 || m.preflight.listIndex >= len(m.preflight.visibleIdx) {
+		return m, nil
+	}
+	idx := m.preflight.visibleIdx[m.preflight.listIndex]
+	if len(m.preflight.items[idx].Conflicts) == 0 {
+		m.statusMsg = "Keine Konflikte für diese Story (C: prüfen)"
+		return m, nil
+	}
+	m.conflictView = ConflictResolverState{itemIdx: idx}
+	m.state = stateConflictResolver
+	return m, nil
+}
+
+// resolveConflict records the chosen side for the conflict under the cursor.
+func (m *Model) resolveConflict(side string) {
+	it := &m.preflight.items[m.conflictView.itemIdx]
+	c := &it.Conflicts[m.conflictView.index]
+	if it.Resolutions == nil {
+		it.Resolutions = make(map[string]string)
+	}
+	it.Resolutions[c.Path] = side
+	c.Resolution = side
+}
+
+func (m Model) handleConflictResolverKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	cv := &m.conflictView
+	conflicts := m.preflight.items[cv.itemIdx].Conflicts
+	switch msg.String() {
+	case "esc", "q", "b", "enter":
+		open := sync.UnresolvedConflicts(conflicts)
+		m.statusMsg = fmt.Sprintf("%d von %d Konflikten aufgelöst", len(conflicts)-open, len(conflicts))
+		m.state = statePreflight
+		m.updateViewportContent()
+	case "j", "down":
+		if cv.index < len(conflicts)-1 {
+			cv.index++
+		}
+	case "k", "up":
+		if cv.index > 0 {
+			cv.index--
+		}
+	case "s":
+		m.resolveConflict(sync.ResolveSource)
+	case "t":
+		m.resolveConflict(sync.ResolveTarget)
+	case "S", "T":
+		side := sync.ResolveSource
+		if msg.String() == "T" {
+			side = sync.ResolveTarget
+		}
+		for i := range conflicts {
+			cv.index = i
+			m.resolveConflict(side)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) viewConflictResolver() string {
+	it := m.preflight.items[m.conflictView.itemIdx]
+	title := listHeaderStyle.Render("Merge-Konflikte: " + it.Story.FullSlug)
+	var lines []string
+	lines = append(lines, subtitleStyle.Render("Quelle und Ziel haben diese Felder seit dem letzten Sync unterschiedlich geändert. Ohne Auswahl bleibt der Zielwert."), "")
+	width := m.width - 20
+	if width < 30 {
+		width = 30
+	}
+	for i, c := range it.Conflicts {
+		marker := "  "
+		if i == m.conflictView.index {
+			marker = "> "
+		}
+		choice := subtleStyle.Render("[offen → Ziel]")
+		switch c.Resolution {
+		case sync.ResolveSource:
+			choice = okStyle.Render("[Quelle]")
+		case sync.ResolveTarget:
+			choice = okStyle.Render("[Ziel]")
+		}
+		lines = append(lines, spaceItemStyle.Render(marker+c.Path)+" "+choice)
+		if i == m.conflictView.index {
+			lines = append(lines,
+				"      Base:   "+subtleStyle.Render(sync.ValuePreview(c.Base, width)),
+				"      Ziel:   "+sync.ValuePreview(c.Target, width),
+				"      Quelle: "+sync.ValuePreview(c.Source, width))
+		}
+	}
+	help := renderFooter("", "⌨️  ↑↓/j/k: wählen  •  s/t: Quelle/Ziel  •  S/T: alle  •  Enter/Esc: zurück")
+	return title + "\n\n" + strings.Join(lines, "\n") + "\n\n" + help
+}
@@ -0,0 +1,215 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/sb"
+)
+
+// MergeDiffState holds the field-level diff screen for one preflight item.
+type MergeDiffState struct {
+	slug     string
+	changes  []sync.FieldChange
+	offset   int
+	loading  bool
+	errorMsg string
+}
+
+// mergeDiffMsg carries the field changes the merge would apply to one story.
+type mergeDiffMsg struct {
+	slug    string
+	changes []sync.FieldChange
+	err     error
+}
+
+// mergeSettingsFromEnv reads SB_MERGE_POLICY, SB_MERGE_DENY and SB_MERGE_ALLOW.
+// Invalid values are logged and fall back to overwrite / no rules.
+func mergeSettingsFromEnv(policy, deny, allow string) (string, sync.FieldRules) {
+	p, err := sync.ParseMergePolicy(policy)
+	if err != nil {
+		log.Printf("SB_MERGE_POLICY: %v", err)
+	}
+	rules, err := sync.ParseFieldRules(deny, allow)
+	if err != nil {
+		log.Printf("SB_MERGE_DENY/SB_MERGE_ALLOW: %v", err)
+	}
+	return p, rules
+}
+
+// toggleMergePolicy cycles overwrite → source-wins-per-field → fill-missing-only.
+func (m *Model) toggleMergePolicy() {
+	m.mergePolicy = sync.NextMergePolicy(m.mergePolicy)
+	m.enableBaseStore()
+	m.statusMsg = "Merge-Policy: " + m.mergePolicy
+}
+
+// enableBaseStore starts recording merge bases once a merge policy is
+// chosen; plain overwrite syncs without SB_BASE_DIR record none.
+func (m *Model) enableBaseStore() {
+	if m.baseStore == nil && m.mergePolicy != sync.MergeOverwrite {
+		m.baseStore = sync.NewBaseStore("")
+	}
+}
+
+// openMergeDiff shows how the current merge settings would change the target
+// content of the selected colliding story.
+func (m Model) openMergeDiff() (Model, tea.Cmd) {
+	if m.preflight.listIndex < 0 || m.preflight.listIndex >= len(m.preflight.visibleIdx) {
+		return m, nil
+	}
+	it := m.preflight.items[m.preflight.visibleIdx[m.preflight.listIndex]]
+	if it.Story.IsFolder || !it.Collision || it.CopyAsNew {
+		m.statusMsg = "Diff nur für bestehende Ziel-Stories verfügbar"
+		return m, nil
+	}
+	targetID := 0
+	for _, st := range m.storiesTarget {
+		if st.FullSlug == it.Story.FullSlug {
+			targetID = st.ID
+			break
+		}
+	}
+	if targetID == 0 {
+		m.statusMsg = "Ziel-Story nicht im Scan gefunden"
+		return m, nil
+	}
+	m.mergeDiff = MergeDiffState{slug: it.Story.FullSlug, loading: true}
+	m.state = stateMergeDiff
+	return m, m.mergeDiffCmd(it.Story.ID, targetID, it.Story.FullSlug, it.Resolutions)
+}
+
+func (m Model) mergeDiffCmd(sourceID, targetID int, slug string, resolutions map[string]string) tea.Cmd {
+	srcID, tgtID := 0, 0
+	if m.sourceSpace != nil {
+		srcID = m.sourceSpace.ID
+	}
+	if m.targetSpace != nil {
+		tgtID = m.targetSpace.ID
+	}
+	token := m.cfg.Token
+	policy, rules, transforms := m.mergePolicy, m.fieldRules, m.transforms
+	store := m.baseStore
+	if len(m.syncLanguages) > 0 {
+		// language-scoped syncs merge translations without base
+		store = nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		c := sb.New(token)
+		src, err := c.GetStoryRaw(ctx, srcID, sourceID)
+		if err != nil {
+			return mergeDiffMsg{slug: slug, err: fmt.Errorf("source: %w", err)}
+		}
+		tgt, err := c.GetStoryRaw(ctx, tgtID, targetID)
+		if err != nil {
+			return mergeDiffMsg{slug: slug, err: fmt.Errorf("target: %w", err)}
+		}
+		var base map[string]interface{}
+		if store != nil {
+			payload, ok, err := store.Load(tgtID, targetID)
+			if err != nil {
+				return mergeDiffMsg{slug: slug, err: fmt.Errorf("base: %w", err)}
+			}
+			if ok {
+				base, _ = payload["content"].(map[string]interface{})
+			}
+		}
+		// diff against the payload as it will be written
+		return mergeDiffMsg{slug: slug, changes: sync.PreviewMerge(tgt, src, base, resolutions, policy, rules, transforms, tgtID)}
+	}
+}
+
+// applyMergeDiff stores the computed changes if they belong to the open diff.
+func (m *Model) applyMergeDiff(msg mergeDiffMsg) {
+	if msg.slug != m.mergeDiff.slug {
+		return
+	}
+	m.mergeDiff.loading = false
+	if msg.err != nil {
+		m.mergeDiff.errorMsg = "Diff konnte nicht berechnet werden: " + msg.err.Error()
+		return
+	}
+	m.mergeDiff.changes = msg.changes
+}
+
+// mergeDiffPageSize returns how many change lines fit on screen.
+func (m Model) mergeDiffPageSize() int {
+	if n := m.height - 12; n > 5 {
+		return n
+	}
+	return 5
+}
+
+func (m Model) handleMergeDiffKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	d := &m.mergeDiff
+	maxOffset := len(d.changes) - m.mergeDiffPageSize()
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+	switch msg.String() {
+	case "esc", "q", "b", "d":
+		m.state = statePreflight
+		m.updateViewportContent()
+	case "j", "down":
+		if d.offset < maxOffset {
+			d.offset++
+		}
+	case "k", "up":
+		if d.offset > 0 {
+			d.offset--
+		}
+	}
+	return m, nil
+}
+
+func (m Model) viewMergeDiff() string {
+	title := listHeaderStyle.Render("Feld-Diff: " + m.mergeDiff.slug)
+	var lines []string
+	sub := "Merge-Policy: " + m.mergePolicy
+	if r := m.fieldRules.String(); r != "" {
+		sub += "  |  Regeln: " + r
+	}
+	if t := m.transforms.String(); t != "" {
+		sub += "  |  Transforms: " + t
+	}
+	lines = append(lines, subtitleStyle.Render(sub), "")
+	switch {
+	case m.mergeDiff.loading:
+		lines = append(lines, m.spinner.View()+" lade Quell- und Ziel-Content…")
+	case m.mergeDiff.errorMsg != "":
+		lines = append(lines, errorStyle.Render("❌ "+m.mergeDiff.errorMsg))
+	case len(m.mergeDiff.changes) == 0:
+		lines = append(lines, subtleStyle.Render("Keine abweichenden Felder."))
+	default:
+		counts := map[string]int{}
+		for _, c := range m.mergeDiff.changes {
+			counts[c.Action]++
+		}
+		lines = append(lines, fmt.Sprintf("%d übernehmen · %d auffüllen · %d entfernen · %d Ziel behalten · %d geschützt",
+			counts[sync.FieldOverwrite], counts[sync.FieldFill], counts[sync.FieldRemove], counts[sync.FieldKeep], counts[sync.FieldProtected]), "")
+		end := m.mergeDiff.offset + m.mergeDiffPageSize()
+		if end > len(m.mergeDiff.changes) {
+			end = len(m.mergeDiff.changes)
+		}
+		for _, c := range m.mergeDiff.changes[m.mergeDiff.offset:end] {
+			line := c.String()
+			switch c.Action {
+			case sync.FieldProtected, sync.FieldKeep:
+				line = warnStyle.Render(line)
+			case sync.FieldRemove:
+				line = errorStyle.Render(line)
+			}
+			lines = append(lines, "  "+line)
+		}
+	}
+	help := renderFooter("", "⌨️  ↑↓/j/k: scrollen  •  Esc/d: zurück")
+	return title + "\n\n" + strings.Join(lines, "\n") + "\n\n" + help
+}
@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/sb"
+)
+
+// ScheduleInputState holds the inline publish time input of the preflight.
+type ScheduleInputState struct {
+	active   bool
+	planWide bool   // apply to all selected stories instead of one
+	slug     string // target item when not plan-wide
+	input    textinput.Model
+	errorMsg string
+}
+
+// targetTimezoneMsg carries the target space's timezone name
+type targetTimezoneMsg struct {
+	name string
+	err  error
+}
+
+func (m Model) loadTargetTimezoneCmd() tea.Cmd {
+	tgtID := 0
+	if m.targetSpace != nil {
+		tgtID = m.targetSpace.ID
+	}
+	token := m.cfg.Token
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		d, err := sb.New(token).GetSpaceDetails(ctx, tgtID)
+		return targetTimezoneMsg{name: d.Timezone, err: err}
+	}
+}
+
+// applyTargetTimezone stores the target location; failures fall back to UTC.
+func (m *Model) applyTargetTimezone(msg targetTimezoneMsg) {
+	if msg.err != nil {
+		m.targetLoc = time.UTC
+		m.statusMsg = "Zeitzone des Ziels unbekannt, verwende UTC: " + msg.err.Error()
+		return
+	}
+	m.targetLoc = sync.LoadTimezone(msg.name)
+}
+
+// openScheduleInput starts entering a publish time for the item under the
+// cursor or, when planWide, for all selected stories.
+func (m Model) openScheduleInput(planWide bool) (Model, tea.Cmd) {
+	slug := ""
+	if !planWide {
+		if m.preflight.listIndex < 0 || m.preflight.listIndex >= len(m.preflight.visibleIdx) {
+			return m, nil
+		}
+		it := m.preflight.items[m.preflight.visibleIdx[m.preflight.listIndex]]
+		if it.Story.IsFolder || !it.Selected || it.Skip {
+			return m, nil
+		}
+		slug = it.Story.FullSlug
+	}
+	in := textinput.New()
+	in.Placeholder = "YYYY-MM-DD HH:MM"
+	in.CharLimit = 40
+	in.Width = 24
+	if at := m.publishAt[slug]; at != "" && m.targetLoc != nil {
+		if t, err := sync.ParsePublishAt(at); err == nil {
+			in.SetValue(t.In(m.targetLoc).Format("2006-01-02 15:04"))
+		}
+	}
+	m.schedule = ScheduleInputState{active: true, planWide: planWide, slug: slug, input: in}
+	cmds := []tea.Cmd{m.schedule.input.Focus()}
+	if m.targetLoc == nil {
+		cmds = append(cmds, m.loadTargetTimezoneCmd())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) handleScheduleInputKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.schedule.active = false
+		return m, nil
+	case "enter":
+		if m.targetLoc == nil {
+			m.schedule.errorMsg = "Zeitzone des Ziels wird noch geladen…"
+			return m, nil
+		}
+		t, err := sync.ParseSchedule(m.schedule.input.Value(), m.targetLoc)
+		if err == nil {
+			err = sync.ValidateSchedule(t, time.Now())
+		}
+		if err != nil {
+			m.schedule.errorMsg = err.Error()
+			return m, nil
+		}
+		n := m.applySchedule(sync.FormatPublishAt(t))
+		m.schedule.active = false
+		m.statusMsg = fmt.Sprintf("Veröffentlichung geplant für %d Stories: %s", n, m.formatScheduleLocal(sync.FormatPublishAt(t)))
+		m.updateViewportContent()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.schedule.input, cmd = m.schedule.input.Update(msg)
+	return m, cmd
+}
+
+// applySchedule sets schedule mode and publish time on the target item(s).
+func (m *Model) applySchedule(at string) int {
+	if m.publishAt == nil {
+		m.publishAt = make(map[string]string)
+	}
+	n := 0
+	for _, it := range m.preflight.items {
+		if it.Story.IsFolder || !it.Selected || it.Skip {
+			continue
+		}
+		if !m.schedule.planWide && it.Story.FullSlug != m.schedule.slug {
+			continue
+		}
+		m.publishAt[it.Story.FullSlug] = at
+		m.setPublishMode(it.Story.FullSlug, PublishModeSchedule)
+		n++
+	}
+	return n
+}
+
+// formatScheduleLocal renders a UTC publish_at in the target timezone.
+func (m Model) formatScheduleLocal(at string) string {
+	t, err := sync.ParsePublishAt(at)
+	if err != nil {
+		return at
+	}
+	loc := m.targetLoc
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format("2006-01-02 15:04 MST")
+}
+
+// renderScheduleInput renders the inline schedule prompt for the footer.
+func (m Model) renderScheduleInput() string {
+	scope := "Story " + m.schedule.slug
+	if m.schedule.planWide {
+		scope = "alle ausgewählten Stories"
+	}
+	tz := "…"
+	if m.targetLoc != nil {
+		tz = m.targetLoc.String()
+	}
+	line := fmt.Sprintf("Veröffentlichen am (%s, Zeitzone %s): %s", scope, tz, m.schedule.input.View())
+	if m.schedule.errorMsg != "" {
+		line += "  " + errorStyle.Render(m.schedule.errorMsg)
+	}
+	return strings.TrimSpace(line)
+}
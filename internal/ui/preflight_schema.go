@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/sb"
+)
+
+// schemaValidateMsg carries schema issues per source story ID and the IDs
+// of all stories that were validated.
+type schemaValidateMsg struct {
+	issues    map[int][]sync.SchemaIssue
+	validated map[int]bool
+	checked   int
+	err       error
+}
+
+// schemaPolicyFromEnv maps SB_SCHEMA_POLICY to a known policy (default: warn).
+func schemaPolicyFromEnv(v string) string {
+	if strings.EqualFold(strings.TrimSpace(v), sync.SchemaPolicyBlock) {
+		return sync.SchemaPolicyBlock
+	}
+	return sync.SchemaPolicyWarn
+}
+
+// validateSchemasCmd loads the target's component schemas and validates the
+// content of all selected, non-skipped stories in the preflight list. Reads
+// go through the shared per-space limiter.
+func (m Model) validateSchemasCmd() tea.Cmd {
+	srcID, tgtID := 0, 0
+	if m.sourceSpace != nil {
+		srcID = m.sourceSpace.ID
+	}
+	if m.targetSpace != nil {
+		tgtID = m.targetSpace.ID
+	}
+	token := m.cfg.Token
+	lim := m.fanOut.limiter
+	var ids []int
+	for _, it := range m.preflight.items {
+		if it.Story.IsFolder || !it.Selected || (it.Skip && !it.SchemaBlocked) {
+			continue
+		}
+		ids = append(ids, it.Story.ID)
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		c := sb.New(token)
+
+		if err := waitRead(ctx, lim, tgtID); err != nil {
+			return schemaValidateMsg{err: err}
+		}
+		comps, err := c.ListComponents(ctx, tgtID)
+		if err != nil {
+			return schemaValidateMsg{err: fmt.Errorf("target components: %w", err)}
+		}
+		v := sync.NewSchemaValidator(comps)
+
+		issues := make(map[int][]sync.SchemaIssue, len(ids))
+		validated := make(map[int]bool, len(ids))
+		for _, id := range ids {
+			if err := waitRead(ctx, lim, srcID); err != nil {
+				return schemaValidateMsg{err: err}
+			}
+			st, err := c.GetStoryWithContent(ctx, srcID, id)
+			if err != nil {
+				return schemaValidateMsg{err: fmt.Errorf("source story %d: %w", id, err)}
+			}
+			validated[id] = true
+			if found := v.ValidateStory(st); len(found) > 0 {
+				issues[id] = found
+			}
+		}
+		return schemaValidateMsg{issues: issues, validated: validated, checked: len(ids)}
+	}
+}
+
+// applySchemaValidation records validation results on the validated
+// preflight items according to the current schema policy; items that were
+// not validated keep their earlier results.
+func (m *Model) applySchemaValidation(msg schemaValidateMsg) {
+	m.schemaValidating = false
+	if msg.err != nil {
+		m.statusMsg = "Schema-Prüfung fehlgeschlagen: " + msg.err.Error()
+		return
+	}
+	affected := 0
+	for i := range m.preflight.items {
+		it := &m.preflight.items[i]
+		if it.Story.IsFolder || !msg.validated[it.Story.ID] {
+			continue
+		}
+		found := msg.issues[it.Story.ID]
+		if len(found) > 0 {
+			affected++
+		}
+		sync.ApplySchemaIssues(it, found, m.schemaPolicy)
+	}
+	if affected == 0 {
+		m.statusMsg = fmt.Sprintf("Schema-Prüfung: %d Stories ok", msg.checked)
+		return
+	}
+	m.statusMsg = fmt.Sprintf("Schema-Prüfung: %d von %d Stories mit Abweichungen (Policy: %s)", affected, msg.checked, m.schemaPolicy)
+}
+
+// toggleSchemaPolicy switches between warn and block and re-applies the
+// policy to items that already carry schema issues.
+func (m *Model) toggleSchemaPolicy() {
+	if m.schemaPolicy == sync.SchemaPolicyBlock {
+		m.schemaPolicy = sync.SchemaPolicyWarn
+	} else {
+		m.schemaPolicy = sync.SchemaPolicyBlock
+	}
+	for i := range m.preflight.items {
+		it := &m.preflight.items[i]
+		hasIssue := strings.HasPrefix(it.Issue, "schema: ")
+		switch {
+		case m.schemaPolicy == sync.SchemaPolicyBlock && hasIssue && !it.Skip:
+			it.SchemaBlocked = true
+			it.Skip = true
+			recalcState(it)
+		case m.schemaPolicy == sync.SchemaPolicyWarn && it.SchemaBlocked:
+			it.SchemaBlocked = false
+			it.Skip = false
+			recalcState(it)
+		}
+	}
+	m.statusMsg = "Schema-Policy: " + m.schemaPolicy
+}
@@ -2,10 +2,13 @@ package ui
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	sync "storyblok-sync/internal/core/sync"
 	"storyblok-sync/internal/sb"
 )
 
@@ -1079,3 +1082,419 @@ func TestFolderForkWithTranslatedSlugs(t *testing.T) {
 		}
 	}
 }
+
+func TestPreflightSchemaValidationPolicy(t *testing.T) {
+	st1 := sb.Story{ID: 1, Name: "one", Slug: "one", FullSlug: "one"}
+	st2 := sb.Story{ID: 2, Name: "two", Slug: "two", FullSlug: "two"}
+	m := InitialModel()
+	m.storiesSource = []sb.Story{st1, st2}
+	m.rebuildStoryIndex()
+	m.applyFilter()
+	m.selection.selected[st1.FullSlug] = true
+	m.selection.selected[st2.FullSlug] = true
+	m.startPreflight()
+	m.schemaPolicy = sync.SchemaPolicyWarn
+
+	issues := map[int][]sync.SchemaIssue{1: {{Component: "gallery", Kind: sync.SchemaIssueUnknownComponent}}}
+	model, _ := m.Update(schemaValidateMsg{issues: issues, validated: map[int]bool{1: true, 2: true}, checked: 2})
+	m = model.(Model)
+	first := m.preflight.items[0]
+	if first.Issue == "" || first.Skip {
+		t.Fatalf("warn policy should annotate without skipping: %+v", first)
+	}
+	if m.preflight.items[1].Issue != "" {
+		t.Fatalf("expected no issue on valid story")
+	}
+
+	// a later run that did not validate an item leaves its issue alone
+	model, _ = m.Update(schemaValidateMsg{validated: map[int]bool{2: true}, checked: 1})
+	m = model.(Model)
+	if m.preflight.items[0].Issue != first.Issue {
+		t.Fatalf("unchecked item lost its issue: %+v", m.preflight.items[0])
+	}
+	if !strings.Contains(m.renderPreflightContent(), "unknown component") {
+		t.Fatalf("expected issue to be rendered in preflight list")
+	}
+
+	// Switching to block skips affected items, switching back releases them
+	m, _ = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	if m.schemaPolicy != sync.SchemaPolicyBlock || !m.preflight.items[0].Skip || m.preflight.items[0].State != StateSkip {
+		t.Fatalf("block policy should skip item: %+v", m.preflight.items[0])
+	}
+	m, _ = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	if m.preflight.items[0].Skip || m.preflight.items[0].State != StateCreate {
+		t.Fatalf("warn policy should release item: %+v", m.preflight.items[0])
+	}
+}
+
+func TestPreflightWorkflowCheckBlocksLockedStages(t *testing.T) {
+	st1 := sb.Story{ID: 1, Name: "one", Slug: "one", FullSlug: "one"}
+	st2 := sb.Story{ID: 2, Name: "two", Slug: "two", FullSlug: "two"}
+	m := InitialModel()
+	m.storiesSource = []sb.Story{st1, st2}
+	m.storiesTarget = []sb.Story{{ID: 11, Name: "one", Slug: "one", FullSlug: "one"}, {ID: 12, Name: "two", Slug: "two", FullSlug: "two"}}
+	m.targetSpace = &sb.Space{ID: 2}
+	m.rebuildStoryIndex()
+	m.applyFilter()
+	m.selection.selected[st1.FullSlug] = true
+	m.selection.selected[st2.FullSlug] = true
+	m.startPreflight()
+	m.workflowTransition = false
+	m.workflowLockedStages = []string{"Approved"}
+
+	stages := []sb.WorkflowStage{
+		{ID: 1, Name: "Drafting", WorkflowID: 1, IsDefault: true, AllowAllUsers: true},
+		{ID: 2, Name: "Approved", WorkflowID: 1, Position: 2},
+	}
+	model, _ := m.Update(workflowCheckMsg{stages: stages, current: map[string]int{"one": 2, "two": 1}})
+	m = model.(Model)
+	one, two := m.preflight.items[0], m.preflight.items[1]
+	if !one.WorkflowLocked || !one.Skip || one.State != StateSkip {
+		t.Fatalf("locked stage should block item without opt-in: %+v", one)
+	}
+	if two.WorkflowLocked || two.Skip || two.WorkflowStage != "Drafting" {
+		t.Fatalf("editable stage should not block: %+v", two)
+	}
+	if !strings.Contains(m.renderPreflightContent(), "Approved") {
+		t.Fatalf("expected locked stage to be rendered")
+	}
+
+	// Opting in releases the item; the guard is built for the sync run
+	m, _ = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	if !m.workflowTransition || m.preflight.items[0].Skip || m.preflight.items[0].State != StateUpdate {
+		t.Fatalf("opt-in should release item: %+v", m.preflight.items[0])
+	}
+	if g := m.newWorkflowGuard(nil); g == nil || !g.AllowTransition() || !g.IsLocked(2) {
+		t.Fatalf("unexpected guard: %+v", g)
+	}
+}
+
+func TestStorySyncBuildsWorkflowGuardOnlyWhenEnabled(t *testing.T) {
+	t.Chdir(t.TempDir())
+	start := func(locked []string) Model {
+		m := InitialModel()
+		m.journalPath = ""
+		m.workflowLockedStages = locked
+		m.sourceSpace = &sb.Space{ID: 1, Name: "src"}
+		m.targetSpace = &sb.Space{ID: 2, Name: "tgt"}
+		m.preflight.items = []PreflightItem{{Story: sb.Story{ID: 1, FullSlug: "a"}, Selected: true, Collision: true, State: StateUpdate, Run: RunPending}}
+		m, _ = m.beginStorySync(true)
+		m.syncCancel()
+		return m
+	}
+	if m := start(nil); m.workflowGuard != nil {
+		t.Fatal("expected no workflow guard without workflow checks")
+	}
+	if m := start([]string{"Approved"}); m.workflowGuard == nil {
+		t.Fatal("expected a workflow guard for configured locked stages without a preflight check")
+	}
+}
+
+func TestReleasePickerSelectsRelease(t *testing.T) {
+	m := InitialModel()
+	m.targetSpace = &sb.Space{ID: 2, Name: "target"}
+	m.state = statePreflight
+
+	m, cmd := m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	if m.state != stateReleasePicker || cmd == nil || !m.releasePick.loading {
+		t.Fatalf("expected release picker with pending load, state=%v", m.state)
+	}
+	model, _ := m.Update(releasesMsg{releases: []sb.Release{{ID: 7, Name: "Spring"}}})
+	m = model.(Model)
+
+	// option 1 is the first release; option 2 would create a new one
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = model.(Model)
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+	if m.state != statePreflight || m.release == nil || m.release.ID != 7 {
+		t.Fatalf("expected release 7 selected, got %+v (state %v)", m.release, m.state)
+	}
+	if !strings.Contains(m.renderPreflightHeader(), "Release: Spring") {
+		t.Fatalf("header should show release: %s", m.renderPreflightHeader())
+	}
+
+	// creating a new release selects it
+	m, _ = m.openReleasePicker()
+	model, _ = m.Update(releaseCreatedMsg{release: sb.Release{ID: 9, Name: "sbsync new"}})
+	m = model.(Model)
+	if m.release == nil || m.release.ID != 9 || m.state != statePreflight {
+		t.Fatalf("expected created release selected, got %+v", m.release)
+	}
+}
+
+func TestLanguagePickerValidatesTarget(t *testing.T) {
+	m := InitialModel()
+	m.sourceSpace = &sb.Space{ID: 1, Name: "source"}
+	m.targetSpace = &sb.Space{ID: 2, Name: "target"}
+	m.state = statePreflight
+
+	m, cmd := m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	if m.state != stateLanguagePicker || cmd == nil || !m.langPick.loading {
+		t.Fatalf("expected language picker with pending load, state=%v", m.state)
+	}
+	model, _ := m.Update(languagesMsg{
+		source: []sb.Language{{Code: "fr", Name: "Français"}, {Code: "it", Name: "Italiano"}},
+		target: []sb.Language{{Code: "fr", Name: "Français"}},
+	})
+	m = model.(Model)
+
+	press := func(msg tea.KeyMsg) {
+		model, _ := m.Update(msg)
+		m = model.(Model)
+	}
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	down := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}
+	// mark it (not configured in target) -> rejected
+	press(down)
+	press(down)
+	press(space)
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != stateLanguagePicker || !strings.Contains(m.langPick.errorMsg, "it") {
+		t.Fatalf("expected validation error for it, got %q (state %v)", m.langPick.errorMsg, m.state)
+	}
+	// switch to fr only
+	press(space)
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	press(space)
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != statePreflight || len(m.syncLanguages) != 1 || m.syncLanguages[0] != "fr" {
+		t.Fatalf("expected fr scope, got %v (state %v)", m.syncLanguages, m.state)
+	}
+	if !strings.Contains(m.renderPreflightHeader(), "Sprachen: fr") {
+		t.Fatalf("header should show languages: %s", m.renderPreflightHeader())
+	}
+
+	// option 0 resets to a full sync
+	m, _ = m.openLanguagePicker()
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != stateLanguagePicker {
+		t.Fatalf("enter must wait for loaded languages")
+	}
+	model, _ = m.Update(languagesMsg{source: []sb.Language{{Code: "fr"}}, target: []sb.Language{{Code: "fr"}}})
+	m = model.(Model)
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.syncLanguages != nil || m.state != statePreflight {
+		t.Fatalf("expected full sync, got %v", m.syncLanguages)
+	}
+}
+
+func TestPreflightMergePolicyAndDiff(t *testing.T) {
+	t.Setenv("SB_MERGE_POLICY", "")
+	t.Setenv("SB_BASE_DIR", "")
+	st := sb.Story{ID: 1, Name: "page", Slug: "page", FullSlug: "page"}
+	m := InitialModel()
+	if m.baseStore != nil {
+		t.Fatal("plain overwrite syncs should not record merge bases")
+	}
+	m.sourceSpace = &sb.Space{ID: 1, Name: "source"}
+	m.targetSpace = &sb.Space{ID: 2, Name: "target"}
+	m.storiesTarget = []sb.Story{{ID: 50, FullSlug: "page"}}
+	m.preflight.items = []PreflightItem{{Story: st, Collision: true, Selected: true, State: StateUpdate}}
+	m.refreshPreflightVisible()
+	m.state = statePreflight
+	if m.mergePolicy != sync.MergeOverwrite {
+		t.Fatalf("expected overwrite by default, got %q", m.mergePolicy)
+	}
+
+	m, _ = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	if m.mergePolicy != sync.MergeSourceWins || !strings.Contains(m.renderPreflightHeader(), "Merge: "+sync.MergeSourceWins) {
+		t.Fatalf("expected source-wins policy in header: %s", m.renderPreflightHeader())
+	}
+	if m.baseStore == nil {
+		t.Fatal("choosing a merge policy should enable merge bases")
+	}
+
+	m, cmd := m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if m.state != stateMergeDiff || cmd == nil || !m.mergeDiff.loading {
+		t.Fatalf("expected diff screen with pending load, state=%v", m.state)
+	}
+	changes := []sync.FieldChange{
+		{Component: "page", Field: "title", Action: sync.FieldOverwrite},
+		{Component: "page", Field: "seo", Action: sync.FieldProtected},
+	}
+	model, _ := m.Update(mergeDiffMsg{slug: "page", changes: changes})
+	m = model.(Model)
+	view := m.viewMergeDiff()
+	if !strings.Contains(view, "1 geschützt") || !strings.Contains(view, "seo (page)") {
+		t.Fatalf("diff view should list protected field:\n%s", view)
+	}
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = model.(Model)
+	if m.state != statePreflight {
+		t.Fatalf("esc should return to preflight, got %v", m.state)
+	}
+}
+
+func TestBaseStoreEnabledBySBBaseDir(t *testing.T) {
+	t.Setenv("SB_MERGE_POLICY", "")
+	t.Setenv("SB_BASE_DIR", t.TempDir())
+	if m := InitialModel(); m.baseStore == nil {
+		t.Fatal("SB_BASE_DIR should enable merge bases")
+	}
+}
+
+func TestPreflightConflictCheckUsesSharedLimiter(t *testing.T) {
+	m := InitialModel()
+	m.sourceSpace = &sb.Space{ID: 1, Name: "source"}
+	m.targetSpace = &sb.Space{ID: 2, Name: "target"}
+	m.preflight.items = []PreflightItem{{Story: sb.Story{ID: 1, FullSlug: "page"}, Collision: true, Selected: true, State: StateUpdate}}
+	m.refreshPreflightVisible()
+	m.state = statePreflight
+
+	m, cmd := m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	if cmd == nil || !m.conflictChecking || m.fanOut.limiter == nil {
+		t.Fatalf("expected a conflict check on the shared limiter, limiter=%v", m.fanOut.limiter)
+	}
+}
+
+func TestPreflightMergeDiffUsesVisibleItem(t *testing.T) {
+	folder := sb.Story{ID: 10, Name: "blog", Slug: "blog", FullSlug: "blog", IsFolder: true}
+	child := sb.Story{ID: 11, Name: "post", Slug: "post", FullSlug: "blog/post", FolderID: &folder.ID}
+	page := sb.Story{ID: 12, Name: "page", Slug: "page", FullSlug: "page"}
+	m := InitialModel()
+	m.targetSpace = &sb.Space{ID: 2, Name: "target"}
+	m.storiesTarget = []sb.Story{{ID: 51, FullSlug: "blog/post"}, {ID: 52, FullSlug: "page"}}
+	m.preflight.items = []PreflightItem{
+		{Story: folder, Selected: true, State: StateCreate},
+		{Story: child, Collision: true, Selected: true, State: StateUpdate},
+		{Story: page, Collision: true, Selected: true, State: StateUpdate},
+	}
+	m.folderCollapsed = map[int]bool{folder.ID: true}
+	m.refreshPreflightVisible()
+	m.preflight.listIndex = 1 // "page" directly below the collapsed folder
+	m.state = statePreflight
+
+	m, _ = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if m.state != stateMergeDiff || m.mergeDiff.slug != "page" {
+		t.Fatalf("expected diff for the visible item, got %q (state %v)", m.mergeDiff.slug, m.state)
+	}
+}
+
+func TestPreflightConflictResolverUsesVisibleItem(t *testing.T) {
+	folder := sb.Story{ID: 10, Name: "blog", Slug: "blog", FullSlug: "blog", IsFolder: true}
+	child := sb.Story{ID: 11, Name: "post", Slug: "post", FullSlug: "blog/post", FolderID: &folder.ID}
+	page := sb.Story{ID: 12, Name: "page", Slug: "page", FullSlug: "page"}
+	m := InitialModel()
+	m.preflight.items = []PreflightItem{
+		{Story: folder, Selected: true, State: StateCreate},
+		{Story: child, Collision: true, Selected: true, State: StateUpdate, Conflicts: []sync.MergeConflict{{Path: "title"}}},
+		{Story: page, Collision: true, Selected: true, State: StateUpdate, Conflicts: []sync.MergeConflict{{Path: "seo"}}},
+	}
+	m.folderCollapsed = map[int]bool{folder.ID: true}
+	m.refreshPreflightVisible()
+	m.preflight.listIndex = 1 // "page" directly below the collapsed folder
+	m.state = statePreflight
+
+	m, _ = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+	if m.state != stateConflictResolver || m.conflictView.itemIdx != 2 {
+		t.Fatalf("expected resolver for the visible item, got item %d (state %v)", m.conflictView.itemIdx, m.state)
+	}
+}
+
+func TestPreflightConflictResolver(t *testing.T) {
+	st := sb.Story{ID: 1, Name: "page", Slug: "page", FullSlug: "page"}
+	m := InitialModel()
+	m.preflight.items = []PreflightItem{{Story: st, Collision: true, Selected: true, State: StateUpdate}}
+	m.refreshPreflightVisible()
+	m.state = statePreflight
+
+	// Without conflicts the resolver does not open
+	m, _ = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+	if m.state != statePreflight {
+		t.Fatalf("resolver must not open without conflicts")
+	}
+
+	conflicts := []sync.MergeConflict{
+		{Path: "title", Base: "A", Target: "Ziel", Source: "Quelle"},
+		{Path: "body[b1].headline", Base: "A", Target: "x", Source: "y"},
+	}
+	model, _ := m.Update(conflictCheckMsg{conflicts: map[string][]sync.MergeConflict{"page": conflicts}, checked: 1})
+	m = model.(Model)
+	if !strings.Contains(m.renderPreflightContent(), "[Konflikte 2/2 offen]") {
+		t.Fatalf("expected conflict badge:\n%s", m.renderPreflightContent())
+	}
+
+	m, _ = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+	if m.state != stateConflictResolver {
+		t.Fatalf("expected resolver, got %v", m.state)
+	}
+	if !strings.Contains(m.viewConflictResolver(), `Quelle: "Quelle"`) {
+		t.Fatalf("resolver should preview values:\n%s", m.viewConflictResolver())
+	}
+	press := func(r rune) {
+		model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = model.(Model)
+	}
+	press('s')
+	press('j')
+	press('t')
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+	if m.state != statePreflight {
+		t.Fatalf("enter should return to preflight")
+	}
+	it := m.preflight.items[0]
+	want := map[string]string{"title": sync.ResolveSource, "body[b1].headline": sync.ResolveTarget}
+	if !reflect.DeepEqual(it.Resolutions, want) {
+		t.Fatalf("unexpected resolutions: %v", it.Resolutions)
+	}
+	if got := (&preflightItemAdapter{item: it}).MergeResolutions(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("adapter must pass resolutions, got %v", got)
+	}
+}
+
+func TestPreflightScheduleMode(t *testing.T) {
+	future := time.Now().Add(48 * time.Hour).UTC()
+	st1 := sb.Story{ID: 1, Name: "one", Slug: "one", FullSlug: "one", PublishAt: sync.FormatPublishAt(future)}
+	st2 := sb.Story{ID: 2, Name: "two", Slug: "two", FullSlug: "two"}
+	m := InitialModel()
+	m.storiesSource = []sb.Story{st1, st2}
+	m.rebuildStoryIndex()
+	m.applyFilter()
+	m.selection.selected[st1.FullSlug] = true
+	m.selection.selected[st2.FullSlug] = true
+	m.startPreflight()
+	m.targetLoc = time.UTC
+
+	// future source schedule is carried over
+	if m.getPublishMode("one") != PublishModeSchedule || m.scheduledAt("one") != sync.FormatPublishAt(future) {
+		t.Fatalf("expected carried-over schedule, mode=%s at=%s", m.getPublishMode("one"), m.scheduledAt("one"))
+	}
+	if m.getPublishMode("two") == PublishModeSchedule {
+		t.Fatalf("story without schedule must not default to schedule")
+	}
+
+	// plan-wide input rejects past times and applies future ones
+	m, _ = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	if !m.schedule.active || !m.schedule.planWide {
+		t.Fatalf("expected plan-wide schedule input")
+	}
+	m.schedule.input.SetValue("2000-01-01 10:00")
+	m, _ = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.schedule.active || m.schedule.errorMsg == "" {
+		t.Fatalf("past time should be rejected")
+	}
+	when := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Minute)
+	m.schedule.input.SetValue(when.Format("2006-01-02 15:04"))
+	m, _ = m.handlePreflightKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.schedule.active {
+		t.Fatalf("input should close on valid time: %s", m.schedule.errorMsg)
+	}
+	for _, slug := range []string{"one", "two"} {
+		if m.scheduledAt(slug) != sync.FormatPublishAt(when) {
+			t.Fatalf("%s: expected plan-wide schedule, got %q", slug, m.scheduledAt(slug))
+		}
+	}
+	if !strings.Contains(m.renderPreflightContent(), "[Plan ") {
+		t.Fatalf("expected schedule badge")
+	}
+
+	adapter := &preflightItemAdapter{item: m.preflight.items[1], overridePublish: true, publishAt: m.scheduledAt("two")}
+	if adapter.GetStory().PublishAt != sync.FormatPublishAt(when) || adapter.GetStory().Published {
+		t.Fatalf("adapter should carry publish_at as draft: %+v", adapter.GetStory())
+	}
+	if err := validateScheduledItem(sync.FormatPublishAt(time.Now().Add(-time.Hour))); err == nil {
+		t.Fatalf("expected past schedule to fail at sync time")
+	}
+}
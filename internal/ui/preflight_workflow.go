@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/sb"
+)
+
+// workflowCheckMsg carries the target's workflow stages and the current stage
+// of every colliding target story (keyed by full slug).
+type workflowCheckMsg struct {
+	stages  []sb.WorkflowStage
+	current map[string]int
+	err     error
+}
+
+// lockedStagesFromEnv parses SB_WORKFLOW_LOCKED_STAGES (comma-separated stage names).
+func lockedStagesFromEnv(v string) []string {
+	var out []string
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// workflowCheckCmd loads the target's workflow stages and looks up the stage
+// of each existing target story that the preflight would update.
+func (m Model) workflowCheckCmd() tea.Cmd {
+	tgtID := 0
+	if m.targetSpace != nil {
+		tgtID = m.targetSpace.ID
+	}
+	token := m.cfg.Token
+	targetIDs := make(map[string]int)
+	for _, st := range m.storiesTarget {
+		targetIDs[st.FullSlug] = st.ID
+	}
+	var slugs []string
+	for _, it := range m.preflight.items {
+		if it.Story.IsFolder || !it.Selected || !it.Collision || it.CopyAsNew || (it.Skip && !it.WorkflowBlocked) {
+			continue
+		}
+		if _, ok := targetIDs[it.Story.FullSlug]; ok {
+			slugs = append(slugs, it.Story.FullSlug)
+		}
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		c := sb.New(token)
+
+		stages, err := c.ListWorkflowStages(ctx, tgtID)
+		if err != nil {
+			return workflowCheckMsg{err: fmt.Errorf("workflow stages: %w", err)}
+		}
+		current := make(map[string]int, len(slugs))
+		if len(stages) == 0 {
+			return workflowCheckMsg{stages: stages, current: current}
+		}
+		for _, slug := range slugs {
+			changes, err := c.ListWorkflowStageChanges(ctx, tgtID, targetIDs[slug])
+			if err != nil {
+				return workflowCheckMsg{err: fmt.Errorf("target story %s: %w", slug, err)}
+			}
+			if id := sync.LatestWorkflowStage(changes); id != 0 {
+				current[slug] = id
+			}
+		}
+		return workflowCheckMsg{stages: stages, current: current}
+	}
+}
+
+// workflowChecksEnabled reports whether updates keep workflow stages: the
+// preflight loaded the target's stages or locked stages are configured.
+func (m Model) workflowChecksEnabled() bool {
+	return m.workflowStages != nil || len(m.workflowLockedStages) > 0
+}
+
+// newWorkflowGuard builds the guard used during sync, only when workflow
+// checks are enabled; otherwise updates cost no stage lookups. With locked
+// stages configured but no preflight check (w) the guard loads the target's
+// stages when the sync starts.
+func (m Model) newWorkflowGuard(api sync.WorkflowAPI) *sync.WorkflowGuard {
+	if m.targetSpace == nil || !m.workflowChecksEnabled() {
+		return nil
+	}
+	g := sync.NewWorkflowGuard(api, m.targetSpace.ID, m.workflowStages, m.workflowLockedStages, m.workflowTransition)
+	if l, ok := api.(sync.WorkflowStageLister); ok && m.workflowStages == nil {
+		g.LoadStagesFrom(l)
+	}
+	return g
+}
+
+// applyWorkflowCheck annotates preflight items with their target stage.
+func (m *Model) applyWorkflowCheck(msg workflowCheckMsg) {
+	m.workflowChecking = false
+	if msg.err != nil {
+		m.statusMsg = "Workflow-Prüfung fehlgeschlagen: " + msg.err.Error()
+		return
+	}
+	m.workflowStages = msg.stages
+	m.workflowCurrent = msg.current
+	m.reapplyWorkflowStages()
+	locked := 0
+	for _, it := range m.preflight.items {
+		if it.WorkflowLocked {
+			locked++
+		}
+	}
+	m.statusMsg = fmt.Sprintf("Workflow-Prüfung: %d Stories mit Stage, %d gesperrt (Stage-Wechsel: %s)", len(msg.current), locked, onOff(m.workflowTransition))
+}
+
+// reapplyWorkflowStages maps the last check onto the preflight items.
+func (m *Model) reapplyWorkflowStages() {
+	if m.workflowStages == nil {
+		return
+	}
+	guard := m.newWorkflowGuard(nil)
+	if guard == nil {
+		return
+	}
+	for i := range m.preflight.items {
+		it := &m.preflight.items[i]
+		stageID, ok := m.workflowCurrent[it.Story.FullSlug]
+		if !ok || it.Story.IsFolder {
+			sync.ApplyWorkflowStage(it, "", false, m.workflowTransition)
+			continue
+		}
+		sync.ApplyWorkflowStage(it, guard.StageName(stageID), guard.IsLocked(stageID), m.workflowTransition)
+	}
+}
+
+// toggleWorkflowTransition switches the opt-in for temporary stage changes.
+func (m *Model) toggleWorkflowTransition() {
+	m.workflowTransition = !m.workflowTransition
+	m.reapplyWorkflowStages()
+	m.statusMsg = "Stage-Wechsel für gesperrte Stories: " + onOff(m.workflowTransition)
+}
+
+func onOff(b bool) string {
+	if b {
+		return "an"
+	}
+	return "aus"
+}
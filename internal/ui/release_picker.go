@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"storyblok-sync/internal/sb"
+)
+
+// ReleasePickerState holds the target release selection screen.
+// Options: index 0 = live content, 1..n = open releases, n+1 = create new.
+type ReleasePickerState struct {
+	releases []sb.Release
+	index    int
+	loading  bool
+	creating bool
+	input    textinput.Model
+	errorMsg string
+}
+
+// releasesMsg carries the open releases of the target space
+type releasesMsg struct {
+	releases []sb.Release
+	err      error
+}
+
+// releaseCreatedMsg carries a newly created release
+type releaseCreatedMsg struct {
+	release sb.Release
+	err     error
+}
+
+// openReleasePicker switches to the release picker and loads the target's releases.
+func (m Model) openReleasePicker() (Model, tea.Cmd) {
+	in := textinput.New()
+	in.Placeholder = "Name der Release"
+	in.CharLimit = 100
+	in.Width = 40
+	in.SetValue("sbsync " + time.Now().Format("2006-01-02 15:04"))
+	m.releasePick = ReleasePickerState{loading: true, input: in}
+	m.state = stateReleasePicker
+	return m, m.loadReleasesCmd()
+}
+
+func (m Model) loadReleasesCmd() tea.Cmd {
+	tgtID := 0
+	if m.targetSpace != nil {
+		tgtID = m.targetSpace.ID
+	}
+	token := m.cfg.Token
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		all, err := sb.New(token).ListReleases(ctx, tgtID)
+		if err != nil {
+			return releasesMsg{err: err}
+		}
+		open := make([]sb.Release, 0, len(all))
+		for _, r := range all {
+			if !r.Released {
+				open = append(open, r)
+			}
+		}
+		return releasesMsg{releases: open}
+	}
+}
+
+func (m Model) createReleaseCmd(name string) tea.Cmd {
+	tgtID := 0
+	if m.targetSpace != nil {
+		tgtID = m.targetSpace.ID
+	}
+	token := m.cfg.Token
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		r, err := sb.New(token).CreateRelease(ctx, tgtID, name)
+		return releaseCreatedMsg{release: r, err: err}
+	}
+}
+
+// applyReleases stores loaded releases and preselects the current choice.
+func (m *Model) applyReleases(msg releasesMsg) {
+	m.releasePick.loading = false
+	if msg.err != nil {
+		m.releasePick.errorMsg = "Releases konnten nicht geladen werden: " + msg.err.Error()
+		return
+	}
+	m.releasePick.releases = msg.releases
+	m.releasePick.index = 0
+	if m.release != nil {
+		for i, r := range msg.releases {
+			if r.ID == m.release.ID {
+				m.releasePick.index = i + 1
+			}
+		}
+	}
+}
+
+// applyCreatedRelease selects a newly created release and returns to preflight.
+func (m *Model) applyCreatedRelease(msg releaseCreatedMsg) {
+	m.releasePick.loading = false
+	if msg.err != nil {
+		m.releasePick.errorMsg = "Release konnte nicht angelegt werden: " + msg.err.Error()
+		return
+	}
+	rel := msg.release
+	m.setRelease(&rel)
+}
+
+// setRelease selects the target release (nil: live content) and returns to preflight.
+func (m *Model) setRelease(r *sb.Release) {
+	m.release = r
+	m.releasePick.creating = false
+	m.state = statePreflight
+	if r == nil {
+		m.statusMsg = "Sync schreibt in den Live-Content"
+	} else {
+		m.statusMsg = fmt.Sprintf("Sync schreibt in Release %q (ID %d)", r.Name, r.ID)
+	}
+	m.updateViewportContent()
+}
+
+func (m Model) handleReleasePickerKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	key := msg.String()
+	rp := &m.releasePick
+	if rp.creating {
+		switch key {
+		case "esc":
+			rp.creating = false
+			rp.input.Blur()
+			return m, nil
+		case "enter":
+			name := strings.TrimSpace(rp.input.Value())
+			if name == "" {
+				rp.errorMsg = "Name darf nicht leer sein"
+				return m, nil
+			}
+			rp.loading = true
+			rp.errorMsg = ""
+			return m, m.createReleaseCmd(name)
+		}
+		var cmd tea.Cmd
+		rp.input, cmd = rp.input.Update(msg)
+		return m, cmd
+	}
+
+	last := len(rp.releases) + 1
+	switch key {
+	case "esc", "q", "b":
+		m.state = statePreflight
+		m.updateViewportContent()
+	case "j", "down":
+		if rp.index < last {
+			rp.index++
+		}
+	case "k", "up":
+		if rp.index > 0 {
+			rp.index--
+		}
+	case "enter":
+		if rp.loading {
+			return m, nil
+		}
+		switch {
+		case rp.index == 0:
+			m.setRelease(nil)
+		case rp.index == last:
+			rp.creating = true
+			rp.errorMsg = ""
+			return m, rp.input.Focus()
+		default:
+			rel := rp.releases[rp.index-1]
+			m.setRelease(&rel)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) viewReleasePicker() string {
+	title := listHeaderStyle.Render("Ziel-Release wählen")
+	var lines []string
+	lines = append(lines, subtitleStyle.Render("Änderungen werden in der Release gesammelt und im Storyblok-UI geprüft und gemerged."))
+	lines = append(lines, "")
+	if m.releasePick.loading {
+		lines = append(lines, m.spinner.View()+" lade Releases…")
+	} else {
+		options := []string{"Live-Content (keine Release)"}
+		for _, r := range m.releasePick.releases {
+			options = append(options, fmt.Sprintf("%s (ID %d)", r.Name, r.ID))
+		}
+		options = append(options, "Neue Release anlegen…")
+		for i, opt := range options {
+			marker := "  "
+			if i == m.releasePick.index {
+				marker = "> "
+			}
+			lines = append(lines, spaceItemStyle.Render(marker+opt))
+		}
+	}
+	if m.releasePick.creating {
+		lines = append(lines, "", "Name der neuen Release:", m.releasePick.input.View())
+	}
+	if m.releasePick.errorMsg != "" {
+		lines = append(lines, "", errorStyle.Render("❌ "+m.releasePick.errorMsg))
+	}
+	help := renderFooter("", "⌨️  ↑↓/j/k: wählen  •  Enter: bestätigen  •  Esc: zurück")
+	return title + "\n\n" + strings.Join(lines, "\n") + "\n\n" + help
+}
@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"storyblok-sync/internal/report"
+	"storyblok-sync/internal/sb"
+)
+
+// savedReport is one report file offered in the report picker.
+type savedReport struct {
+	path   string
+	report *Report
+}
+
+// ReportPickerState holds the "open report" screen listing saved reports.
+type ReportPickerState struct {
+	reports  []savedReport
+	index    int
+	errorMsg string
+}
+
+// RetryReport preloads a saved report whose failures are retried once the
+// token is validated (`sbsync retry <report.json>`).
+func (m Model) RetryReport(path string, r *Report) Model {
+	m.retryReport = r
+	m.retryReportPath = path
+	return m
+}
+
+// openReportPicker lists the saved reports in the working directory.
+func (m Model) openReportPicker() (Model, tea.Cmd) {
+	m.reportPick = ReportPickerState{}
+	files, err := report.List(".")
+	if err != nil {
+		m.reportPick.errorMsg = err.Error()
+	}
+	for _, f := range files {
+		r, err := report.Load(f)
+		if err != nil {
+			continue
+		}
+		m.reportPick.reports = append(m.reportPick.reports, savedReport{path: f, report: r})
+	}
+	m.state = stateReportPicker
+	return m, nil
+}
+
+func (m Model) handleReportPickerKey(key string) (Model, tea.Cmd) {
+	rp := &m.reportPick
+	switch key {
+	case "esc", "q", "b":
+		m.state = stateModePicker
+	case "j", "down":
+		if rp.index < len(rp.reports)-1 {
+			rp.index++
+		}
+	case "k", "up":
+		if rp.index > 0 {
+			rp.index--
+		}
+	case "enter":
+		if len(rp.reports) == 0 {
+			return m, nil
+		}
+		sel := rp.reports[rp.index]
+		if sel.report.Summary.Failure == 0 {
+			rp.errorMsg = "Der Report enthält keine Fehler"
+			return m, nil
+		}
+		m = m.RetryReport(sel.path, sel.report)
+		return m.startReportRetry()
+	}
+	return m, nil
+}
+
+// startReportRetry restores the spaces and settings of the loaded report and
+// rescans, so failures are resolved against the current source and target.
+func (m Model) startReportRetry() (Model, tea.Cmd) {
+	r := m.retryReport
+	srcID, tgtID := r.SpaceIDs()
+	src, okSrc := containsSpaceID(m.spaces, strconv.Itoa(srcID))
+	tgt, okTgt := containsSpaceID(m.spaces, strconv.Itoa(tgtID))
+	if !okSrc || !okTgt {
+		m.retryReport = nil
+		m.statusMsg = fmt.Sprintf("Spaces des Reports %s nicht gefunden", filepath.Base(m.retryReportPath))
+		if m.sourceSpace != nil && m.targetSpace != nil {
+			m.state = stateModePicker
+			return m, nil
+		}
+		m.state = stateSpaceSelect
+		m.selectingSource = true
+		m.selectedIndex = 0
+		return m, nil
+	}
+	m.sourceSpace = &src
+	m.targetSpace = &tgt
+	m.fanOut = FanOutState{}
+	for _, t := range r.Targets {
+		if t.ID == tgt.ID {
+			continue
+		}
+		if sp, ok := containsSpaceID(m.spaces, strconv.Itoa(t.ID)); ok {
+			m.fanOut.targets = append(m.fanOut.targets, sp)
+		}
+	}
+	m.release = nil
+	if r.ReleaseID > 0 {
+		m.release = &sb.Release{ID: r.ReleaseID, Name: r.Release}
+	}
+	m.syncLanguages = r.Languages
+	m.currentMode = modeStories
+	m.state = stateScanning
+	m.statusMsg = fmt.Sprintf("Scanne Spaces für Retry aus %s…", filepath.Base(m.retryReportPath))
+	return m, tea.Batch(m.spinner.Tick, m.scanStoriesCmd())
+}
+
+// retryFromReport builds a preflight of the report's failed items after the
+// rescan, keeping the publish modes they were synced with.
+func (m Model) retryFromReport() (Model, tea.Cmd) {
+	r := m.retryReport
+	name := filepath.Base(m.retryReportPath)
+	m.retryReport = nil
+	m.retryReportPath = ""
+	items, missing := m.failedItemsFromEntries(r.Entries)
+	for _, e := range r.Entries {
+		if e.Status != "failure" || e.PublishMode == "" {
+			continue
+		}
+		m.setPublishMode(e.Slug, e.PublishMode)
+		if e.PublishMode == PublishModeSchedule && e.PublishAt != "" {
+			if m.publishAt == nil {
+				m.publishAt = make(map[string]string)
+			}
+			m.publishAt[e.Slug] = e.PublishAt
+		}
+	}
+	if len(items) == 0 {
+		m.state = stateBrowseList
+		m.statusMsg = fmt.Sprintf("Keine Fehler aus %s in der Quelle gefunden", name)
+		m.updateViewportContent()
+		return m, nil
+	}
+	m.preflight = PreflightState{items: items, listIndex: 0}
+	// Items carry their target already; the fan-out must not expand them again
+	m.fanOut.expanded = len(m.fanOut.targets) > 0
+	m.refreshPreflightVisible()
+	m.state = statePreflight
+	m.statusMsg = fmt.Sprintf("Retry: %d fehlgeschlagene Items aus %s", len(items), name)
+	if len(missing) > 0 {
+		m.statusMsg += fmt.Sprintf(" – %d nicht mehr in der Quelle: %s", len(missing), strings.Join(missing, ", "))
+	}
+	m.updateViewportContent()
+	return m, nil
+}
+
+func (m Model) viewReportPicker() string {
+	title := listHeaderStyle.Render("Report öffnen – Fehler erneut synchronisieren")
+	var lines []string
+	if len(m.reportPick.reports) == 0 {
+		lines = append(lines, subtleStyle.Render("Keine sync-report-*.json im aktuellen Verzeichnis"))
+	}
+	for i, sr := range m.reportPick.reports {
+		marker := "  "
+		if i == m.reportPick.index {
+			marker = "> "
+		}
+		s := sr.report.Summary
+		line := fmt.Sprintf("%s%s  %s → %s  (%d Erfolge, %d Fehler)", marker, filepath.Base(sr.path), sr.report.SourceSpace, sr.report.TargetSpace, s.Success, s.Failure)
+		lines = append(lines, spaceItemStyle.Render(line))
+	}
+	if m.reportPick.errorMsg != "" {
+		lines = append(lines, "", errorStyle.Render("❌ "+m.reportPick.errorMsg))
+	}
+	help := renderFooter("", "⌨️  ↑↓/j/k: wählen  •  Enter: Fehler erneut synchronisieren  •  Esc: zurück")
+	return title + "\n\n" + strings.Join(lines, "\n") + "\n\n" + help
+}
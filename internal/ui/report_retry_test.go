@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"storyblok-sync/internal/report"
+	"storyblok-sync/internal/sb"
+)
+
+func writeReport(t *testing.T, dir string, r *Report) string {
+	t.Helper()
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "sync-report-20250101-120000.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRetryFailuresFromSavedReport(t *testing.T) {
+	dir := t.TempDir()
+	r := report.New("src (1)", "tgt (2)")
+	r.SourceSpaceID, r.TargetSpaceID = 1, 2
+	r.Add(ReportEntry{Slug: "app/ok", Status: "success", Operation: "create", PublishMode: PublishModePublish})
+	r.Add(ReportEntry{Slug: "app/page", Status: "failure", Operation: "sync", Error: "boom", PublishMode: PublishModePublish})
+	r.Add(ReportEntry{Slug: "app/later", Status: "failure", Operation: "sync", Error: "boom", PublishMode: PublishModeSchedule, PublishAt: "2030-01-01 08:00"})
+	r.Add(ReportEntry{Slug: "app/gone", Status: "failure", Operation: "sync", Error: "boom"})
+	path := writeReport(t, dir, r)
+	loaded, err := report.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := InitialModel().RetryReport(path, loaded)
+	m.cfg.Path = filepath.Join(dir, ".sbrc")
+	model, _ := m.Update(validateMsg{spaces: []sb.Space{{ID: 1, Name: "src"}, {ID: 2, Name: "tgt"}}})
+	m = model.(Model)
+	if m.state != stateScanning || m.sourceSpace.ID != 1 || m.targetSpace.ID != 2 {
+		t.Fatalf("expected scan of report spaces, got state %v", m.state)
+	}
+
+	src := []sb.Story{
+		{ID: 1, FullSlug: "app", IsFolder: true},
+		{ID: 2, FullSlug: "app/ok"},
+		{ID: 3, FullSlug: "app/page", Published: true},
+		{ID: 4, FullSlug: "app/later"},
+	}
+	tgt := []sb.Story{{ID: 90, FullSlug: "app", IsFolder: true}, {ID: 91, FullSlug: "app/page"}}
+	model, _ = m.Update(scanMsg{src: src, tgt: tgt})
+	m = model.(Model)
+	if m.state != statePreflight {
+		t.Fatalf("expected preflight, got %v", m.state)
+	}
+	if len(m.preflight.items) != 2 {
+		t.Fatalf("expected the two resolvable failures, got %+v", m.preflight.items)
+	}
+	if it := m.preflight.items[0]; it.Story.FullSlug != "app/page" || it.State != StateUpdate {
+		t.Fatalf("unexpected first item %+v", it)
+	}
+	if it := m.preflight.items[1]; it.Story.FullSlug != "app/later" || it.State != StateCreate {
+		t.Fatalf("unexpected second item %+v", it)
+	}
+	if m.getPublishMode("app/page") != PublishModePublish || m.scheduledAt("app/later") != "2030-01-01 08:00" {
+		t.Fatalf("publish modes not restored: %v %v", m.publishMode, m.publishAt)
+	}
+	if m.retryReport != nil {
+		t.Fatal("retry report should be consumed")
+	}
+}
+
+func TestReportPickerListsSavedReports(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	r := report.New("src (1)", "tgt (2)")
+	r.Add(ReportEntry{Slug: "a", Status: "success", Operation: "create"})
+	writeReport(t, dir, r)
+
+	m := InitialModel()
+	m.state = stateModePicker
+	m, _ = m.handleModePickerKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if m.state != stateReportPicker || len(m.reportPick.reports) != 1 {
+		t.Fatalf("expected picker with one report, got %v %d", m.state, len(m.reportPick.reports))
+	}
+	m, _ = m.handleReportPickerKey("enter")
+	if m.state != stateReportPicker || m.reportPick.errorMsg == "" {
+		t.Fatalf("expected a report without failures to be rejected")
+	}
+	m, _ = m.handleReportPickerKey("esc")
+	if m.state != stateModePicker {
+		t.Fatalf("expected mode picker, got %v", m.state)
+	}
+}
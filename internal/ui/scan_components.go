@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"storyblok-sync/internal/infra/logx"
+	"storyblok-sync/internal/infra/tracex"
 	"storyblok-sync/internal/sb"
 	"time"
 
@@ -36,16 +37,21 @@ func (m Model) scanComponentsCmd() tea.Cmd {
 			tgtID = m.targetSpace.ID
 		}
 		logx.Infof("COMP_SCAN start src=%d tgt=%d", srcID, tgtID)
+		ctx, span := tracex.Start(ctx, "scan", tracex.String("kind", "components"),
+			tracex.Int("source_space_id", srcID), tracex.Int("target_space_id", tgtID))
+		defer span.End()
 
 		// Load groups first
 		srcGroups, err := m.api.ListComponentGroups(ctx, srcID)
 		if err != nil {
 			logx.Errorf("COMP_SCAN groups source error: %v", err)
+			span.SetError(err)
 			return compScanMsg{err: err}
 		}
 		tgtGroups, err := m.api.ListComponentGroups(ctx, tgtID)
 		if err != nil {
 			logx.Errorf("COMP_SCAN groups target error: %v", err)
+			span.SetError(err)
 			return compScanMsg{err: err}
 		}
 
@@ -53,14 +59,17 @@ func (m Model) scanComponentsCmd() tea.Cmd {
 		srcComps, err := m.api.ListComponents(ctx, srcID)
 		if err != nil {
 			logx.Errorf("COMP_SCAN components source error: %v", err)
+			span.SetError(err)
 			return compScanMsg{err: err}
 		}
 		tgtComps, err := m.api.ListComponents(ctx, tgtID)
 		if err != nil {
 			logx.Errorf("COMP_SCAN components target error: %v", err)
+			span.SetError(err)
 			return compScanMsg{err: err}
 		}
 		logx.Infof("COMP_SCAN done src comps=%d tgt comps=%d", len(srcComps), len(tgtComps))
+		span.SetAttrs(tracex.Int("source_components", len(srcComps)), tracex.Int("target_components", len(tgtComps)))
 		return compScanMsg{srcComps: srcComps, tgtComps: tgtComps, srcGroups: srcGroups, tgtGroups: tgtGroups}
 	}
 }
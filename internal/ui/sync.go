@@ -10,6 +10,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/infra/tracex"
 	"storyblok-sync/internal/sb"
 )
 
@@ -55,10 +56,29 @@ type syncResultMsg = sync.SyncResultMsg
 
 // optimizePreflight deduplicates entries, pre-plans missing folders, and sorts by sync order (folders first).
 func (m *Model) optimizePreflight() {
+	_, span := tracex.Start(context.Background(), "preflight.optimize", tracex.Int("items", len(m.preflight.items)))
+	defer span.End()
 	planner := sync.NewPreflightPlanner(m.storiesSource, m.storiesTarget)
 	m.preflight.items = planner.OptimizePreflight(m.preflight.items)
 }
 
+// endSyncSpan closes the root span of a finished run with its summary.
+func (m *Model) endSyncSpan(cancelled int) {
+	if m.syncSpan == nil {
+		return
+	}
+	m.syncSpan.SetAttrs(
+		tracex.Int("success", m.report.Summary.Success),
+		tracex.Int("warning", m.report.Summary.Warning),
+		tracex.Int("failure", m.report.Summary.Failure),
+		tracex.Int("cancelled", cancelled))
+	if m.report.Summary.Failure > 0 {
+		m.syncSpan.SetError(fmt.Errorf("%d items failed", m.report.Summary.Failure))
+	}
+	m.syncSpan.End()
+	m.syncSpan = nil
+}
+
 func (m *Model) runNextItem() tea.Cmd {
 	// Find next pending item, preferring current syncIndex, then scanning forward, then wrap-around
 	if len(m.preflight.items) == 0 {
@@ -68,14 +88,44 @@ func (m *Model) runNextItem() tea.Cmd {
 	if m.paused {
 		return nil
 	}
-	// Phase barrier: if any folder is not yet done (pending or running),
-	// do not start stories. Folders must complete fully before stories run.
-	hasActiveFolders := false
+	// Phase barrier: if any folder of a target is not yet done (pending or
+	// running), do not start that target's stories. Folders must complete
+	// fully before stories run. Without fan-out all items share target 0.
+	activeFolders := make(map[int]bool)
+	runningUnits := make(map[int]int)
 	for i := range m.preflight.items {
-		if m.preflight.items[i].Story.IsFolder && m.preflight.items[i].Run != RunDone {
-			hasActiveFolders = true
-			break
+		it := m.preflight.items[i]
+		if it.Story.IsFolder && it.Run != RunDone {
+			activeFolders[it.TargetSpaceID] = true
+		}
+		// Budgeted scheduling: limit concurrent write units per target to maxWorkers
+		if it.Run == RunRunning {
+			runningUnits[it.TargetSpaceID] += m.expectedWriteUnits(it)
+		}
+	}
+	allowed := m.maxWorkers
+	if allowed <= 0 {
+		allowed = 1
+	}
+	// A target whose next item exceeds its budget waits; others may proceed.
+	blocked := make(map[int]bool)
+	eligible := func(i int) bool {
+		it := m.preflight.items[i]
+		if it.Run != RunPending || blocked[it.TargetSpaceID] {
+			return false
+		}
+		if activeFolders[it.TargetSpaceID] && !it.Story.IsFolder {
+			return false // defer stories until all folders are handled
+		}
+		budget := allowed
+		if m.fanOut.expanded && activeFolders[it.TargetSpaceID] {
+			budget = 1 // folder phase of this target runs sequentially
 		}
+		if runningUnits[it.TargetSpaceID]+m.expectedWriteUnits(it) > budget {
+			blocked[it.TargetSpaceID] = true
+			return false
+		}
+		return true
 	}
 	idx := -1
 	// First pass: from current index to end
@@ -84,44 +134,23 @@ func (m *Model) runNextItem() tea.Cmd {
 		start = 0
 	}
 	for i := start; i < len(m.preflight.items); i++ {
-		if m.preflight.items[i].Run == RunPending {
-			if hasActiveFolders && !m.preflight.items[i].Story.IsFolder {
-				continue // defer stories until all folders are handled
-			}
+		if eligible(i) {
 			idx = i
 			break
 		}
 	}
 	// Second pass: from 0 to current index
 	if idx == -1 {
-		for i := 0; i < start; i++ {
-			if m.preflight.items[i].Run == RunPending {
-				if hasActiveFolders && !m.preflight.items[i].Story.IsFolder {
-					continue // still in folder phase
-				}
+		for i := 0; i < start && i < len(m.preflight.items); i++ {
+			if eligible(i) {
 				idx = i
 				break
 			}
 		}
 	}
 	if idx == -1 {
-		// Either no pending items or only stories pending while folders still running.
-		// In both cases, do not schedule anything new.
-		return nil
-	}
-	// Budgeted scheduling: limit concurrent write units to maxWorkers
-	runningUnits := 0
-	for i := range m.preflight.items {
-		if m.preflight.items[i].Run == RunRunning {
-			runningUnits += m.expectedWriteUnits(m.preflight.items[i])
-		}
-	}
-	candUnits := m.expectedWriteUnits(m.preflight.items[idx])
-	allowed := m.maxWorkers
-	if allowed <= 0 {
-		allowed = 1
-	}
-	if runningUnits+candUnits > allowed {
+		// Either no pending items, only stories pending while folders still
+		// running, or every target's budget is used up. Do not schedule anything new.
 		return nil
 	}
 	m.syncIndex = idx
@@ -141,7 +170,7 @@ func (m *Model) runNextItem() tea.Cmd {
 	if !it.Story.IsFolder {
 		mode := m.getPublishMode(it.Story.FullSlug)
 		exists, tgtPublished := false, false
-		for _, t := range m.storiesTarget {
+		for _, t := range m.targetStoriesFor(it) {
 			if t.FullSlug == it.Story.FullSlug {
 				exists = true
 				tgtPublished = t.Published
@@ -162,12 +191,20 @@ func (m *Model) runNextItem() tea.Cmd {
 			if m.unpublishAfter == nil {
 				m.unpublishAfter = make(map[string]bool)
 			}
-			m.unpublishAfter[it.Story.FullSlug] = true
+			m.unpublishAfter[m.runKey(it)] = true
 			log.Printf("UNPUBLISH_MARK: slug=%s op=update will schedule unpublish after overwrite (mode=draft, srcPub=true, tgtPub=true)", it.Story.FullSlug)
 		}
 		log.Printf("PUBLISH_OVERRIDE: slug=%s mode=%s exists=%t tgtPublished=%t publishFlag=%t", it.Story.FullSlug, mode, exists, tgtPublished, publishFlag)
 		item.overridePublish = true
 		item.publishFlag = publishFlag
+		if mode == PublishModeSchedule {
+			item.publishAt = m.publishAt[it.Story.FullSlug]
+			if err := validateScheduledItem(item.publishAt); err != nil {
+				return func() tea.Msg {
+					return syncResultMsg{Index: idx, Err: err}
+				}
+			}
+		}
 	}
 
 	return func() tea.Msg {
@@ -178,11 +215,40 @@ func (m *Model) runNextItem() tea.Cmd {
 
 		// Rebuild report adapter and target index at execution time.
 		reportAdapter := &reportAdapter{report: &m.report}
-		tgtIndex := make(map[string]sb.Story, len(m.storiesTarget))
-		for _, s := range m.storiesTarget {
+		targetStories := m.targetStoriesFor(it)
+		tgtIndex := make(map[string]sb.Story, len(targetStories))
+		for _, s := range targetStories {
 			tgtIndex[s.FullSlug] = s
 		}
-		orchestrator := sync.NewSyncOrchestrator(m.api, reportAdapter, m.sourceSpace, m.targetSpace, tgtIndex)
+		primary := m.isPrimaryTarget(it)
+		orchestrator := sync.NewSyncOrchestrator(m.api, reportAdapter, m.sourceSpace, m.itemTarget(it), tgtIndex)
+		if m.fanOut.limiter != nil {
+			orchestrator.SetSpaceLimiter(m.fanOut.limiter)
+		}
+		if !primary {
+			// Fan-out runs start without release and workflow checks
+			if r := m.fanOut.tags[it.TargetSpaceID]; r != nil {
+				orchestrator.SetTagReconciler(r)
+			}
+		} else {
+			if m.tagReconciler != nil {
+				orchestrator.SetTagReconciler(m.tagReconciler)
+			}
+			if m.workflowGuard != nil {
+				orchestrator.SetWorkflowGuard(m.workflowGuard)
+			}
+			if m.release != nil {
+				orchestrator.SetReleaseID(m.release.ID)
+			}
+		}
+		if len(m.syncLanguages) > 0 {
+			orchestrator.SetLanguages(m.syncLanguages)
+		}
+		orchestrator.SetMergePolicy(m.mergePolicy, m.fieldRules)
+		orchestrator.SetTransforms(m.transforms)
+		if m.baseStore != nil {
+			orchestrator.SetBaseStore(m.baseStore)
+		}
 		// Delegate to orchestrator command
 		cmd := orchestrator.RunSyncItem(m.syncContext, idx, item)
 		return cmd()
@@ -194,16 +260,36 @@ type preflightItemAdapter struct {
 	item            PreflightItem
 	overridePublish bool
 	publishFlag     bool
+	publishAt       string // UTC publish_at for schedule mode
 }
 
 func (pia *preflightItemAdapter) GetStory() sb.Story {
 	st := pia.item.Story
 	if pia.overridePublish && !st.IsFolder {
 		st.Published = pia.publishFlag
+		st.PublishAt = pia.publishAt
 	}
 	return st
 }
 
+// MergeResolutions exposes the resolver choices to the orchestrator.
+func (pia *preflightItemAdapter) MergeResolutions() map[string]string {
+	return pia.item.Resolutions
+}
+
+// validateScheduledItem re-checks a schedule right before the write; time may
+// have passed since the preflight.
+func validateScheduledItem(publishAt string) error {
+	if publishAt == "" {
+		return fmt.Errorf("publish_at fehlt für Modus %s", PublishModeSchedule)
+	}
+	t, err := sync.ParsePublishAt(publishAt)
+	if err != nil {
+		return err
+	}
+	return sync.ValidateSchedule(t, time.Now())
+}
+
 func (pia *preflightItemAdapter) IsFolder() bool {
 	return pia.item.Story.IsFolder
 }
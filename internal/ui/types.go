@@ -4,6 +4,9 @@ import (
 	"context"
 	"storyblok-sync/internal/config"
 	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/core/tagsync"
+	"storyblok-sync/internal/core/transform"
+	"storyblok-sync/internal/infra/tracex"
 	"storyblok-sync/internal/sb"
 	"time"
 
@@ -19,6 +22,7 @@ const (
 	stateWelcome state = iota
 	stateTokenPrompt
 	stateValidating
+	stateResumePrompt
 	stateSpaceSelect
 	stateModePicker
 	stateScanning
@@ -29,6 +33,11 @@ const (
 	statePreflight
 	stateCopyAsNew
 	stateFolderFork
+	stateReleasePicker
+	stateLanguagePicker
+	stateReportPicker
+	stateMergeDiff
+	stateConflictResolver
 	stateSync
 	stateReport
 	stateQuit
@@ -40,6 +49,8 @@ type syncMode int
 const (
 	modeStories syncMode = iota
 	modeComponents
+	// modeCombined syncs the components used by the selected stories first, then the stories
+	modeCombined
 )
 
 type SelectionState struct {
@@ -124,9 +135,14 @@ type Model struct {
 	syncIndex   int
 	syncCancel  context.CancelFunc // for cancelling sync operations
 	syncContext context.Context    // cancellable context for sync
+	syncSpan    *tracex.Span       // root span of the running sync (nil when tracing is off)
 	paused      bool               // pause flag to stop scheduling new work
 	api         *sb.Client
 	report      Report
+	// target tag reconciler shared by all items of a sync run
+	tagReconciler *tagsync.Reconciler
+	// failure threshold webhook already sent for this run
+	failureNotified bool
 	// Per-item metrics snapshots to compute rate-limit retry deltas
 	syncStartMetrics map[int]sb.MetricsSnapshot
 
@@ -158,6 +174,10 @@ type Model struct {
 	compMaps    compRemapMaps
 	// components rate limiting (per-space)
 	compLimiter *sync.SpaceLimiter
+	// combined mode: components derived from the selected stories are being resolved
+	combinedResolving bool
+	// combinedGen numbers resolve runs; results of superseded runs are dropped
+	combinedGen int
 
 	// scan results
 	storiesSource []sb.Story
@@ -248,6 +268,73 @@ type Model struct {
 	publishMode map[string]string // key: FullSlug
 	// Items that should be unpublished after overwrite (special case)
 	unpublishAfter map[string]bool // key: FullSlug
+	// Scheduled publish time (UTC publish_at) per slug for PublishModeSchedule
+	publishAt map[string]string
+	schedule  ScheduleInputState
+	// Target space timezone used to interpret schedule input (nil until loaded)
+	targetLoc *time.Location
+
+	// --- Schema validation (preflight) ---
+	// Policy for stories whose content does not match the target schemas: "warn" or "block"
+	schemaPolicy     string
+	schemaValidating bool
+
+	// --- Workflow stages (preflight) ---
+	// Stages and current stage per target slug from the last workflow check (nil: not checked)
+	workflowStages       []sb.WorkflowStage
+	workflowCurrent      map[string]int
+	workflowChecking     bool
+	workflowLockedStages []string
+	// Opt-in: move locked stories to an editable stage for the update and back afterwards
+	workflowTransition bool
+	workflowGuard      *sync.WorkflowGuard
+
+	// --- Releases ---
+	// Target release all story writes are staged in (nil: live content)
+	release     *sb.Release
+	releasePick ReleasePickerState
+
+	// --- Language scope ---
+	// Languages whose translations are synced into existing target stories (nil: full sync)
+	syncLanguages []string
+	langPick      LanguagePickerState
+
+	// --- Content merge ---
+	// How source content is merged into existing target stories, plus per-component field rules
+	mergePolicy string
+	fieldRules  sync.FieldRules
+	mergeDiff   MergeDiffState
+	// Rules rewriting story payloads before each write; the field diff shows their result
+	transforms *transform.Pipeline
+
+	// --- Three-way merge ---
+	// Bases recorded after each write; conflicts are checked and resolved in preflight
+	baseStore        *sync.BaseStore
+	conflictChecking bool
+	conflictView     ConflictResolverState
+
+	// --- Multi-target fan-out ---
+	// Additional target spaces synced in the same run
+	fanOut FanOutState
+
+	// --- Sync journal ---
+	// Append-only journal of the running sync; an unfinished one found at
+	// startup is offered for resume after token validation
+	journal         *sync.Journal
+	journalPath     string
+	pendingJournal  *sync.JournalState
+	resumingJournal bool
+
+	// --- Report export ---
+	// Format written by "e" on the report screen; with reportAutoExport it is
+	// also written whenever a run finishes (--report-format)
+	reportFormat     string
+	reportAutoExport bool
+
+	// --- Retry from saved report ---
+	reportPick      ReportPickerState
+	retryReport     *Report
+	retryReportPath string
 
 	// --- EMA estimates for rate-limit budgeting ---
 	emaWritePerItem float64
@@ -11,6 +11,8 @@ import (
 
 	"storyblok-sync/internal/config"
 	"storyblok-sync/internal/infra/logx"
+	"storyblok-sync/internal/infra/notify"
+	"storyblok-sync/internal/report"
 	"storyblok-sync/internal/sb"
 )
 
@@ -35,6 +37,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.state == stateFolderFork {
 			return m.handleFolderForkKey(msg)
 		}
+		if m.state == stateReleasePicker {
+			return m.handleReleasePickerKey(msg)
+		}
+		if m.state == stateLanguagePicker {
+			return m.handleLanguagePickerKey(msg)
+		}
+		if m.state == stateReportPicker {
+			return m.handleReportPickerKey(key)
+		}
+		if m.state == stateMergeDiff {
+			return m.handleMergeDiffKey(msg)
+		}
+		if m.state == stateConflictResolver {
+			return m.handleConflictResolverKey(msg)
+		}
 		if m.state == stateCompList {
 			return m.handleCompListKey(msg)
 		}
@@ -51,6 +68,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.statusMsg = "Sync cancelled by user (Ctrl+C) – press 'r' to resume or 'q' to quit"
 				return m, nil
 			}
+			// Stop in-flight component work (rename propagation) before quitting
+			if m.state == stateCompSync && m.syncCancel != nil {
+				m.syncCancel()
+			}
 			// If not syncing, quit the application
 			return m, tea.Quit
 		}
@@ -65,6 +86,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleTokenPromptKey(msg)
 		case stateValidating:
 			return m.handleValidatingKey(key)
+		case stateResumePrompt:
+			return m.handleResumePromptKey(key)
 		case stateSpaceSelect:
 			return m.handleSpaceSelectKey(key)
 		case stateScanning:
@@ -123,32 +146,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.statusMsg = fmt.Sprintf("Token gespeichert. %d Spaces gefunden.", len(m.spaces))
 		}
-		// check if we have spaces configured and validate if their ids are in m.spaces
-		if m.cfg.SourceSpace != "" && m.cfg.TargetSpace != "" {
-			sourceSpace, sourceIdIsOk := containsSpaceID(m.spaces, m.cfg.SourceSpace)
-			targetSpace, targetIdIsOk := containsSpaceID(m.spaces, m.cfg.TargetSpace)
-
-			if sourceIdIsOk && targetIdIsOk {
-				m.sourceSpace = &sourceSpace
-				m.targetSpace = &targetSpace
-				m.statusMsg = fmt.Sprintf("Target gesetzt: %s (%d). Scanne jetzt Stories…", sourceSpace.Name, sourceSpace.ID)
-				m.state = stateScanning
-				return m, tea.Batch(m.spinner.Tick, m.scanStoriesCmd())
-			}
+		// an interrupted sync takes precedence over the configured spaces
+		if m.pendingJournal != nil {
+			m.state = stateResumePrompt
+			return m, nil
 		}
-		m.state = stateSpaceSelect
-		m.selectingSource = true
-		m.selectedIndex = 0
-		return m, nil
+		return m.routeAfterValidation()
 
 	case scanMsg:
 		if msg.err != nil {
+			m.resumingJournal = false
+			m.retryReport = nil
 			m.statusMsg = "Scan-Fehler: " + msg.err.Error()
 			m.state = stateSpaceSelect // zurück; du kannst auch einen Fehler-Screen bauen
 			return m, nil
 		}
 		m.storiesSource = msg.src
 		m.storiesTarget = msg.tgt
+		m.fanOut.stories = msg.extra
 		m.selection.listIndex = 0
 		m.rebuildStoryIndex()
 		m.applyFilter()
@@ -158,11 +173,63 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// optional: Selektion leeren, da sich die Liste geändert hat
 			clear(m.selection.selected)
 		}
+		if m.resumingJournal {
+			return m.resumeFromJournal()
+		}
+		if m.retryReport != nil {
+			return m.retryFromReport()
+		}
 		m.statusMsg = fmt.Sprintf("Scan ok. Source: %d Stories, Target: %d Stories.", len(m.storiesSource), len(m.storiesTarget))
+		if m.fanOutActive() {
+			m.statusMsg += fmt.Sprintf(" %d weitere Ziele.", len(m.fanOut.targets))
+		}
 		m.state = stateBrowseList
 		m.updateViewportContent()
 		return m, nil
 
+	case combinedScanMsg:
+		return m.handleCombinedScanResult(msg)
+
+	case combinedComponentsMsg:
+		m.applyCombinedComponents(msg)
+		m.updateViewportContent()
+		return m, nil
+
+	case schemaValidateMsg:
+		m.applySchemaValidation(msg)
+		m.updateViewportContent()
+		return m, nil
+
+	case targetTimezoneMsg:
+		m.applyTargetTimezone(msg)
+		return m, nil
+
+	case releasesMsg:
+		m.applyReleases(msg)
+		return m, nil
+
+	case releaseCreatedMsg:
+		m.applyCreatedRelease(msg)
+		return m, nil
+
+	case languagesMsg:
+		m.applyLanguages(msg)
+		return m, nil
+
+	case mergeDiffMsg:
+		m.applyMergeDiff(msg)
+		return m, nil
+
+	case conflictCheckMsg:
+		m.applyConflictCheck(msg)
+		m.updateViewportContent()
+		return m, nil
+
+	case workflowCheckMsg:
+		m.applyWorkflowCheck(msg)
+		m.updateViewportContent()
+		return m, nil
+
 	case compScanMsg:
 		m, _ = m.handleCompScanResult(msg)
 		if msg.err != nil {
@@ -189,16 +256,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.targetSpace != nil {
 			tgtName = m.targetSpace.Name
 		}
-		rep := NewReport(srcName, tgtName)
+		rep := report.New(srcName, tgtName)
 		for _, e := range msg.entries {
-			status := "success"
-			if e.Err != "" {
-				status = "failure"
-			}
-			rep.Add(ReportEntry{Slug: e.Name, Status: status, Operation: e.Operation, Duration: e.DurationMs, Error: e.Err, RateLimit429: e.Retry429})
+			rep.Add(e.reportEntry())
 		}
-		rep.Finalize()
+		_ = rep.Save()
 		m.report = *rep
+		m.notifyRun(notify.EventCompleted)
 		m.state = stateReport
 		m.updateViewportContent()
 		return m, nil
@@ -207,6 +271,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			m.statusMsg = "Component-Apply-Fehler (Init): " + msg.err.Error()
 			// Stay on preflight so user can adjust
+			if m.currentMode == modeCombined {
+				m.syncing = false
+				m.state = statePreflight
+			}
 			m.updateViewportContent()
 			return m, nil
 		}
@@ -214,6 +282,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.api == nil {
 			m.api = sb.New(m.cfg.Token)
 		}
+		// Shared write limiter for the item workers and a context that cancels rename propagation
+		if m.compLimiter == nil {
+			m.compLimiter = m.newCompLimiter()
+		}
+		m.syncContext, m.syncCancel = context.WithCancel(context.Background())
 		// Install maps and plan into model
 		m.compMaps = msg.maps
 		m.compResults = nil
@@ -241,7 +314,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.targetSpace != nil {
 			tgtName = m.targetSpace.Name
 		}
-		m.report = *NewReport(srcName, tgtName)
+		m.report = *report.New(srcName, tgtName)
+		if m.sourceSpace != nil {
+			m.report.SourceSpaceID = m.sourceSpace.ID
+		}
+		if m.targetSpace != nil {
+			m.report.TargetSpaceID = m.targetSpace.ID
+		}
+		m.failureNotified = false
+		m.notifyRun(notify.EventStarted)
 		// Switch to components sync view with spinner + stats
 		m.state = stateCompSync
 		m.syncing = true
@@ -269,6 +350,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(append(cmds, m.spinner.Tick, m.statsTick())...)
 
 	case compItemDoneMsg:
+		// A renamed component stays running until its stories are rewritten
+		if msg.renameFrom != "" && msg.entry.Err == "" {
+			return m, m.propagateCompRenameCmd(msg)
+		}
 		// Update per-item run state and schedule next pending
 		idx := msg.idx
 		if idx >= 0 && idx < len(m.compPre.items) {
@@ -278,7 +363,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.compPre.items[idx].Run = RunCancelled
 			}
 		}
+		if idx >= 0 && idx < len(m.compPre.items) {
+			msg.entry.SourceID = m.compPre.items[idx].Source.ID
+			msg.entry.TargetIDBefore = m.compPre.items[idx].TargetID
+		}
 		m.compResults = append(m.compResults, msg.entry)
+		if msg.entry.Err != "" {
+			m.notifyFailures(m.compFailures())
+		}
 		// Log rate-limit warnings for this item if any
 		if msg.entry.Retry429 > 0 {
 			logx.Warnf("COMP_RATE_LIMIT item=%s op=%s retry429=%d retries=%d", msg.entry.Name, msg.entry.Operation, msg.entry.Retry429, msg.entry.RetryTotal)
@@ -321,13 +413,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if running == 0 {
 			// Finalize report with accumulated results; StartTime was set at init
 			for _, e := range m.compResults {
-				status := "success"
-				if e.Err != "" {
-					status = "failure"
-				}
-				m.report.Add(ReportEntry{Slug: e.Name, Status: status, Operation: e.Operation, Duration: e.DurationMs, Error: e.Err, RateLimit429: e.Retry429})
+				m.report.Add(e.reportEntry())
 			}
-			m.report.Finalize()
+			if m.currentMode == modeCombined {
+				// Components are in place; continue with the stories in the same report
+				return m.beginStorySync(false)
+			}
+			_ = m.report.Save()
+			m.autoExportReport()
+			m.notifyRun(notify.EventCompleted)
 			m.state = stateReport
 			m.updateViewportContent()
 			return m, nil
@@ -517,9 +611,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if !it.Story.IsFolder {
 					pub = m.getPublishMode(it.Story.FullSlug)
 				}
-				m.report.Add(ReportEntry{Slug: it.Story.FullSlug, Status: "failure", Operation: "cancelled", Error: "Sync cancelled by user", Duration: 0, Story: &it.Story, RateLimit429: rate429Delta, PublishMode: pub})
+				m.report.Add(m.withStoryIDs(ReportEntry{Slug: it.Story.FullSlug, Status: "failure", Operation: "cancelled", Error: "Sync cancelled by user", Duration: 0, Story: &it.Story, RateLimit429: rate429Delta, PublishMode: pub, PublishAt: m.scheduledAt(it.Story.FullSlug), TargetSpaceID: m.reportTargetID(it)}, it, nil))
 				// Set inline issue for cancelled item
 				m.preflight.items[msg.Index].Issue = "Sync cancelled by user"
+				m.journalResult(it, "Sync cancelled by user")
 
 				// Do NOT cancel remaining items; leave them pending to allow resume
 				m.syncing = false
@@ -540,7 +635,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if !it.Story.IsFolder {
 					pub = m.getPublishMode(it.Story.FullSlug)
 				}
-				m.report.Add(ReportEntry{Slug: it.Story.FullSlug, Status: "failure", Operation: "sync", Error: msg.Err.Error(), Duration: msg.Duration, Story: &it.Story, RateLimit429: rate429Delta, PublishMode: pub})
+				entry := ReportEntry{Slug: it.Story.FullSlug, Status: "failure", Operation: "sync", Error: msg.Err.Error(), Duration: msg.Duration, Story: &it.Story, RateLimit429: rate429Delta, PublishMode: pub, PublishAt: m.scheduledAt(it.Story.FullSlug), TargetSpaceID: m.reportTargetID(it)}
+				if msg.Result != nil {
+					entry.WorkflowSteps = msg.Result.WorkflowSteps
+					entry.MergeConflicts = msg.Result.MergeConflicts
+				}
+				m.report.Add(m.withStoryIDs(entry, it, msg.Result))
+				m.notifyFailures(0)
 				// Set inline issue message
 				m.preflight.items[msg.Index].Issue = msg.Err.Error()
 			} else if msg.Result != nil {
@@ -551,7 +652,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if !it.Story.IsFolder {
 						pub = m.getPublishMode(it.Story.FullSlug)
 					}
-					m.report.Add(ReportEntry{Slug: it.Story.FullSlug, Status: "warning", Operation: msg.Result.Operation, Warning: msg.Result.Warning, Duration: msg.Duration, Story: &it.Story, TargetStory: msg.Result.TargetStory, RateLimit429: rate429Delta, PublishMode: pub})
+					m.report.Add(m.withStoryIDs(ReportEntry{Slug: it.Story.FullSlug, Status: "warning", Operation: msg.Result.Operation, Warning: msg.Result.Warning, Duration: msg.Duration, Story: &it.Story, TargetStory: msg.Result.TargetStory, RateLimit429: rate429Delta, PublishMode: pub, PublishAt: m.scheduledAt(it.Story.FullSlug), CreatedTags: msg.Result.CreatedTags, WorkflowSteps: msg.Result.WorkflowSteps, MergeConflicts: msg.Result.MergeConflicts, TargetSpaceID: m.reportTargetID(it)}, it, msg.Result))
 					// Set inline issue message
 					m.preflight.items[msg.Index].Issue = msg.Result.Warning
 				} else {
@@ -560,32 +661,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if !it.Story.IsFolder {
 						pub = m.getPublishMode(it.Story.FullSlug)
 					}
-					m.report.Add(ReportEntry{Slug: it.Story.FullSlug, Status: "success", Operation: msg.Result.Operation, Duration: msg.Duration, TargetStory: msg.Result.TargetStory, RateLimit429: rate429Delta, PublishMode: pub})
-					// Keep target index fresh: if a folder was created/updated, update m.storiesTarget
+					m.report.Add(m.withStoryIDs(ReportEntry{Slug: it.Story.FullSlug, Status: "success", Operation: msg.Result.Operation, Duration: msg.Duration, TargetStory: msg.Result.TargetStory, RateLimit429: rate429Delta, PublishMode: pub, PublishAt: m.scheduledAt(it.Story.FullSlug), CreatedTags: msg.Result.CreatedTags, WorkflowSteps: msg.Result.WorkflowSteps, MergeConflicts: msg.Result.MergeConflicts, TargetSpaceID: m.reportTargetID(it)}, it, msg.Result))
+					// Keep target index fresh: if a folder was created/updated, update the item's target stories
 					if msg.Result.TargetStory != nil && msg.Result.TargetStory.IsFolder {
-						updated := false
-						for i := range m.storiesTarget {
-							if m.storiesTarget[i].FullSlug == msg.Result.TargetStory.FullSlug {
-								m.storiesTarget[i] = *msg.Result.TargetStory
-								updated = true
-								break
-							}
-						}
-						if !updated {
-							m.storiesTarget = append(m.storiesTarget, *msg.Result.TargetStory)
-						}
+						m.upsertTargetStory(it, *msg.Result.TargetStory)
 					}
 					// If we need to unpublish after overwrite, trigger async unpublish
 					if msg.Result != nil && msg.Result.TargetStory != nil && msg.Result.Operation == "update" {
 						slug := it.Story.FullSlug
+						key := m.runKey(it)
 						needUnpublish := false
-						if m.unpublishAfter != nil && m.unpublishAfter[slug] {
+						if m.unpublishAfter != nil && m.unpublishAfter[key] {
 							needUnpublish = true
 						} else {
 							// Fallback inference (tests may bypass runNextItem): if mode=draft, source published and target published, and operation was update
 							if !it.Story.IsFolder && m.getPublishMode(slug) == PublishModeDraft && it.Story.Published {
 								// check target published in current index
-								for _, t := range m.storiesTarget {
+								for _, t := range m.targetStoriesFor(it) {
 									if t.FullSlug == slug && t.Published {
 										needUnpublish = true
 										break
@@ -598,14 +690,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							// prepare unpublish command to be returned alongside scheduling
 							follow = m.unpublishCmd(msg.Index, msg.Result.TargetStory.ID)
 							// clear flag to avoid duplicates
-							delete(m.unpublishAfter, slug)
+							delete(m.unpublishAfter, key)
 						}
 					}
 				}
 			} else {
 				// Fallback for unexpected case
-				m.report.Add(ReportEntry{Slug: it.Story.FullSlug, Status: "success", Operation: "unknown", Duration: msg.Duration, RateLimit429: rate429Delta})
+				m.report.Add(m.withStoryIDs(ReportEntry{Slug: it.Story.FullSlug, Status: "success", Operation: "unknown", Duration: msg.Duration, RateLimit429: rate429Delta, TargetSpaceID: m.reportTargetID(it)}, it, msg.Result))
+			}
+			errMsg := ""
+			if msg.Err != nil {
+				errMsg = msg.Err.Error()
 			}
+			m.journalResult(it, errMsg)
 		}
 
 		// Track successes for success/sec metric (count both pure success and success-with-warning)
@@ -672,13 +769,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Maintain a worker pool. During folder phase, allow only 1; afterwards, allow up to 6.
+		// Fan-out runs keep up to 6 per target; runNextItem limits each target's folder phase.
 		allowed := 6
-		if hasPendingFolders {
+		if hasPendingFolders && !m.fanOut.expanded {
 			allowed = 1
 		}
 		m.maxWorkers = allowed
 		if pending > 0 && !m.paused {
-			toStart := allowed - running
+			toStart := allowed*m.syncTargets() - running
 			if toStart > pending {
 				toStart = pending
 			}
@@ -723,8 +821,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusMsg = fmt.Sprintf("Sync cancelled - %d completed, %d cancelled", done, cancelled)
 		} else {
 			m.statusMsg = m.report.GetDisplaySummary()
+			// cancelled items stay resumable; a complete run closes the journal
+			m.finishJournal()
 		}
 		_ = m.report.Save()
+		m.autoExportReport()
+		m.endSyncSpan(cancelled)
+		if cancelled == 0 {
+			m.notifyRun(notify.EventCompleted)
+		}
 
 		// Update viewport content for report view
 		m.updateViewportContent()
@@ -749,6 +854,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// routeAfterValidation starts a preloaded report retry or scans the configured
+// spaces when both are still available; otherwise it continues with the space
+// selection.
+func (m Model) routeAfterValidation() (Model, tea.Cmd) {
+	if m.retryReport != nil {
+		return m.startReportRetry()
+	}
+	if m.cfg.SourceSpace != "" && m.cfg.TargetSpace != "" {
+		sourceSpace, sourceIdIsOk := containsSpaceID(m.spaces, m.cfg.SourceSpace)
+		targetSpace, targetIdIsOk := containsSpaceID(m.spaces, m.cfg.TargetSpace)
+
+		if sourceIdIsOk && targetIdIsOk {
+			m.sourceSpace = &sourceSpace
+			m.targetSpace = &targetSpace
+			m.statusMsg = fmt.Sprintf("Target gesetzt: %s (%d). Scanne jetzt Stories…", sourceSpace.Name, sourceSpace.ID)
+			m.state = stateScanning
+			return m, tea.Batch(m.spinner.Tick, m.scanStoriesCmd())
+		}
+	}
+	m.state = stateSpaceSelect
+	m.selectingSource = true
+	m.selectedIndex = 0
+	return m, nil
+}
+
 // countLines returns the number of visual lines in a string by counting newlines.
 // Returns 0 for empty strings.
 // (no dynamic header/footer counting; use fixed base + known extras for stability)
@@ -772,11 +902,15 @@ func (m *Model) unpublishCmd(index, storyID int) tea.Cmd {
 		if m.api == nil || m.targetSpace == nil {
 			return unpublishDoneMsg{Index: index, Err: fmt.Errorf("api not initialized"), Duration: 0}
 		}
+		spaceID := m.targetSpace.ID
+		if index >= 0 && index < len(m.preflight.items) {
+			spaceID = m.itemTarget(m.preflight.items[index]).ID
+		}
 		start := time.Now()
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
-		log.Printf("UNPUBLISH_START: space=%d storyID=%d", m.targetSpace.ID, storyID)
-		err := m.api.UnpublishStory(ctx, m.targetSpace.ID, storyID)
+		log.Printf("UNPUBLISH_START: space=%d storyID=%d", spaceID, storyID)
+		err := m.api.UnpublishStory(ctx, spaceID, storyID)
 		d := time.Since(start).Milliseconds()
 		if err != nil {
 			log.Printf("UNPUBLISH_DONE: storyID=%d err=%v durationMs=%d", storyID, err, d)
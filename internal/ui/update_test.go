@@ -213,6 +213,7 @@ func TestMarkMovesCursorDown(t *testing.T) {
 }
 
 func TestSyncCompletionWaitsUntilNoRunning(t *testing.T) {
+	t.Chdir(t.TempDir())
 	// Model in sync state with two running items and no pending
 	m := InitialModel()
 	m.state = stateSync
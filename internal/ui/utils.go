@@ -3,7 +3,9 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	sync "storyblok-sync/internal/core/sync"
 	"storyblok-sync/internal/sb"
 )
 
@@ -95,6 +97,8 @@ const (
 	PublishModeDraft          = "draft"
 	PublishModePublish        = "publish"
 	PublishModePublishChanges = "publish_changes"
+	// PublishModeSchedule writes a draft with publish_at set (see m.publishAt)
+	PublishModeSchedule = "schedule"
 )
 
 // getPublishMode returns the publish mode for a slug, defaulting to draft.
@@ -113,7 +117,7 @@ func (m *Model) setPublishMode(slug, mode string) {
 		m.publishMode = make(map[string]string)
 	}
 	switch mode {
-	case PublishModeDraft, PublishModePublish, PublishModePublishChanges:
+	case PublishModeDraft, PublishModePublish, PublishModePublishChanges, PublishModeSchedule:
 		m.publishMode[slug] = mode
 	default:
 		m.publishMode[slug] = PublishModeDraft
@@ -139,11 +143,13 @@ func (m *Model) cyclePublishModeForSlug(slug string) {
 			return PublishModePublish
 		case PublishModePublish:
 			return PublishModePublishChanges
+		case PublishModePublishChanges:
+			return PublishModeSchedule
 		default:
 			return PublishModeDraft
 		}
 	}
-	for i := 0; i < 3; i++ {
+	for i := 0; i < 4; i++ {
 		cand := next(curr)
 		if m.isPublishModeValid(*it, cand) {
 			m.setPublishMode(slug, cand)
@@ -160,7 +166,7 @@ func (m *Model) isPublishModeValid(it PreflightItem, mode string) bool {
 		return false
 	}
 	exists, tgtPublished := false, false
-	for _, t := range m.storiesTarget {
+	for _, t := range m.targetStoriesFor(it) {
 		if t.FullSlug == it.Story.FullSlug {
 			exists = true
 			tgtPublished = t.Published
@@ -172,6 +178,9 @@ func (m *Model) isPublishModeValid(it PreflightItem, mode string) bool {
 		return exists && tgtPublished
 	case PublishModePublish, PublishModeDraft:
 		return true
+	case PublishModeSchedule:
+		// only once a time was entered or carried over from the source
+		return m.publishAt[it.Story.FullSlug] != ""
 	default:
 		return false
 	}
@@ -182,11 +191,21 @@ func (m *Model) initDefaultPublishModes() {
 	if m.publishMode == nil {
 		m.publishMode = make(map[string]string)
 	}
+	if m.publishAt == nil {
+		m.publishAt = make(map[string]string)
+	}
+	now := time.Now()
 	// Default policy: mirror source state (overwrite when both published)
 	for _, it := range m.preflight.items {
 		if it.Story.IsFolder || !it.Selected || it.Skip {
 			continue
 		}
+		// Carry over pending source schedules
+		if at, ok := sync.FutureSourceSchedule(it.Story.PublishAt, now); ok {
+			m.publishAt[it.Story.FullSlug] = at
+			m.publishMode[it.Story.FullSlug] = PublishModeSchedule
+			continue
+		}
 		if it.Story.Published && m.shouldPublish() {
 			m.publishMode[it.Story.FullSlug] = PublishModePublish
 		} else {
@@ -233,3 +252,11 @@ func (m Model) selectableSpaces() []sb.Space {
 	}
 	return filtered
 }
+
+// scheduledAt returns the UTC publish_at for a slug in schedule mode ("" otherwise).
+func (m *Model) scheduledAt(slug string) string {
+	if m.getPublishMode(slug) != PublishModeSchedule {
+		return ""
+	}
+	return m.publishAt[slug]
+}
@@ -167,7 +167,7 @@ func (m Model) renderCompPreflightFooter() string {
 	}
 	status := fmt.Sprintf("create:%d update:%d skip:%d", cCreate, cUpdate, cSkip)
 	return renderFooter(status,
-		"j/k bewegen  |  space Skip/Apply  |  f Fork (umbenennen)  |  u Force-Update (Presets)  |  Enter Anwenden  |  b/Esc zurück",
+		"j/k bewegen  |  space Skip/Apply  |  f Fork (umbenennen)  |  r Rename übernehmen  |  u Force-Update (Presets)  |  Enter Anwenden  |  b/Esc zurück",
 		"Enter beendet Umbenennen | Esc abbrechen",
 	)
 }
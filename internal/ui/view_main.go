@@ -42,6 +42,8 @@ func (m Model) View() string {
 			b.WriteString(m.viewTokenPrompt())
 		case stateValidating:
 			b.WriteString(m.viewValidating())
+		case stateResumePrompt:
+			b.WriteString(m.viewResumePrompt())
 		case stateSpaceSelect:
 			b.WriteString(m.viewSpaceSelect())
 		case stateScanning:
@@ -52,6 +54,16 @@ func (m Model) View() string {
 			b.WriteString(m.viewCopyAsNew())
 		case stateFolderFork:
 			b.WriteString(m.viewFolderFork())
+		case stateReleasePicker:
+			b.WriteString(m.viewReleasePicker())
+		case stateLanguagePicker:
+			b.WriteString(m.viewLanguagePicker())
+		case stateReportPicker:
+			b.WriteString(m.viewReportPicker())
+		case stateMergeDiff:
+			b.WriteString(m.viewMergeDiff())
+		case stateConflictResolver:
+			b.WriteString(m.viewConflictResolver())
 		}
 		return lipgloss.JoinVertical(lipgloss.Left, header, b.String(), footer)
 	}
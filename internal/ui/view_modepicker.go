@@ -21,7 +21,7 @@ func (m Model) viewModePicker() string {
 	lines = append(lines, "")
 
 	// Options
-	options := []string{"Stories", "Components"}
+	options := []string{"Stories", "Components", "Stories + verwendete Components"}
 	for i, opt := range options {
 		marker := "  "
 		if i == m.modePickerIndex {
@@ -30,6 +30,6 @@ func (m Model) viewModePicker() string {
 		lines = append(lines, spaceItemStyle.Render(marker+opt))
 	}
 	content := strings.Join(lines, "\n")
-	help := renderFooter("", "⌨️  ↑↓/j/k: wählen  •  Enter: bestätigen  •  b/Esc: zurück  •  q: beenden")
+	help := renderFooter("", "⌨️  ↑↓/j/k: wählen  •  Enter: bestätigen  •  o: Report öffnen  •  b/Esc: zurück  •  q: beenden")
 	return title + "\n\n" + content + "\n\n" + help
 }
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	sync "storyblok-sync/internal/core/sync"
 )
 
 // Preflight is rendered via viewport header/content/footer.
@@ -45,7 +47,15 @@ func (m Model) renderPreflightHeader() string {
 			collisions++
 		}
 	}
-	return fmt.Sprintf("Preflight – %d Items  |  Kollisionen: %d", total, collisions)
+	release := "live"
+	if m.release != nil {
+		release = m.release.Name
+	}
+	header := fmt.Sprintf("Preflight – %d Items  |  Kollisionen: %d  |  Schema-Policy: %s  |  Release: %s  |  Sprachen: %s  |  Merge: %s", total, collisions, m.schemaPolicy, release, m.languagesLabel(), m.mergePolicy)
+	if m.fanOutActive() {
+		header += fmt.Sprintf("  |  Ziele: %d", m.targetCount())
+	}
+	return header
 }
 
 func (m Model) renderPreflightContent() string {
@@ -90,16 +100,28 @@ func (m Model) renderPreflightContent() string {
 				badges = append(badges, "[Pub]")
 			case PublishModePublishChanges:
 				badges = append(badges, "[Pub+∆]")
+			case PublishModeSchedule:
+				badges = append(badges, "[Plan "+m.formatScheduleLocal(m.publishAt[it.Story.FullSlug])+"]")
 			default:
 				badges = append(badges, "[Draft]")
 			}
-			if m.targetSpace != nil && m.targetSpace.PlanLevel == 999 {
+			if tgt := m.itemTarget(it); tgt != nil && tgt.PlanLevel == 999 {
 				badges = append(badges, "[Dev]")
 			}
 		}
+		if b := m.targetBadge(it); b != "" {
+			badges = append(badges, b)
+		}
 		if len(badges) > 0 {
 			content += " " + helpStyle.Render(strings.Join(badges, ""))
 		}
+		if n := len(it.Conflicts); n > 0 {
+			open := sync.UnresolvedConflicts(it.Conflicts)
+			content += " " + warnStyle.Render(fmt.Sprintf("[Konflikte %d/%d offen]", open, n))
+		}
+		if it.Issue != "" {
+			content += " " + warnStyle.Render("⚠ "+it.Issue)
+		}
 		content = lineStyle.Render(content)
 		cursorCell := " "
 		if visPos == m.preflight.listIndex {
@@ -124,6 +146,11 @@ func (m Model) renderPreflightContent() string {
 		}
 		lines[visPos] = cursorCell + stateCell + content
 	}
+	if section := m.renderCombinedCompSection(); len(section) > 0 {
+		lines = append(section, lines...)
+	}
+	// Combined per-target overview goes last so cursor line math stays untouched
+	lines = append(lines, m.renderFanOutOverview()...)
 	b.WriteString(strings.Join(lines, "\n"))
 	return b.String()
 }
@@ -132,16 +159,17 @@ func (m Model) renderPreflightFooter() string {
 	var statusLine string
 	if m.syncing {
 		statusLine = renderProgress(m.syncIndex, len(m.preflight.items), m.width-2)
+	} else if m.schedule.active {
+		statusLine = m.renderScheduleInput()
 	}
 
-	var helpText string
 	if m.syncing {
-		helpText = "Syncing... | Ctrl+C to cancel"
-	} else {
-		helpText = "j/k bewegen  |  f Fork  |  F Quick-Fork  |  p Publish/Draft/Pub+∆  |  P auf Geschwister/Unterordner anwenden  |  x skip  |  X alle skippen  |  c Skips entfernen  |  Enter OK  |  esc/q zurück"
+		return renderFooter(statusLine, "Syncing... | Ctrl+C to cancel")
 	}
-
-	return renderFooter(statusLine, helpText)
+	return renderFooter(statusLine,
+		"j/k bewegen  |  f/F Fork/Quick-Fork  |  p Publish/Draft/Pub+∆/Plan  |  t/T Zeitpunkt (Story/alle)  |  P auf Geschwister/Unterordner anwenden  |  x/X skip/alle  |  c Skips entfernen  |  Enter OK  |  esc/q zurück",
+		"v/V Schema prüfen/warn-block  |  w/W Workflow/Stage-Wechsel  |  R Release  |  i Sprachen  |  m Merge-Policy  |  d Feld-Diff  |  C/K Konflikte prüfen/auflösen",
+	)
 }
 
 // viewFolderFork renders the full-screen folder fork UI
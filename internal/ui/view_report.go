@@ -5,6 +5,9 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	sync "storyblok-sync/internal/core/sync"
+	"storyblok-sync/internal/report"
 )
 
 // removed unused viewReport (superseded by renderReport* functions)
@@ -16,17 +19,34 @@ func (m *Model) updateReportViewport() {
 
 func (m Model) renderReportHeader() string {
 	totalDuration := float64(m.report.Duration) / 1000.0
-	return fmt.Sprintf("Sync Report – %s | Duration: %.2fs | Source: %s | Target: %s",
+	header := fmt.Sprintf("Sync Report – %s | Duration: %.2fs | Source: %s | Target: %s",
 		m.report.GetDisplaySummary(), totalDuration, m.report.SourceSpace, m.report.TargetSpace)
+	if m.report.Release != "" {
+		header += fmt.Sprintf(" | Release: %s (ID %d) – im Storyblok-UI prüfen und mergen", m.report.Release, m.report.ReleaseID)
+	}
+	if len(m.report.Languages) > 0 {
+		header += " | Sprachen: " + strings.Join(m.report.Languages, ",")
+	}
+	if n := len(m.report.Targets); n > 1 {
+		header += fmt.Sprintf(" | Ziele: %d", n)
+	}
+	return header
 }
 
+// Report status styles
+var (
+	reportSuccessStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+	reportWarningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true)
+	reportErrorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
+)
+
 func (m Model) renderReportContent() string {
 	var b strings.Builder
 
 	// Statistics section with colored boxes
-	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
-	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true)
-	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
+	successStyle := reportSuccessStyle
+	warningStyle := reportWarningStyle
+	errorStyle := reportErrorStyle
 
 	statsBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -55,70 +75,115 @@ func (m Model) renderReportContent() string {
 	}
 	b.WriteString("\n\n")
 
-	if len(m.report.Entries) == 0 {
+	switch {
+	case len(m.report.Entries) == 0:
 		b.WriteString(subtleStyle.Render("No entries in report.") + "\n")
-	} else {
-		// Group entries by status for better organization
-		var successes, warnings, failures []ReportEntry
-		for _, entry := range m.report.Entries {
-			switch entry.Status {
-			case "success":
-				successes = append(successes, entry)
-			case "warning":
-				warnings = append(warnings, entry)
-			case "failure":
-				failures = append(failures, entry)
+	case len(m.report.Targets) > 1:
+		// Fan-out run: one section per target space, primary first
+		for _, t := range m.report.Targets {
+			entries := m.report.EntriesForTarget(t.ID)
+			ok, warn, fail := 0, 0, 0
+			for _, e := range entries {
+				switch e.Status {
+				case "success":
+					ok++
+				case "warning":
+					warn++
+				case "failure":
+					fail++
+				}
 			}
+			b.WriteString(listHeaderStyle.Render(fmt.Sprintf("→ %s (%d) – %d ok, %d Warnungen, %d Fehler", t.Name, t.ID, ok, warn, fail)) + "\n")
+			writeReportEntries(&b, entries)
+			b.WriteString("\n")
 		}
+	default:
+		writeReportEntries(&b, m.report.Entries)
+	}
 
-		// Show failures first (most important)
-		if len(failures) > 0 {
-			b.WriteString(errorStyle.Render("⚠ FAILURES") + "\n")
-			for _, entry := range failures {
-				duration := fmt.Sprintf("%dms", entry.Duration)
-				b.WriteString(fmt.Sprintf("  %s %s (%s) %s - %s\n",
-					symbolStory, entry.Slug, entry.Operation, duration, entry.Error))
-			}
-			b.WriteString("\n")
+	return b.String()
+}
+
+// writeReportEntries lists entries grouped by status: failures, warnings, successes.
+func writeReportEntries(b *strings.Builder, entries []ReportEntry) {
+	successStyle := reportSuccessStyle
+	warningStyle := reportWarningStyle
+	errorStyle := reportErrorStyle
+
+	// Group entries by status for better organization
+	var successes, warnings, failures []ReportEntry
+	for _, entry := range entries {
+		switch entry.Status {
+		case "success":
+			successes = append(successes, entry)
+		case "warning":
+			warnings = append(warnings, entry)
+		case "failure":
+			failures = append(failures, entry)
 		}
+	}
 
-		// Show warnings next
-		if len(warnings) > 0 {
-			b.WriteString(warningStyle.Render("⚠ WARNINGS") + "\n")
-			for _, entry := range warnings {
-				duration := fmt.Sprintf("%dms", entry.Duration)
-				b.WriteString(fmt.Sprintf("  %s %s (%s) %s - %s\n",
-					symbolStory, entry.Slug, entry.Operation, duration, entry.Warning))
+	// Show failures first (most important)
+	if len(failures) > 0 {
+		b.WriteString(errorStyle.Render("⚠ FAILURES") + "\n")
+		for _, entry := range failures {
+			duration := fmt.Sprintf("%dms", entry.Duration)
+			b.WriteString(fmt.Sprintf("  %s %s (%s) %s - %s\n",
+				symbolStory, entry.Slug, entry.Operation, duration, entry.Error))
+			if len(entry.WorkflowSteps) > 0 {
+				b.WriteString("      " + subtleStyle.Render("wf: "+report.WorkflowStepsText(entry.WorkflowSteps)) + "\n")
 			}
-			b.WriteString("\n")
 		}
+		b.WriteString("\n")
+	}
 
-		// Show all successes; rely on viewport for scrolling
-		if len(successes) > 0 {
-			b.WriteString(successStyle.Render("✓ SUCCESSES") + "\n")
-			for i := 0; i < len(successes); i++ {
-				entry := successes[i]
-				duration := fmt.Sprintf("%dms", entry.Duration)
-				symbol := symbolStory
-				if entry.TargetStory != nil && entry.TargetStory.IsFolder {
-					symbol = symbolFolder
-				}
-				extra := fmt.Sprintf("  · rl:%d", entry.RateLimit429)
-				b.WriteString(fmt.Sprintf("  %s %s (%s) %s%s\n",
-					symbol, entry.Slug, entry.Operation, duration, extra))
-			}
+	// Show warnings next
+	if len(warnings) > 0 {
+		b.WriteString(warningStyle.Render("⚠ WARNINGS") + "\n")
+		for _, entry := range warnings {
+			duration := fmt.Sprintf("%dms", entry.Duration)
+			b.WriteString(fmt.Sprintf("  %s %s (%s) %s - %s\n",
+				symbolStory, entry.Slug, entry.Operation, duration, entry.Warning))
 		}
+		b.WriteString("\n")
 	}
 
-	return b.String()
+	// Show all successes; rely on viewport for scrolling
+	if len(successes) > 0 {
+		b.WriteString(successStyle.Render("✓ SUCCESSES") + "\n")
+		for i := 0; i < len(successes); i++ {
+			entry := successes[i]
+			duration := fmt.Sprintf("%dms", entry.Duration)
+			symbol := symbolStory
+			if entry.TargetStory != nil && entry.TargetStory.IsFolder {
+				symbol = symbolFolder
+			}
+			extra := fmt.Sprintf("  · rl:%d", entry.RateLimit429)
+			if entry.PublishAt != "" {
+				extra += "  · plan: " + entry.PublishAt
+			}
+			if len(entry.CreatedTags) > 0 {
+				extra += "  · tags+: " + strings.Join(entry.CreatedTags, ", ")
+			}
+			if len(entry.WorkflowSteps) > 0 {
+				extra += "  · wf: " + report.WorkflowStepsText(entry.WorkflowSteps)
+			}
+			if n := len(entry.MergeConflicts); n > 0 {
+				extra += fmt.Sprintf("  · konflikte: %d (%d aufgelöst)", n, n-sync.UnresolvedConflicts(entry.MergeConflicts))
+			}
+			b.WriteString(fmt.Sprintf("  %s %s (%s) %s%s\n",
+				symbol, entry.Slug, entry.Operation, duration, extra))
+		}
+	}
 }
 
 func (m Model) renderReportFooter() string {
 	var helpText string
+	export := fmt.Sprintf("e export (%s)  |  f format", m.reportFormat)
 	if m.report.Summary.Failure > 0 {
-		helpText = "j/k scroll  |  pgup/pgdown blättern  |  r retry failures  |  enter/b back to scan  |  q exit"
+		helpText = "j/k scroll  |  pgup/pgdown blättern  |  r retry failures  |  " + export + "  |  enter/b back to scan  |  q exit"
 	} else {
-		helpText = "j/k scroll  |  pgup/pgdown blättern  |  enter/b back to scan  |  q exit"
+		helpText = "j/k scroll  |  pgup/pgdown blättern  |  " + export + "  |  enter/b back to scan  |  q exit"
 	}
 	return renderFooter("", helpText)
 }
@@ -92,6 +92,13 @@ func (m Model) viewSpaceSelect() string {
 		for i, sp := range visible {
 			var line string
 			spaceInfo := fmt.Sprintf("%s (ID: %d)", sp.Name, sp.ID)
+			if !m.selectingSource {
+				mark := "[ ] "
+				if m.fanOut.marked[sp.ID] {
+					mark = "[x] "
+				}
+				spaceInfo = mark + spaceInfo
+			}
 
 			if i == m.selectedIndex {
 				line = spaceSelectedStyle.Render("▶ " + spaceInfo)
@@ -103,7 +110,11 @@ func (m Model) viewSpaceSelect() string {
 	}
 
 	// Create footer that sits at the bottom
-	footer := renderFooter("", "⌨️  ↑↓/j/k: navigieren  •  Enter: auswählen  •  q: beenden")
+	help := "⌨️  ↑↓/j/k: navigieren  •  Enter: auswählen  •  q: beenden"
+	if !m.selectingSource {
+		help = "⌨️  ↑↓/j/k: navigieren  •  Space: weiteres Ziel markieren  •  Enter: primäres Ziel wählen  •  q: beenden"
+	}
+	footer := renderFooter("", help)
 
 	// Calculate available height for content (total height - header - footer - margins)
 	contentHeight := m.height - 4 // rough estimate for header and footer space
@@ -123,8 +134,11 @@ func (m Model) viewSpaceSelect() string {
 
 func (m Model) viewScanning() string {
 	title := "🔄 Scanne Stories"
-	if m.currentMode == modeComponents {
+	switch m.currentMode {
+	case modeComponents:
 		title = "🔄 Scanne Components"
+	case modeCombined:
+		title = "🔄 Scanne Stories & Components"
 	}
 	header := listHeaderStyle.Render(title)
 
@@ -138,8 +152,11 @@ func (m Model) viewScanning() string {
 	}
 
 	loading := "Lade Stories aus beiden Spaces..."
-	if m.currentMode == modeComponents {
+	switch m.currentMode {
+	case modeComponents:
 		loading = "Lade Components & Gruppen aus beiden Spaces..."
+	case modeCombined:
+		loading = "Lade Stories, Components & Gruppen aus beiden Spaces..."
 	}
 	content := fmt.Sprintf("%s %s\n\n", m.spinner.View(), subtitleStyle.Render(loading))
 	content += fmt.Sprintf("📂 Source: %s\n", okStyle.Render(src))
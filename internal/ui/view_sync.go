@@ -179,10 +179,13 @@ func (m *Model) updateSyncViewport() {
 			default:
 				badges = " [Draft]"
 			}
-			if m.targetSpace != nil && m.targetSpace.PlanLevel == 999 {
+			if tgt := m.itemTarget(item); tgt != nil && tgt.PlanLevel == 999 {
 				badges += "[Dev]"
 			}
 		}
+		if b := m.targetBadge(item); b != "" {
+			badges += " " + b
+		}
 
 		line := fmt.Sprintf("%s %s%s | %s (%s)",
 			color.Render(status),